@@ -3,6 +3,7 @@
 // Package assets generated by go-bindata.
 // sources:
 // templates/cloudformation/iam_user_osdCcsAdmin.json
+// templates/pricing/aws.json
 package assets
 
 import (
@@ -85,6 +86,133 @@ func templatesCloudformationIam_user_osdccsadminJson() (*asset, error) {
 	return a, nil
 }
 
+var _templatesPricingAwsJson = []byte(`{
+  "currency": "USD",
+  "hoursPerMonth": 730,
+  "defaultRegion": "us-east-1",
+  "serviceFees": {
+    "classicClusterHourly": 0.03,
+    "hostedControlPlaneHourly": 0.25,
+    "workerVCPUHourly": 0.04275
+  },
+  "controlPlane": {
+    "instanceType": "m5.2xlarge",
+    "replicas": 3
+  },
+  "infra": {
+    "instanceType": "r5.xlarge",
+    "replicas": 2,
+    "multiAZReplicas": 3
+  },
+  "spotPriceRatio": {
+    "default": 0.35,
+    "c5": 0.38,
+    "c6i": 0.38,
+    "g4dn": 0.30,
+    "m5": 0.36,
+    "m5a": 0.40,
+    "m6a": 0.40,
+    "m6g": 0.38,
+    "m6i": 0.36,
+    "p3": 0.30,
+    "r5": 0.32,
+    "r6i": 0.32
+  },
+  "regions": {
+    "af-south-1": 1.19,
+    "ap-east-1": 1.38,
+    "ap-northeast-1": 1.29,
+    "ap-northeast-2": 1.23,
+    "ap-northeast-3": 1.29,
+    "ap-south-1": 1.05,
+    "ap-southeast-1": 1.25,
+    "ap-southeast-2": 1.25,
+    "ca-central-1": 1.11,
+    "eu-central-1": 1.20,
+    "eu-north-1": 1.06,
+    "eu-south-1": 1.17,
+    "eu-west-1": 1.11,
+    "eu-west-2": 1.16,
+    "eu-west-3": 1.17,
+    "me-south-1": 1.22,
+    "sa-east-1": 1.59,
+    "us-east-1": 1.00,
+    "us-east-2": 1.00,
+    "us-gov-east-1": 1.26,
+    "us-gov-west-1": 1.26,
+    "us-west-1": 1.17,
+    "us-west-2": 1.00
+  },
+  "instanceTypes": {
+    "c5.xlarge": {"vcpu": 4, "memoryGiB": 8, "onDemand": 0.17},
+    "c5.2xlarge": {"vcpu": 8, "memoryGiB": 16, "onDemand": 0.34},
+    "c5.4xlarge": {"vcpu": 16, "memoryGiB": 32, "onDemand": 0.68},
+    "c5.9xlarge": {"vcpu": 36, "memoryGiB": 72, "onDemand": 1.53},
+    "c5.12xlarge": {"vcpu": 48, "memoryGiB": 96, "onDemand": 2.04},
+    "c5.18xlarge": {"vcpu": 72, "memoryGiB": 144, "onDemand": 3.06},
+    "c5.24xlarge": {"vcpu": 96, "memoryGiB": 192, "onDemand": 4.08},
+    "c6i.xlarge": {"vcpu": 4, "memoryGiB": 8, "onDemand": 0.17},
+    "c6i.2xlarge": {"vcpu": 8, "memoryGiB": 16, "onDemand": 0.34},
+    "c6i.4xlarge": {"vcpu": 16, "memoryGiB": 32, "onDemand": 0.68},
+    "c6i.8xlarge": {"vcpu": 32, "memoryGiB": 64, "onDemand": 1.36},
+    "g4dn.xlarge": {"vcpu": 4, "memoryGiB": 16, "onDemand": 0.526},
+    "g4dn.2xlarge": {"vcpu": 8, "memoryGiB": 32, "onDemand": 0.752},
+    "g4dn.4xlarge": {"vcpu": 16, "memoryGiB": 64, "onDemand": 1.204},
+    "g4dn.8xlarge": {"vcpu": 32, "memoryGiB": 128, "onDemand": 2.176},
+    "g4dn.12xlarge": {"vcpu": 48, "memoryGiB": 192, "onDemand": 3.912},
+    "m5.xlarge": {"vcpu": 4, "memoryGiB": 16, "onDemand": 0.192},
+    "m5.2xlarge": {"vcpu": 8, "memoryGiB": 32, "onDemand": 0.384},
+    "m5.4xlarge": {"vcpu": 16, "memoryGiB": 64, "onDemand": 0.768},
+    "m5.8xlarge": {"vcpu": 32, "memoryGiB": 128, "onDemand": 1.536},
+    "m5.12xlarge": {"vcpu": 48, "memoryGiB": 192, "onDemand": 2.304},
+    "m5.16xlarge": {"vcpu": 64, "memoryGiB": 256, "onDemand": 3.072},
+    "m5.24xlarge": {"vcpu": 96, "memoryGiB": 384, "onDemand": 4.608},
+    "m5a.xlarge": {"vcpu": 4, "memoryGiB": 16, "onDemand": 0.172},
+    "m5a.2xlarge": {"vcpu": 8, "memoryGiB": 32, "onDemand": 0.344},
+    "m5a.4xlarge": {"vcpu": 16, "memoryGiB": 64, "onDemand": 0.688},
+    "m5a.8xlarge": {"vcpu": 32, "memoryGiB": 128, "onDemand": 1.376},
+    "m6a.xlarge": {"vcpu": 4, "memoryGiB": 16, "onDemand": 0.1728},
+    "m6a.2xlarge": {"vcpu": 8, "memoryGiB": 32, "onDemand": 0.3456},
+    "m6a.4xlarge": {"vcpu": 16, "memoryGiB": 64, "onDemand": 0.6912},
+    "m6g.xlarge": {"vcpu": 4, "memoryGiB": 16, "onDemand": 0.154},
+    "m6g.2xlarge": {"vcpu": 8, "memoryGiB": 32, "onDemand": 0.308},
+    "m6g.4xlarge": {"vcpu": 16, "memoryGiB": 64, "onDemand": 0.616},
+    "m6g.8xlarge": {"vcpu": 32, "memoryGiB": 128, "onDemand": 1.232},
+    "m6i.xlarge": {"vcpu": 4, "memoryGiB": 16, "onDemand": 0.192},
+    "m6i.2xlarge": {"vcpu": 8, "memoryGiB": 32, "onDemand": 0.384},
+    "m6i.4xlarge": {"vcpu": 16, "memoryGiB": 64, "onDemand": 0.768},
+    "m6i.8xlarge": {"vcpu": 32, "memoryGiB": 128, "onDemand": 1.536},
+    "p3.2xlarge": {"vcpu": 8, "memoryGiB": 61, "onDemand": 3.06},
+    "p3.8xlarge": {"vcpu": 32, "memoryGiB": 244, "onDemand": 12.24},
+    "r5.xlarge": {"vcpu": 4, "memoryGiB": 32, "onDemand": 0.252},
+    "r5.2xlarge": {"vcpu": 8, "memoryGiB": 64, "onDemand": 0.504},
+    "r5.4xlarge": {"vcpu": 16, "memoryGiB": 128, "onDemand": 1.008},
+    "r5.8xlarge": {"vcpu": 32, "memoryGiB": 256, "onDemand": 2.016},
+    "r5.12xlarge": {"vcpu": 48, "memoryGiB": 384, "onDemand": 3.024},
+    "r5.16xlarge": {"vcpu": 64, "memoryGiB": 512, "onDemand": 4.032},
+    "r6i.xlarge": {"vcpu": 4, "memoryGiB": 32, "onDemand": 0.252},
+    "r6i.2xlarge": {"vcpu": 8, "memoryGiB": 64, "onDemand": 0.504},
+    "r6i.4xlarge": {"vcpu": 16, "memoryGiB": 128, "onDemand": 1.008},
+    "r6i.8xlarge": {"vcpu": 32, "memoryGiB": 256, "onDemand": 2.016}
+  }
+}
+`)
+
+func templatesPricingAwsJsonBytes() ([]byte, error) {
+	return _templatesPricingAwsJson, nil
+}
+
+func templatesPricingAwsJson() (*asset, error) {
+	bytes, err := templatesPricingAwsJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/pricing/aws.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -138,6 +266,7 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"templates/cloudformation/iam_user_osdCcsAdmin.json": templatesCloudformationIam_user_osdccsadminJson,
+	"templates/pricing/aws.json":                         templatesPricingAwsJson,
 }
 
 // AssetDir returns the file names below a certain
@@ -185,6 +314,9 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"cloudformation": &bintree{nil, map[string]*bintree{
 			"iam_user_osdCcsAdmin.json": &bintree{templatesCloudformationIam_user_osdccsadminJson, map[string]*bintree{}},
 		}},
+		"pricing": &bintree{nil, map[string]*bintree{
+			"aws.json": &bintree{templatesPricingAwsJson, map[string]*bintree{}},
+		}},
 	}},
 }}
 
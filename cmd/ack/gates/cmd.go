@@ -0,0 +1,284 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gates
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	semver "github.com/hashicorp/go-version"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	upgradecluster "github.com/openshift/rosa/cmd/upgrade/cluster"
+	"github.com/openshift/rosa/pkg/helper/verify"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	version     string
+	allClusters bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "gates",
+	Aliases: []string{"gate"},
+	Short:   "Acknowledge version gates",
+	Long: "Acknowledge the version gates that need to be agreed to before upgrading clusters to an " +
+		"OpenShift version",
+	Example: `  # Acknowledge the gates of version 4.14 for the cluster named "mycluster"
+  rosa ack gates --version 4.14 -c mycluster
+
+  # Acknowledge the gates of version 4.14 for all the clusters and record them for audit
+  rosa ack gates --version 4.14 --all-clusters --yes -o json`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddOptionalClusterFlag(Cmd)
+
+	flags.BoolVar(
+		&args.allClusters,
+		"all-clusters",
+		false,
+		"Acknowledge the gates for all the clusters.",
+	)
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"OpenShift version that the clusters will be upgraded to, for example '4.14'.",
+	)
+	Cmd.MarkFlagRequired("version")
+
+	output.AddFlag(Cmd)
+	confirm.AddFlag(flags)
+}
+
+// acknowledgement records who acknowledged a gate for a cluster
+type acknowledgement struct {
+	ClusterID        string    `json:"cluster_id"`
+	ClusterName      string    `json:"cluster_name"`
+	GateID           string    `json:"gate_id"`
+	Version          string    `json:"version"`
+	Description      string    `json:"description"`
+	DocumentationURL string    `json:"documentation_url,omitempty"`
+	WarningMessage   string    `json:"warning_message,omitempty"`
+	STSOnly          bool      `json:"sts_only"`
+	AcknowledgedBy   string    `json:"acknowledged_by"`
+	AcknowledgedAt   time.Time `json:"acknowledged_at"`
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := ""
+	if cmd.Flags().Changed("cluster") {
+		clusterKey = r.GetClusterKey()
+	}
+	if args.allClusters == (clusterKey != "") {
+		r.Reporter.Errorf("Expected either the '--cluster' or the '--all-clusters' option")
+		os.Exit(1)
+	}
+	if output.HasFlag() && !confirm.Yes() {
+		r.Reporter.Errorf("The '--output' option requires the '--yes' option")
+		os.Exit(1)
+	}
+
+	version, err := semver.NewVersion(args.version)
+	if err != nil {
+		r.Reporter.Errorf("Invalid version '%s': %v", args.version, err)
+		os.Exit(1)
+	}
+	minor := fmt.Sprintf("%d.%d", version.Segments()[0], version.Segments()[1])
+
+	versionGates, err := r.OCMClient.ListAllOcpGates(minor)
+	if err != nil {
+		r.Reporter.Errorf("Failed to fetch gates for version %s: %v", minor, err)
+		os.Exit(1)
+	}
+
+	var clusters []*cmv1.Cluster
+	if args.allClusters {
+		clusters, err = r.OCMClient.GetClusters(r.Creator, 100)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get clusters: %v", err)
+			os.Exit(1)
+		}
+	} else {
+		cluster := r.FetchCluster()
+		if cluster.State() != cmv1.ClusterStateReady {
+			r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+			os.Exit(1)
+		}
+		clusters = []*cmv1.Cluster{cluster}
+	}
+
+	account, err := r.OCMClient.GetCurrentAccount()
+	if err != nil {
+		r.Reporter.Errorf("Failed to get current account: %v", err)
+		os.Exit(1)
+	}
+
+	acknowledgements := []acknowledgement{}
+	for _, cluster := range clusters {
+		if cluster.State() != cmv1.ClusterStateReady {
+			r.Reporter.Warnf("Skipping cluster '%s' because it is not ready", cluster.Name())
+			continue
+		}
+		clusterMinor, err := semver.NewVersion(ocm.GetVersionMinor(cluster.Version().RawID()))
+		if err != nil {
+			r.Reporter.Warnf("Skipping cluster '%s' because its version '%s' is invalid: %v",
+				cluster.Name(), cluster.Version().RawID(), err)
+			continue
+		}
+		if !clusterMinor.LessThan(semver.Must(semver.NewVersion(minor))) {
+			r.Reporter.Infof("Cluster '%s' is already at version %s", cluster.Name(), cluster.Version().RawID())
+			continue
+		}
+
+		agreements, err := r.OCMClient.GetGateAgreements(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get acknowledged gates of cluster '%s': %v", cluster.Name(), err)
+			os.Exit(1)
+		}
+		_, isSTS := cluster.AWS().STS().GetRoleARN()
+		missingGates := ocm.FilterMissingGates(versionGates, agreements, isSTS)
+		if isSTS {
+			missingGates = filterSTSGates(r, cluster, minor, missingGates)
+		}
+		if len(missingGates) == 0 {
+			r.Reporter.Infof("There are no gates of version %s to acknowledge for cluster '%s'",
+				minor, cluster.Name())
+			continue
+		}
+
+		if !output.HasFlag() {
+			printGates(r, cluster, minor, missingGates)
+		}
+		if !confirm.Confirm("acknowledge %d gates of version %s for cluster '%s'", len(missingGates), minor,
+			cluster.Name()) {
+			continue
+		}
+		for _, gate := range missingGates {
+			err = r.OCMClient.AckVersionGate(cluster.ID(), gate.ID())
+			if err != nil {
+				r.Reporter.Errorf("Failed to acknowledge gate '%s' for cluster '%s': %v",
+					gate.ID(), cluster.Name(), err)
+				os.Exit(1)
+			}
+			acknowledgements = append(acknowledgements, acknowledgement{
+				ClusterID:        cluster.ID(),
+				ClusterName:      cluster.Name(),
+				GateID:           gate.ID(),
+				Version:          gate.VersionRawIDPrefix(),
+				Description:      gate.Description(),
+				DocumentationURL: gate.DocumentationURL(),
+				WarningMessage:   gate.WarningMessage(),
+				STSOnly:          gate.STSOnly(),
+				AcknowledgedBy:   account.Username(),
+				AcknowledgedAt:   time.Now().UTC(),
+			})
+		}
+		if !output.HasFlag() {
+			r.Reporter.Infof("Acknowledged %d gates of version %s for cluster '%s'", len(missingGates), minor,
+				cluster.Name())
+		}
+	}
+
+	if output.HasFlag() {
+		err = output.Print(map[string]interface{}{
+			"version":          minor,
+			"acknowledgements": acknowledgements,
+		})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// filterSTSGates drops the STS gates when the roles of the cluster are not ready for the version,
+// running the same role checks as the upgrade preflight
+func filterSTSGates(r *rosa.Runtime, cluster *cmv1.Cluster, version string,
+	gates []*cmv1.VersionGate) []*cmv1.VersionGate {
+	hasSTSGates := false
+	for _, gate := range gates {
+		if gate.STSOnly() {
+			hasSTSGates = true
+			break
+		}
+	}
+	if !hasSTSGates {
+		return gates
+	}
+
+	failures := []string{}
+	for _, check := range upgradecluster.PreflightRoles(r, cluster, version) {
+		if check.Result == verify.Failed {
+			failures = append(failures, check.Message)
+		}
+	}
+	if len(failures) == 0 {
+		return gates
+	}
+	r.Reporter.Warnf("Skipping the STS gates of version %s for cluster '%s': %s", version, cluster.Name(),
+		strings.Join(failures, " "))
+	r.Reporter.Warnf("Run 'rosa upgrade roles -c %s --cluster-version=%s' and acknowledge the gates again",
+		cluster.ID(), version)
+	filtered := []*cmv1.VersionGate{}
+	for _, gate := range gates {
+		if !gate.STSOnly() {
+			filtered = append(filtered, gate)
+		}
+	}
+	return filtered
+}
+
+func printGates(r *rosa.Runtime, cluster *cmv1.Cluster, version string, versionGates []*cmv1.VersionGate) {
+	steps := []string{}
+	for _, gate := range versionGates {
+		step := fmt.Sprintf("Description: %s\n", gate.Description())
+		if gate.WarningMessage() != "" {
+			step = fmt.Sprintf("%s"+
+				"    Warning:     %s\n", step, gate.WarningMessage())
+		}
+		step = fmt.Sprintf("%s"+
+			"    URL:         %s\n", step, gate.DocumentationURL())
+		steps = append(steps, step)
+	}
+	err := interactive.PrintHelp(interactive.Help{
+		Message: fmt.Sprintf("Read the descriptions of the gates of version %s for cluster '%s' "+
+			"before acknowledging them", version, cluster.Name()),
+		Steps: steps,
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to print gates: %v", err)
+		os.Exit(1)
+	}
+}
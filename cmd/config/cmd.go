@@ -0,0 +1,40 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/config/deletecontext"
+	"github.com/openshift/rosa/cmd/config/getcontexts"
+	"github.com/openshift/rosa/cmd/config/setcontext"
+	"github.com/openshift/rosa/cmd/config/usecontext"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the configuration",
+	Long: "Manage the configuration. Contexts are named sets of defaults for the environment, " +
+		"AWS profile, AWS region and cluster used by commands when the corresponding options aren't given.",
+}
+
+func init() {
+	Cmd.AddCommand(setcontext.Cmd)
+	Cmd.AddCommand(usecontext.Cmd)
+	Cmd.AddCommand(getcontexts.Cmd)
+	Cmd.AddCommand(deletecontext.Cmd)
+}
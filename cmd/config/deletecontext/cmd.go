@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletecontext
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "delete-context NAME",
+	Short: "Delete a context",
+	Long:  "Delete a context. Commands stop using its defaults if it was in use.",
+	Example: `  # Delete the context of the production clusters
+  rosa config delete-context prod`,
+	Args: cobra.ExactArgs(1),
+	Run:  run,
+}
+
+func init() {
+	confirm.AddFlag(Cmd.Flags())
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+	name := argv[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		r.Reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	if cfg.GetContext(name) == nil {
+		r.Reporter.Errorf("There is no context named '%s'", name)
+		os.Exit(1)
+	}
+	if !confirm.Confirm("delete context %s", name) {
+		os.Exit(0)
+	}
+	err = cfg.DeleteContext(name)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	err = config.Save(cfg)
+	if err != nil {
+		r.Reporter.Errorf("Failed to save config file: %v", err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Deleted context '%s'", name)
+}
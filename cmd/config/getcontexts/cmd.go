@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getcontexts
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "get-contexts",
+	Aliases: []string{"get-context"},
+	Short:   "List contexts",
+	Long:    "List the contexts of the configuration, marking the one in use.",
+	Example: `  # List the contexts
+  rosa config get-contexts`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	cfg, err := config.Load()
+	if err != nil {
+		r.Reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	names := cfg.ContextNames()
+	if len(names) == 0 {
+		r.Reporter.Infof("There are no contexts, run 'rosa config set-context' to create one")
+		os.Exit(0)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CURRENT\tNAME\tENV\tPROFILE\tREGION\tCLUSTER\n")
+	for _, name := range names {
+		context := cfg.GetContext(name)
+		current := ""
+		if name == cfg.CurrentContext {
+			current = "*"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			current,
+			name,
+			context.Env,
+			context.Profile,
+			context.Region,
+			context.Cluster,
+		)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package setcontext
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	env     string
+	profile string
+	region  string
+	cluster string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "set-context NAME",
+	Short: "Create or update a context",
+	Long: "Create or update a context. Only the options given are changed in existing contexts, " +
+		"an empty value removes an option.",
+	Example: `  # Create a context for the production clusters
+  rosa config set-context prod --env production --profile prod-aws --region us-east-1 --cluster main
+
+  # Change the default cluster of the context
+  rosa config set-context prod --cluster other`,
+	Args: cobra.ExactArgs(1),
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(
+		&args.env,
+		"env",
+		"",
+		"Environment of the API gateway, used by 'rosa login'. The value can be the complete URL or an alias. "+
+			"The valid aliases are 'production', 'staging' and 'integration'.",
+	)
+	flags.StringVar(
+		&args.profile,
+		"profile",
+		"",
+		"AWS profile used when the '--profile' option and the AWS_PROFILE environment variable aren't set.",
+	)
+	flags.StringVar(
+		&args.region,
+		"region",
+		"",
+		"AWS region used when the '--region' option and the AWS_REGION environment variable aren't set.",
+	)
+	flags.StringVarP(
+		&args.cluster,
+		"cluster",
+		"c",
+		"",
+		"Name or ID of the cluster used by commands that require one when the '--cluster' option isn't set.",
+	)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+	name := argv[0]
+
+	err := config.ValidateContextName(name)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	err = validateEnv(args.env)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	if args.cluster != "" && !ocm.IsValidClusterKey(args.cluster) {
+		r.Reporter.Errorf("Cluster name, identifier or external identifier '%s' isn't valid: it "+
+			"must contain only letters, digits, dashes and underscores", args.cluster)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		r.Reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	if cfg == nil {
+		cfg = new(config.Config)
+	}
+
+	context := cfg.GetContext(name)
+	created := context == nil
+	if created {
+		context = &config.Context{}
+	}
+	if cmd.Flags().Changed("env") {
+		context.Env = args.env
+	}
+	if cmd.Flags().Changed("profile") {
+		context.Profile = args.profile
+	}
+	if cmd.Flags().Changed("region") {
+		context.Region = args.region
+	}
+	if cmd.Flags().Changed("cluster") {
+		context.Cluster = args.cluster
+	}
+
+	err = cfg.SetContext(name, context)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	err = config.Save(cfg)
+	if err != nil {
+		r.Reporter.Errorf("Failed to save config file: %v", err)
+		os.Exit(1)
+	}
+
+	if created {
+		r.Reporter.Infof("Created context '%s'", name)
+	} else {
+		r.Reporter.Infof("Updated context '%s'", name)
+	}
+	if cfg.CurrentContext != name {
+		r.Reporter.Infof("Run 'rosa config use-context %s' to use it", name)
+	}
+}
+
+func validateEnv(env string) error {
+	if env == "" || strings.HasPrefix(env, "https://") || strings.HasPrefix(env, "http://") {
+		return nil
+	}
+	if _, ok := ocm.URLAliases[env]; ok {
+		return nil
+	}
+	return fmt.Errorf("Environment '%s' isn't valid: it must be a URL or one of the aliases "+
+		"'production', 'staging' and 'integration'", env)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usecontext
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "use-context NAME",
+	Short: "Use a context",
+	Long:  "Use the defaults of a context in the following commands.",
+	Example: `  # Use the context of the production clusters
+  rosa config use-context prod`,
+	Args: cobra.ExactArgs(1),
+	Run:  run,
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+	name := argv[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		r.Reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	err = cfg.UseContext(name)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	err = config.Save(cfg)
+	if err != nil {
+		r.Reporter.Errorf("Failed to save config file: %v", err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Switched to context '%s'", name)
+
+	// The credentials aren't part of the context, so they may be for another environment
+	context := cfg.GetContext(name)
+	if context.Env == "" {
+		return
+	}
+	url, ok := ocm.URLAliases[context.Env]
+	if !ok {
+		url = context.Env
+	}
+	if cfg.URL != "" && cfg.URL != url {
+		r.Reporter.Warnf("You are logged in to another environment. Run 'rosa login --env %s' to "+
+			"log in to the environment of the context", context.Env)
+	}
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/pricing"
 	"github.com/openshift/rosa/pkg/properties"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -2256,6 +2257,7 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Infof(
 			"Creating cluster '%s' should succeed. Run without the '--dry-run' flag to create the cluster.",
 			clusterName)
+		printCostEstimate(r, clusterConfig)
 		os.Exit(0)
 	}
 
@@ -2741,3 +2743,41 @@ func buildCommand(spec ocm.Spec, operatorRolesPrefix string,
 func getRolePrefix(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, helper.RandomLabel(4))
 }
+
+// printCostEstimate prints the estimated cost of the cluster described by the spec using the
+// bundled price catalog. Failing to estimate the cost never prevents creating the cluster.
+func printCostEstimate(r *rosa.Runtime, clusterConfig ocm.Spec) {
+	catalog, err := pricing.LoadCatalog()
+	if err != nil {
+		r.Reporter.Warnf("Unable to estimate the cost of cluster '%s': %v", clusterConfig.Name, err)
+		return
+	}
+	compute := pricing.NodeGroup{
+		Name:         "worker",
+		Role:         pricing.ComputeRole,
+		InstanceType: clusterConfig.ComputeMachineType,
+		MinReplicas:  clusterConfig.ComputeNodes,
+		MaxReplicas:  clusterConfig.ComputeNodes,
+	}
+	if clusterConfig.Autoscaling {
+		compute.MinReplicas = clusterConfig.MinReplicas
+		compute.MaxReplicas = clusterConfig.MaxReplicas
+	}
+	groups := append(catalog.ClusterNodeGroups(clusterConfig.MultiAZ, clusterConfig.Hypershift.Enabled), compute)
+	estimate, err := catalog.Estimate(clusterConfig.Region, clusterConfig.Hypershift.Enabled, true, groups)
+	if err != nil {
+		r.Reporter.Warnf("Unable to estimate the cost of cluster '%s': %v", clusterConfig.Name, err)
+		return
+	}
+	if output.HasFlag() {
+		err = output.Print(estimate)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("Estimated cost of cluster '%s' in region '%s', excluding storage, "+
+		"networking and data transfer:", clusterConfig.Name, clusterConfig.Region)
+	estimate.Print(os.Stdout)
+}
@@ -26,6 +26,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/helper/verify"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -78,10 +79,13 @@ var args struct {
 	// HTPasswd
 	htpasswdUsername string
 	htpasswdPassword string
+	htpasswdFile     string
+
+	verify bool
 }
 
 var validIdps = []string{"github", "gitlab", "google", "htpasswd", "ldap", "openid"}
-var validMappingMethods = []string{"add", "claim", "generate", "lookup"}
+var ValidMappingMethods = []string{"add", "claim", "generate", "lookup"}
 
 var idRE = regexp.MustCompile(`(?i)^[0-9a-z]+([-_][0-9a-z]+)*$`)
 
@@ -92,6 +96,13 @@ var Cmd = &cobra.Command{
 	Example: `  # Add a GitHub identity provider to a cluster named "mycluster"
   rosa create idp --type=github --cluster=mycluster
 
+  # Add an HTPasswd identity provider with the users of a file to a cluster named "mycluster"
+  rosa create idp --type=htpasswd --from-file=users.htpasswd --cluster=mycluster
+
+  # Add an OpenID identity provider to a cluster named "mycluster" after checking its configuration
+  rosa create idp --type=openid --issuer-url=https://example.com --client-id=abc --client-secret=xyz \
+    --email-claims=email --cluster=mycluster --verify
+
   # Add an identity provider following interactive prompts
   rosa create idp --cluster=mycluster --interactive`,
 	Run: run,
@@ -125,7 +136,7 @@ func init() {
 		"claim",
 		fmt.Sprintf(
 			"Specifies how new identities are mapped to users when they log in. Options are %s",
-			validMappingMethods,
+			ValidMappingMethods,
 		),
 	)
 	flags.StringVar(
@@ -290,6 +301,22 @@ func init() {
 			"- Be at least 14 characters (ASCII-standard) without whitespaces\n"+
 			"- Include uppercase letters, lowercase letters, and numbers or symbols (ASCII-standard characters only)",
 	)
+	flags.StringVar(
+		&args.htpasswdFile,
+		"from-file",
+		"",
+		"HTPasswd: Path to a file with the users to add. Files with a '.csv' extension contain "+
+			"'username,password' records,\nany other file is read as an htpasswd file with plain text "+
+			"'username:password' lines.",
+	)
+
+	flags.BoolVar(
+		&args.verify,
+		"verify",
+		false,
+		"Check that the identity provider can reach its external services before creating it. "+
+			"See 'rosa verify idp --help'.",
+	)
 
 	interactive.AddFlag(flags)
 }
@@ -394,6 +421,10 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if args.verify {
+		verifyIDP(idpName, idpBuilder, cluster, clusterKey, r)
+	}
+
 	doCreateIDP(idpName, idpBuilder, cluster, clusterKey, r)
 }
 
@@ -411,6 +442,26 @@ func getIDPName(cmd *cobra.Command, idpName string, r *rosa.Runtime) string {
 	return strings.Trim(idpName, " \t")
 }
 
+func verifyIDP(
+	idpName string,
+	idpBuilder cmv1.IdentityProviderBuilder,
+	cluster *cmv1.Cluster, clusterKey string,
+	r *rosa.Runtime) {
+	idp, err := idpBuilder.Build()
+	if err != nil {
+		r.Reporter.Errorf("Failed to create IDP for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Infof("Verifying IDP '%s'", idpName)
+	checks := VerifyIdentityProvider(cluster, idp, VerifyOptions{})
+	verify.PrintReport(checks)
+	if verify.HasFailures(checks) {
+		r.Reporter.Errorf("IDP '%s' failed verification and has not been created", idpName)
+		os.Exit(1)
+	}
+}
+
 func doCreateIDP(
 	idpName string,
 	idpBuilder cmv1.IdentityProviderBuilder,
@@ -491,19 +542,19 @@ func getMappingMethod(cmd *cobra.Command, mappingMethod string) (string, error)
 		mappingMethod, err = interactive.GetOption(interactive.Input{
 			Question: "Mapping method",
 			Help:     usage,
-			Options:  validMappingMethods,
+			Options:  ValidMappingMethods,
 			Default:  mappingMethod,
 			Required: true,
 		})
 	}
 	isValidMappingMethod := false
-	for _, validMappingMethod := range validMappingMethods {
+	for _, validMappingMethod := range ValidMappingMethods {
 		if mappingMethod == validMappingMethod {
 			isValidMappingMethod = true
 		}
 	}
 	if !isValidMappingMethod {
-		err = fmt.Errorf("Expected a valid mapping method. Options are %s", validMappingMethods)
+		err = fmt.Errorf("Expected a valid mapping method. Options are %s", ValidMappingMethods)
 	}
 	return mappingMethod, err
 }
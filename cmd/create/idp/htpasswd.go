@@ -41,6 +41,22 @@ func createHTPasswdIDP(cmd *cobra.Command,
 	username := args.htpasswdUsername
 	password := args.htpasswdPassword
 
+	var fileUsers []*cmv1.HTPasswdUser
+	if args.htpasswdFile != "" {
+		if username != "" || password != "" {
+			r.Reporter.Errorf("Setting `username` or `password` is not supported together with `from-file`")
+			os.Exit(1)
+		}
+		users, err := ReadHTPasswdFile(args.htpasswdFile)
+		if err == nil {
+			fileUsers, err = BuildHTPasswdUsers(users)
+		}
+		if err != nil {
+			r.Reporter.Errorf("Failed to create IDP for cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+	}
+
 	// Choose which way to create the IDP according to whether it already has an admin or not.
 	htpasswdIDP, userList := FindExistingHTPasswdIDP(cluster, r)
 	if htpasswdIDP != nil {
@@ -70,6 +86,16 @@ func createHTPasswdIDP(cmd *cobra.Command,
 		// Existing IDP contains only admin. Add new user to it
 		r.Reporter.Infof("Cluster already has an HTPasswd IDP named '%s', new users will be added to it.",
 			htpasswdIDP.Name())
+		if fileUsers != nil {
+			err = r.OCMClient.ImportHTPasswdUsers(cluster.ID(), htpasswdIDP.ID(), fileUsers)
+			if err != nil {
+				r.Reporter.Errorf(
+					"Failed to add users to the HTPasswd IDP of cluster '%s': %v", clusterKey, err)
+				os.Exit(1)
+			}
+			r.Reporter.Infof("%d users added", len(fileUsers))
+			return
+		}
 		if username == "" || password == "" {
 			r.Reporter.Infof("At least one user is required to create the IDP.")
 			username, password = getUserDetails(cmd, r)
@@ -83,9 +109,16 @@ func createHTPasswdIDP(cmd *cobra.Command,
 		r.Reporter.Infof("User '%s' added", username)
 	} else {
 		// HTPasswd IDP does not exist - create it
-		if username == "" || password == "" {
-			r.Reporter.Infof("At least one user is required to create the IDP.")
-			username, password = getUserDetails(cmd, r)
+		userBuilders := []*cmv1.HTPasswdUserBuilder{}
+		for _, user := range fileUsers {
+			userBuilders = append(userBuilders, CreateHTPasswdUser(user.Username(), user.Password()))
+		}
+		if len(userBuilders) == 0 {
+			if username == "" || password == "" {
+				r.Reporter.Infof("At least one user is required to create the IDP.")
+				username, password = getUserDetails(cmd, r)
+			}
+			userBuilders = append(userBuilders, CreateHTPasswdUser(username, password))
 		}
 
 		idpBuilder := cmv1.NewIdentityProvider().
@@ -93,10 +126,13 @@ func createHTPasswdIDP(cmd *cobra.Command,
 			Name(idpName).
 			Htpasswd(
 				cmv1.NewHTPasswdIdentityProvider().Users(
-					cmv1.NewHTPasswdUserList().Items(CreateHTPasswdUser(username, password)),
+					cmv1.NewHTPasswdUserList().Items(userBuilders...),
 				),
 			)
 		htpasswdIDP = doCreateIDP(idpName, *idpBuilder, cluster, clusterKey, r)
+		if fileUsers != nil {
+			return
+		}
 	}
 
 	if interactive.Enabled() {
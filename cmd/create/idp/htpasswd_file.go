@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Prefixes of the password hashes generated by the htpasswd tool
+var htpasswdHashPrefixes = []string{"$2y$", "$2a$", "$2b$", "$apr1$", "{SHA}", "$1$", "$5$", "$6$"}
+
+type HTPasswdFileUser struct {
+	Username string
+	Password string
+}
+
+// ReadHTPasswdFile reads the users of an HTPasswd identity provider from a file. Files with a
+// '.csv' extension contain 'username,password' records, any other file is read as an htpasswd
+// file with 'username:password' lines.
+func ReadHTPasswdFile(path string) ([]HTPasswdFileUser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open users file '%s': %v", path, err)
+	}
+	defer file.Close()
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ParseHTPasswdCSV(file)
+	}
+	return ParseHTPasswd(file)
+}
+
+// ParseHTPasswd parses 'username:password' lines, ignoring empty lines and '#' comments
+func ParseHTPasswd(reader io.Reader) ([]HTPasswdFileUser, error) {
+	records := [][]string{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens := strings.SplitN(line, ":", 2)
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("Expected 'username:password' format for line '%s'", tokens[0])
+		}
+		records = append(records, tokens)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to read users file: %v", err)
+	}
+	return validateHTPasswdRecords(records)
+}
+
+// ParseHTPasswdCSV parses 'username,password' records. A first record with the 'username' and
+// 'password' column names is skipped.
+func ParseHTPasswdCSV(reader io.Reader) ([]HTPasswdFileUser, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.FieldsPerRecord = 2
+	csvReader.TrimLeadingSpace = true
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Expected 'username,password' records in users file: %v", err)
+	}
+	if len(records) > 0 && strings.EqualFold(records[0][0], "username") &&
+		strings.EqualFold(records[0][1], "password") {
+		records = records[1:]
+	}
+	return validateHTPasswdRecords(records)
+}
+
+func validateHTPasswdRecords(records [][]string) ([]HTPasswdFileUser, error) {
+	users := []HTPasswdFileUser{}
+	seen := map[string]bool{}
+	for _, record := range records {
+		username := strings.TrimSpace(record[0])
+		password := record[1]
+		if err := usernameValidator(username); err != nil {
+			return nil, err
+		}
+		if seen[username] {
+			return nil, fmt.Errorf("Duplicated user '%s' in users file", username)
+		}
+		seen[username] = true
+		for _, prefix := range htpasswdHashPrefixes {
+			if strings.HasPrefix(password, prefix) {
+				return nil, fmt.Errorf("The password of user '%s' is hashed. The HTPasswd identity provider "+
+					"only accepts plain text passwords, generate the file with 'htpasswd -p' or use a CSV file",
+					username)
+			}
+		}
+		if err := passwordValidator(password); err != nil {
+			return nil, fmt.Errorf("Invalid password for user '%s': %v", username, err)
+		}
+		users = append(users, HTPasswdFileUser{
+			Username: username,
+			Password: password,
+		})
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("Expected at least one user in users file")
+	}
+	return users, nil
+}
+
+func BuildHTPasswdUsers(users []HTPasswdFileUser) ([]*cmv1.HTPasswdUser, error) {
+	htpasswdUsers := []*cmv1.HTPasswdUser{}
+	for _, user := range users {
+		htpasswdUser, err := CreateHTPasswdUser(user.Username, user.Password).Build()
+		if err != nil {
+			return nil, err
+		}
+		htpasswdUsers = append(htpasswdUsers, htpasswdUser)
+	}
+	return htpasswdUsers, nil
+}
+
+// HTPasswdSyncPlan holds the changes needed to make the users of an HTPasswd identity provider
+// match the users of a file
+type HTPasswdSyncPlan struct {
+	Add    []HTPasswdFileUser
+	Update map[string]HTPasswdFileUser
+	Delete []*cmv1.HTPasswdUser
+}
+
+// PlanHTPasswdSync compares the existing users with the users of a file. Passwords can't be read
+// back from the service, so the password of every user present in both is reset. The
+// cluster-admin user created by 'rosa create admin' is never deleted.
+func PlanHTPasswdSync(existing []*cmv1.HTPasswdUser, users []HTPasswdFileUser) HTPasswdSyncPlan {
+	plan := HTPasswdSyncPlan{
+		Add:    []HTPasswdFileUser{},
+		Update: map[string]HTPasswdFileUser{},
+		Delete: []*cmv1.HTPasswdUser{},
+	}
+	existingIDs := map[string]string{}
+	for _, user := range existing {
+		existingIDs[user.Username()] = user.ID()
+	}
+	wanted := map[string]bool{}
+	for _, user := range users {
+		wanted[user.Username] = true
+		if id, ok := existingIDs[user.Username]; ok {
+			plan.Update[id] = user
+		} else {
+			plan.Add = append(plan.Add, user)
+		}
+	}
+	for _, user := range existing {
+		if !wanted[user.Username()] && user.Username() != ClusterAdminUsername {
+			plan.Delete = append(plan.Delete, user)
+		}
+	}
+	return plan
+}
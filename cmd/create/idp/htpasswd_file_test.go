@@ -0,0 +1,76 @@
+package idp_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+)
+
+var _ = Describe("HTPasswd file", func() {
+	Context("ParseHTPasswd", func() {
+		It("Parses plain text users", func() {
+			users, err := idp.ParseHTPasswd(strings.NewReader(
+				"# users\nalice:Alice-Password-123\n\nbob:Bob:Password-1234\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(Equal([]idp.HTPasswdFileUser{
+				{Username: "alice", Password: "Alice-Password-123"},
+				{Username: "bob", Password: "Bob:Password-1234"},
+			}))
+		})
+		It("Rejects hashed passwords", func() {
+			_, err := idp.ParseHTPasswd(strings.NewReader(
+				"alice:$2y$05$7yXkfYFcYGtXHwUYZmKxAOvq9fWqhlCsEhIpF3cgPz8PJQD3R3e9u\n"))
+			Expect(err).To(MatchError(ContainSubstring("The password of user 'alice' is hashed")))
+		})
+		It("Rejects duplicated users", func() {
+			_, err := idp.ParseHTPasswd(strings.NewReader(
+				"alice:Alice-Password-123\nalice:Alice-Password-456\n"))
+			Expect(err).To(MatchError(ContainSubstring("Duplicated user 'alice'")))
+		})
+		It("Rejects weak passwords", func() {
+			_, err := idp.ParseHTPasswd(strings.NewReader("alice:short\n"))
+			Expect(err).To(MatchError(ContainSubstring("Invalid password for user 'alice'")))
+		})
+	})
+
+	Context("ParseHTPasswdCSV", func() {
+		It("Parses records and skips the header", func() {
+			users, err := idp.ParseHTPasswdCSV(strings.NewReader(
+				"username,password\nalice,Alice-Password-123\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(Equal([]idp.HTPasswdFileUser{
+				{Username: "alice", Password: "Alice-Password-123"},
+			}))
+		})
+		It("Rejects the cluster-admin user", func() {
+			_, err := idp.ParseHTPasswdCSV(strings.NewReader("cluster-admin,Alice-Password-123\n"))
+			Expect(err).To(MatchError(ContainSubstring("username 'cluster-admin' is not allowed")))
+		})
+	})
+
+	Context("PlanHTPasswdSync", func() {
+		It("Adds, updates and deletes users to match the file", func() {
+			existing := []*cmv1.HTPasswdUser{}
+			for id, username := range map[string]string{
+				"1": idp.ClusterAdminUsername, "2": "alice", "3": "carol",
+			} {
+				user, err := cmv1.NewHTPasswdUser().ID(id).Username(username).Build()
+				Expect(err).ToNot(HaveOccurred())
+				existing = append(existing, user)
+			}
+			alice := idp.HTPasswdFileUser{Username: "alice", Password: "Alice-Password-123"}
+			bob := idp.HTPasswdFileUser{Username: "bob", Password: "Bob-Password-1234"}
+
+			plan := idp.PlanHTPasswdSync(existing, []idp.HTPasswdFileUser{alice, bob})
+			Expect(plan.Add).To(Equal([]idp.HTPasswdFileUser{bob}))
+			Expect(plan.Update).To(Equal(map[string]idp.HTPasswdFileUser{"2": alice}))
+			Expect(plan.Delete).To(HaveLen(1))
+			Expect(plan.Delete[0].Username()).To(Equal("carol"))
+		})
+	})
+})
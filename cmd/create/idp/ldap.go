@@ -63,14 +63,14 @@ func buildLdapIdp(cmd *cobra.Command,
 			Required: true,
 			Validators: []interactive.Validator{
 				interactive.IsURL,
-				validateLdapURL,
+				ValidateLdapURL,
 			},
 		})
 		if err != nil {
 			return idpBuilder, fmt.Errorf("Expected a valid LDAP URL: %s", err)
 		}
 	}
-	err = validateLdapURL(ldapURL)
+	err = ValidateLdapURL(ldapURL)
 	if err != nil {
 		return idpBuilder, err
 	}
@@ -243,7 +243,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	return
 }
 
-func validateLdapURL(val interface{}) error {
+func ValidateLdapURL(val interface{}) error {
 	ldapURL := fmt.Sprintf("%v", val)
 	parsedLdapURL, err := url.ParseRequestURI(ldapURL)
 	if err != nil {
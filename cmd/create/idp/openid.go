@@ -101,7 +101,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 			Required: true,
 			Validators: []interactive.Validator{
 				interactive.IsURL,
-				validateOpenidIssuerURL,
+				ValidateOpenidIssuerURL,
 			},
 		})
 		if err != nil {
@@ -109,7 +109,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 		}
 	}
 
-	err = validateOpenidIssuerURL(issuerURL)
+	err = ValidateOpenidIssuerURL(issuerURL)
 	if err != nil {
 		return idpBuilder, err
 	}
@@ -242,7 +242,7 @@ separated by commas.`,
 	return
 }
 
-func validateOpenidIssuerURL(val interface{}) error {
+func ValidateOpenidIssuerURL(val interface{}) error {
 	issuerURL := fmt.Sprintf("%v", val)
 	parsedIssuerURL, err := url.ParseRequestURI(issuerURL)
 	if err != nil {
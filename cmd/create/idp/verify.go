@@ -0,0 +1,298 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper/ldap"
+	"github.com/openshift/rosa/pkg/helper/verify"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+type VerifyOptions struct {
+	// Secrets can't be read back from the service, so existing identity providers need the bind
+	// password to be given again
+	LDAPBindPassword string
+	LDAPTestUser     string
+	LDAPTestPassword string
+	Timeout          time.Duration
+}
+
+// VerifyIdentityProvider checks that the external services used by the identity provider are
+// reachable and match its configuration
+func VerifyIdentityProvider(cluster *cmv1.Cluster, idp *cmv1.IdentityProvider,
+	options VerifyOptions) []verify.Check {
+	if options.Timeout == 0 {
+		options.Timeout = 10 * time.Second
+	}
+	checks := []verify.Check{}
+	if ocm.HasAuthURLSupport(idp) {
+		checks = append(checks, checkOAuthCallback(cluster, idp))
+	}
+	switch idp.Type() {
+	case cmv1.IdentityProviderTypeGithub:
+		checks = append(checks, verifyGithub(idp.Github(), options)...)
+	case cmv1.IdentityProviderTypeGitlab:
+		checks = append(checks, verifyOpenIDIssuer(idp.Gitlab().URL(), idp.Gitlab().CA(), options)...)
+	case cmv1.IdentityProviderTypeGoogle:
+		checks = append(checks, verifyOpenIDIssuer("https://accounts.google.com", "", options)...)
+	case cmv1.IdentityProviderTypeLDAP:
+		checks = append(checks, verifyLdap(idp.LDAP(), options)...)
+	case cmv1.IdentityProviderTypeOpenID:
+		checks = append(checks, verifyOpenIDIssuer(idp.OpenID().Issuer(), idp.OpenID().CA(), options)...)
+	}
+	return checks
+}
+
+// checkOAuthCallback checks the callback URL that has to be registered with the provider
+func checkOAuthCallback(cluster *cmv1.Cluster, idp *cmv1.IdentityProvider) verify.Check {
+	name := "OAuth callback URL"
+	callbackURL, err := ocm.GetOAuthURL(cluster, idp)
+	if err != nil {
+		return verify.Fail(name, "Failed to build callback URL: %v", err)
+	}
+	parsedURL, err := url.ParseRequestURI(callbackURL)
+	if err != nil {
+		return verify.Fail(name, "Invalid callback URL '%s': %v", callbackURL, err)
+	}
+	if parsedURL.Scheme != "https" || parsedURL.Hostname() == "" {
+		return verify.Fail(name, "Expected an HTTPS callback URL with a host, got '%s'", callbackURL)
+	}
+	if parsedURL.Path != "/oauth2callback/"+idp.Name() || !idRE.MatchString(idp.Name()) {
+		return verify.Fail(name, "Identity provider name '%s' is not valid in callback URL '%s'",
+			idp.Name(), callbackURL)
+	}
+	return verify.Pass(name, "%s", callbackURL)
+}
+
+func trustSource(ca string) string {
+	if ca == "" {
+		return "the system trust store"
+	}
+	return "the CA bundle"
+}
+
+// checkTLS connects to the host of the URL and validates its certificate chain
+func checkTLS(name string, endpoint string, ca string, options VerifyOptions) verify.Check {
+	parsedURL, err := url.Parse(endpoint)
+	if err != nil {
+		return verify.Fail(name, "Invalid URL '%s': %v", endpoint, err)
+	}
+	if parsedURL.Scheme != "https" {
+		return verify.Skip(name, "'%s' doesn't use TLS", endpoint)
+	}
+	config, err := verify.TLSConfig(ca)
+	if err != nil {
+		return verify.Fail(name, "%v", err)
+	}
+	host := parsedURL.Host
+	if parsedURL.Port() == "" {
+		host = net.JoinHostPort(parsedURL.Hostname(), "443")
+	}
+	dialer := &net.Dialer{Timeout: options.Timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", host, config)
+	if err != nil {
+		return verify.Fail(name, "Certificate chain of '%s' isn't trusted by %s: %v", host, trustSource(ca), err)
+	}
+	conn.Close()
+	return verify.Pass(name, "Certificate chain of '%s' is trusted by %s", host, trustSource(ca))
+}
+
+type openIDConfiguration struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// verifyOpenIDIssuer fetches the discovery document of the issuer
+func verifyOpenIDIssuer(issuer string, ca string, options VerifyOptions) []verify.Check {
+	checks := []verify.Check{checkTLS("TLS certificate", issuer, ca, options)}
+	if checks[0].Result == verify.Failed {
+		return checks
+	}
+
+	name := "OpenID discovery"
+	config, err := verify.TLSConfig(ca)
+	if err != nil {
+		return append(checks, verify.Fail(name, "%v", err))
+	}
+	client := &http.Client{
+		Timeout: options.Timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: config,
+		},
+	}
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	response, err := client.Get(discoveryURL)
+	if err != nil {
+		return append(checks, verify.Fail(name, "Failed to get '%s': %v", discoveryURL, err))
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return append(checks, verify.Fail(name, "Failed to get '%s': %s", discoveryURL, response.Status))
+	}
+	document := openIDConfiguration{}
+	err = json.NewDecoder(response.Body).Decode(&document)
+	if err != nil {
+		return append(checks, verify.Fail(name, "Invalid discovery document at '%s': %v", discoveryURL, err))
+	}
+	// The issuer must be exactly the same for the tokens to be accepted
+	if document.Issuer != issuer {
+		return append(checks, verify.Fail(name, "Discovery document issuer '%s' doesn't match issuer URL '%s'",
+			document.Issuer, issuer))
+	}
+	if document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" {
+		return append(checks, verify.Fail(name, "Discovery document at '%s' is missing the authorization "+
+			"or token endpoint", discoveryURL))
+	}
+	return append(checks, verify.Pass(name, "Found discovery document at '%s'", discoveryURL))
+}
+
+// verifyGithub checks that the organizations, including the ones of the teams, exist
+func verifyGithub(github *cmv1.GithubIdentityProvider, options VerifyOptions) []verify.Check {
+	checks := []verify.Check{}
+	apiURL := "https://api.github.com"
+	if github.Hostname() != "" {
+		hostURL := "https://" + github.Hostname()
+		checks = append(checks, checkTLS("TLS certificate", hostURL, github.CA(), options))
+		apiURL = hostURL + "/api/v3"
+	}
+	config, err := verify.TLSConfig(github.CA())
+	if err != nil {
+		return append(checks, verify.Fail("GitHub organizations", "%v", err))
+	}
+	client := &http.Client{
+		Timeout: options.Timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: config,
+		},
+	}
+
+	organizations := github.Organizations()
+	for _, team := range github.Teams() {
+		organizations = append(organizations, strings.Split(team, "/")[0])
+	}
+	seen := map[string]bool{}
+	for _, organization := range organizations {
+		if seen[organization] {
+			continue
+		}
+		seen[organization] = true
+		name := fmt.Sprintf("GitHub organization '%s'", organization)
+		response, err := client.Get(fmt.Sprintf("%s/orgs/%s", apiURL, url.PathEscape(organization)))
+		if err != nil {
+			checks = append(checks, verify.Fail(name, "%v", err))
+			continue
+		}
+		response.Body.Close()
+		switch response.StatusCode {
+		case http.StatusOK:
+			checks = append(checks, verify.Pass(name, "Organization exists"))
+		case http.StatusNotFound:
+			checks = append(checks, verify.Fail(name, "Organization doesn't exist"))
+		default:
+			// Rate limits and private instances don't mean that the organization is wrong
+			checks = append(checks, verify.Skip(name, "Unable to look up organization: %s", response.Status))
+		}
+	}
+	return checks
+}
+
+// verifyLdap connects to the server, binds with the bind DN and searches for the test user
+func verifyLdap(provider *cmv1.LDAPIdentityProvider, options VerifyOptions) []verify.Check {
+	checks := []verify.Check{}
+	name := "LDAP connection"
+	searchURL, err := ldap.ParseSearchURL(provider.URL())
+	if err != nil {
+		return append(checks, verify.Fail(name, "Invalid LDAP URL: %v", err))
+	}
+	config, err := verify.TLSConfig(provider.CA())
+	if err != nil {
+		return append(checks, verify.Fail(name, "%v", err))
+	}
+	host, _, _ := net.SplitHostPort(searchURL.Host)
+	config.ServerName = host
+	conn, err := ldap.Dial(searchURL, provider.Insecure(), config, options.Timeout)
+	if err != nil {
+		return append(checks, verify.Fail(name, "Failed to connect to '%s': %v", searchURL.Host, err))
+	}
+	defer conn.Close()
+	if provider.Insecure() {
+		checks = append(checks, verify.Pass(name, "Connected to '%s' without TLS", searchURL.Host))
+	} else {
+		checks = append(checks, verify.Pass(name, "Connected to '%s', certificate chain is trusted by %s",
+			searchURL.Host, trustSource(provider.CA())))
+	}
+
+	name = "LDAP bind"
+	bindPassword := provider.BindPassword()
+	if options.LDAPBindPassword != "" {
+		bindPassword = options.LDAPBindPassword
+	}
+	if provider.BindDN() != "" && bindPassword == "" {
+		return append(checks, verify.Skip(name, "The bind password is needed to bind as '%s'", provider.BindDN()))
+	}
+	err = conn.Bind(provider.BindDN(), bindPassword)
+	if err != nil {
+		return append(checks, verify.Fail(name, "Failed to bind as '%s': %v", ldap.BindDNString(provider.BindDN()), err))
+	}
+	checks = append(checks, verify.Pass(name, "Bound as '%s'", ldap.BindDNString(provider.BindDN())))
+
+	name = "LDAP search"
+	if options.LDAPTestUser == "" {
+		_, err = conn.Search(searchURL.BaseDN, searchURL.Scope, searchURL.Filter, nil, 1)
+		if err != nil {
+			return append(checks, verify.Fail(name, "Failed to search '%s': %v", searchURL.BaseDN, err))
+		}
+		return append(checks, verify.Pass(name, "Searched '%s' with filter '%s'", searchURL.BaseDN, searchURL.Filter))
+	}
+	filter := fmt.Sprintf("(&%s(%s=%s))", searchURL.Filter, searchURL.Attribute,
+		ldap.EscapeFilterValue(options.LDAPTestUser))
+	entries, err := conn.Search(searchURL.BaseDN, searchURL.Scope, filter, nil, 2)
+	if err != nil {
+		return append(checks, verify.Fail(name, "Failed to search '%s': %v", searchURL.BaseDN, err))
+	}
+	if len(entries) == 0 {
+		return append(checks, verify.Fail(name, "No entry matches filter '%s'", filter))
+	}
+	// Users can only log in when the search returns exactly one entry
+	if len(entries) > 1 {
+		return append(checks, verify.Fail(name, "More than one entry matches filter '%s'", filter))
+	}
+	userDN := entries[0].DN
+	checks = append(checks, verify.Pass(name, "User '%s' is '%s'", options.LDAPTestUser, userDN))
+
+	if options.LDAPTestPassword != "" {
+		name = "LDAP user bind"
+		err = conn.Bind(userDN, options.LDAPTestPassword)
+		if err != nil {
+			return append(checks, verify.Fail(name, "Failed to bind as '%s': %v", userDN, err))
+		}
+		checks = append(checks, verify.Pass(name, "Bound as '%s'", userDN))
+	}
+	return checks
+}
@@ -0,0 +1,82 @@
+package idp_test
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/helper/verify"
+)
+
+var _ = Describe("Verify", func() {
+	var cluster *cmv1.Cluster
+	var server *httptest.Server
+	var ca string
+	var issuer string
+
+	BeforeEach(func() {
+		var err error
+		cluster, err = cmv1.NewCluster().Name("cluster1").ID("id1").
+			Console(cmv1.NewClusterConsole().URL("https://console-openshift-console.apps.cluster.example.com")).
+			Build()
+		Expect(err).To(BeNil())
+
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/.well-known/openid-configuration" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"issuer": "%s", "authorization_endpoint": "%s/authorize", "token_endpoint": "%s/token"}`,
+				issuer, issuer, issuer)
+		}))
+		issuer = server.URL
+		ca = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	buildOpenID := func(issuerURL string, ca string) *cmv1.IdentityProvider {
+		openID, err := cmv1.NewIdentityProvider().
+			Type(cmv1.IdentityProviderTypeOpenID).
+			Name("openid-1").
+			OpenID(cmv1.NewOpenIDIdentityProvider().Issuer(issuerURL).CA(ca)).
+			Build()
+		Expect(err).To(BeNil())
+		return openID
+	}
+
+	It("Passes with a reachable issuer and a matching CA", func() {
+		checks := idp.VerifyIdentityProvider(cluster, buildOpenID(issuer, ca), idp.VerifyOptions{})
+		Expect(checks).To(HaveLen(3))
+		Expect(checks[0].Name).To(Equal("OAuth callback URL"))
+		Expect(checks[0].Message).To(Equal("https://oauth-openshift.apps.cluster.example.com/oauth2callback/openid-1"))
+		for _, check := range checks {
+			Expect(check.Result).To(Equal(verify.Passed), check.Message)
+		}
+		Expect(verify.HasFailures(checks)).To(BeFalse())
+	})
+
+	It("Fails when the certificate isn't trusted", func() {
+		checks := idp.VerifyIdentityProvider(cluster, buildOpenID(issuer, ""), idp.VerifyOptions{})
+		Expect(checks).To(HaveLen(2))
+		Expect(checks[1].Name).To(Equal("TLS certificate"))
+		Expect(checks[1].Result).To(Equal(verify.Failed))
+		Expect(verify.HasFailures(checks)).To(BeTrue())
+	})
+
+	It("Fails when the issuer doesn't match the discovery document", func() {
+		checks := idp.VerifyIdentityProvider(cluster, buildOpenID(issuer+"/", ca), idp.VerifyOptions{})
+		Expect(checks).To(HaveLen(3))
+		Expect(checks[2].Name).To(Equal("OpenID discovery"))
+		Expect(checks[2].Result).To(Equal(verify.Failed))
+	})
+})
@@ -24,6 +24,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	helper "github.com/openshift/rosa/pkg/helper/ingresses"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -32,6 +33,7 @@ import (
 var args struct {
 	private    bool
 	labelMatch string
+	router     helper.RouterArgs
 }
 
 var Cmd = &cobra.Command{
@@ -46,7 +48,10 @@ var Cmd = &cobra.Command{
   rosa create ingress --cluster=mycluster
 
   # Add an ingress with route selector label match
-  rosa create ingress -c mycluster --label-match="foo=bar,bar=baz"`,
+  rosa create ingress -c mycluster --label-match="foo=bar,bar=baz"
+
+  # Add an ingress behind a network load balancer that ignores the routes of some namespaces
+  rosa create ingress -c mycluster --lb-type=nlb --excluded-namespaces=stage,dev`,
 	Run: run,
 }
 
@@ -70,6 +75,7 @@ func init() {
 			"If no label is specified, all routes will be exposed on both routers.",
 	)
 
+	helper.AddRouterFlags(flags, &args.router)
 	interactive.AddFlag(flags)
 }
 
@@ -100,8 +106,16 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	routerOptions, err := helper.GetRouterOptions(cmd.Flags(), &args.router, nil)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	cluster := r.FetchCluster()
-	if cluster.AWS().PrivateLink() {
+	// The ingresses of hosted control plane clusters run on the data plane, so they don't depend on
+	// how the API is exposed
+	if cluster.AWS().PrivateLink() && !cluster.Hypershift().Enabled() {
 		r.Reporter.Errorf("Cluster '%s' is PrivateLink and does not support creating new ingresses", clusterKey)
 		os.Exit(1)
 	}
@@ -145,7 +159,7 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	_, err = r.OCMClient.CreateIngress(cluster.ID(), ingress)
+	_, err = r.OCMClient.CreateIngress(cluster.ID(), ingress, routerOptions)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add ingress to cluster '%s': %s", clusterKey, err)
 		os.Exit(1)
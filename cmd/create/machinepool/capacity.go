@@ -0,0 +1,120 @@
+package machinepool
+
+import (
+	"fmt"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper/machinepools"
+	"github.com/openshift/rosa/pkg/pricing"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var capacityStrategyFlags = []string{"on-demand-base-capacity", "spot-percentage", "spot-instance-types"}
+
+func isCapacityStrategySet(cmd *cobra.Command) bool {
+	for _, flag := range capacityStrategyFlags {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// getCapacityStrategy returns the capacity strategy requested by the user, or nil when the
+// machine pool uses a single instance type and market
+func getCapacityStrategy(cmd *cobra.Command, r *rosa.Runtime) *machinepools.CapacityStrategy {
+	if !isCapacityStrategySet(cmd) {
+		return nil
+	}
+	if args.useSpotInstances {
+		r.Reporter.Errorf("Setting `use-spot-instances` is not supported together with a capacity strategy, " +
+			"use `spot-percentage` to select the replicas that run on spot instances")
+		os.Exit(1)
+	}
+	strategy := &machinepools.CapacityStrategy{
+		OnDemandBaseCapacity: args.onDemandBaseCapacity,
+		SpotPercentage:       args.spotPercentage,
+		SpotInstanceTypes:    args.spotInstanceTypes,
+	}
+	if err := strategy.Validate(); err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	return strategy
+}
+
+// buildCapacityMachinePools splits the machine pool into one machine pool per instance type and
+// market. The on-demand machine pool keeps the requested name and the spot machine pools are
+// named after it.
+func buildCapacityMachinePools(machinePool *cmv1.MachinePool, strategy *machinepools.CapacityStrategy,
+	maxPrice *float64, step int) ([]*cmv1.MachinePool, error) {
+	var minAllocations, maxAllocations []machinepools.CapacityAllocation
+	if machinePool.Autoscaling() != nil {
+		minAllocations = strategy.Allocate(machinePool.InstanceType(), machinePool.Autoscaling().MinReplicas(), step)
+		maxAllocations = strategy.Allocate(machinePool.InstanceType(), machinePool.Autoscaling().MaxReplicas(), step)
+	} else {
+		maxAllocations = strategy.Allocate(machinePool.InstanceType(), machinePool.Replicas(), step)
+	}
+
+	machinePools := []*cmv1.MachinePool{}
+	for i, allocation := range maxAllocations {
+		if allocation.Replicas == 0 {
+			continue
+		}
+		mpBuilder := cmv1.NewMachinePool().
+			Copy(machinePool).
+			InstanceType(allocation.InstanceType)
+		if i > 0 {
+			mpBuilder = mpBuilder.ID(fmt.Sprintf("%s-spot-%d", machinePool.ID(), i))
+		}
+		if machinePool.Autoscaling() != nil {
+			mpBuilder = mpBuilder.Autoscaling(
+				cmv1.NewMachinePoolAutoscaling().
+					MinReplicas(minAllocations[i].Replicas).
+					MaxReplicas(allocation.Replicas))
+		} else {
+			mpBuilder = mpBuilder.Replicas(allocation.Replicas)
+		}
+		if allocation.Spot {
+			spotBuilder := cmv1.NewAWSSpotMarketOptions()
+			if maxPrice != nil {
+				spotBuilder = spotBuilder.MaxPrice(*maxPrice)
+			}
+			mpBuilder = mpBuilder.AWS(cmv1.NewAWSMachinePool().
+				SpotMarketOptions(spotBuilder))
+		}
+		capacityMachinePool, err := mpBuilder.Build()
+		if err != nil {
+			return nil, err
+		}
+		machinePools = append(machinePools, capacityMachinePool)
+	}
+	if len(machinePools) == 0 {
+		return nil, fmt.Errorf("The capacity strategy doesn't allocate any replicas")
+	}
+	return machinePools, nil
+}
+
+func machinePoolNodeGroup(machinePool *cmv1.MachinePool) pricing.NodeGroup {
+	group := pricing.NodeGroup{
+		Name:         machinePool.ID(),
+		Role:         pricing.ComputeRole,
+		InstanceType: machinePool.InstanceType(),
+		MinReplicas:  machinePool.Replicas(),
+		MaxReplicas:  machinePool.Replicas(),
+	}
+	if machinePool.Autoscaling() != nil {
+		group.MinReplicas = machinePool.Autoscaling().MinReplicas()
+		group.MaxReplicas = machinePool.Autoscaling().MaxReplicas()
+	}
+	if spot := machinePool.AWS().SpotMarketOptions(); spot != nil {
+		group.Spot = true
+		if maxPrice, ok := spot.GetMaxPrice(); ok {
+			group.SpotMaxPrice = &maxPrice
+		}
+	}
+	return group
+}
@@ -50,6 +50,10 @@ var args struct {
 	subnet                string
 	version               string
 	autorepair            bool
+	dryRun                bool
+	onDemandBaseCapacity  int
+	spotPercentage        int
+	spotInstanceTypes     []string
 }
 
 var Cmd = &cobra.Command{
@@ -72,7 +76,15 @@ var Cmd = &cobra.Command{
 
   # Add a machine pool with spot instances to a cluster
   rosa create machinepool -c mycluster --name=mp-1 --replicas=2 --instance-type=r5.2xlarge --use-spot-instances \
-    --spot-max-price=0.5`,
+    --spot-max-price=0.5
+
+  # Add machine pools with 2 on-demand replicas and half of the remaining replicas on spot
+  # instances of two instance types
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=6 --instance-type=m5.xlarge \
+    --on-demand-base-capacity=2 --spot-percentage=50 --spot-instance-types=m5.xlarge,m5a.xlarge
+
+  # Estimate the cost of a machine pool without creating it
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=3 --instance-type=m5.xlarge --dry-run`,
 	Run: run,
 }
 
@@ -153,6 +165,29 @@ func init() {
 		"Max price for spot instance. If empty use the on-demand price.",
 	)
 
+	flags.IntVar(
+		&args.onDemandBaseCapacity,
+		"on-demand-base-capacity",
+		0,
+		"Number of replicas that always run on on-demand instances when using a capacity strategy.",
+	)
+
+	flags.IntVar(
+		&args.spotPercentage,
+		"spot-percentage",
+		0,
+		"Percentage of the replicas above the on-demand base capacity that run on spot instances. "+
+			"A separate machine pool is created for each spot instance type.",
+	)
+
+	flags.StringSliceVar(
+		&args.spotInstanceTypes,
+		"spot-instance-types",
+		nil,
+		"Instance types used for the spot replicas of a capacity strategy. "+
+			"Defaults to the instance type of the machine pool.",
+	)
+
 	flags.BoolVar(
 		&args.multiAvailabilityZone,
 		"multi-availability-zone",
@@ -186,6 +221,13 @@ func init() {
 		"Select auto-repair behaviour for a machinepool in a hosted cluster.",
 	)
 
+	flags.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Simulate creating the machine pool and print its estimated cost.",
+	)
+
 	interactive.AddFlag(flags)
 	output.AddFlag(Cmd)
 }
@@ -6,6 +6,8 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/pricing"
 	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/spf13/cobra"
 )
@@ -129,3 +131,32 @@ func getLabelMap(cmd *cobra.Command, r *rosa.Runtime) map[string]string {
 	}
 	return labelMap
 }
+
+// printCostEstimate prints the estimated cost of the machine pool using the bundled price catalog.
+// Failing to estimate the cost only prints a warning.
+func printCostEstimate(r *rosa.Runtime, cluster *cmv1.Cluster, name string, groups ...pricing.NodeGroup) {
+	if !output.HasFlag() {
+		r.Reporter.Infof("Machine pool '%s' was not created. Run without the '--dry-run' flag "+
+			"to create the machine pool.", name)
+	}
+	catalog, err := pricing.LoadCatalog()
+	if err != nil {
+		r.Reporter.Warnf("Unable to estimate the cost of machine pool '%s': %v", name, err)
+		return
+	}
+	estimate, err := catalog.Estimate(cluster.Region().ID(), cluster.Hypershift().Enabled(), false, groups)
+	if err != nil {
+		r.Reporter.Warnf("Unable to estimate the cost of machine pool '%s': %v", name, err)
+		return
+	}
+	if output.HasFlag() {
+		if err = output.Print(estimate); err != nil {
+			r.Reporter.Errorf("Unable to print cost estimate: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("Estimated cost of machine pool '%s', excluding storage, networking and data transfer:",
+		name)
+	estimate.Print(os.Stdout)
+}
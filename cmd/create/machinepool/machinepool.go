@@ -9,14 +9,14 @@ import (
 
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
-	"k8s.io/apimachinery/pkg/util/errors"
-	"k8s.io/apimachinery/pkg/util/validation"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/pricing"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -317,6 +317,18 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		os.Exit(1)
 	}
 
+	capacityStrategy := getCapacityStrategy(cmd, r)
+	if capacityStrategy != nil {
+		for _, spotInstanceType := range capacityStrategy.SpotInstanceTypes {
+			err = instanceTypeList.ValidateMachineType(spotInstanceType, cluster.MultiAZ())
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid spot instance type: %s", err)
+				os.Exit(1)
+			}
+		}
+	}
+	useCapacitySpot := capacityStrategy != nil && capacityStrategy.SpotPercentage > 0
+
 	labelMap := getLabelMap(cmd, r)
 
 	taintBuilders := getTaints(cmd, r)
@@ -341,12 +353,12 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 			os.Exit(1)
 		}
 	}
-	if isLocalZone && useSpotInstances {
+	if isLocalZone && (useSpotInstances || useCapacitySpot) {
 		r.Reporter.Errorf("Spot instances are not supported for local zones")
 		os.Exit(1)
 	}
 
-	if !isSpotSet && !isSpotMaxPriceSet && !isLocalZone && interactive.Enabled() {
+	if !isSpotSet && !isSpotMaxPriceSet && !isLocalZone && capacityStrategy == nil && interactive.Enabled() {
 		useSpotInstances, err = interactive.GetBool(interactive.Input{
 			Question: "Use spot instances",
 			Help:     cmd.Flags().Lookup("use-spot-instances").Usage,
@@ -359,7 +371,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		}
 	}
 
-	if useSpotInstances && !isSpotMaxPriceSet && interactive.Enabled() {
+	if (useSpotInstances || useCapacitySpot) && !isSpotMaxPriceSet && interactive.Enabled() {
 		spotMaxPrice, err = interactive.GetString(interactive.Input{
 			Question: "Spot instance max price",
 			Help:     cmd.Flags().Lookup("spot-max-price").Usage,
@@ -427,19 +439,55 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		os.Exit(1)
 	}
 
-	createdMachinePool, err := r.OCMClient.CreateMachinePool(cluster.ID(), machinePool)
-	if err != nil {
-		r.Reporter.Errorf("Failed to add machine pool to cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+	machinePools := []*cmv1.MachinePool{machinePool}
+	if capacityStrategy != nil {
+		step := 1
+		if multiAZMachinePool {
+			step = 3
+		}
+		machinePools, err = buildCapacityMachinePools(machinePool, capacityStrategy, maxPrice, step)
+		if err != nil {
+			r.Reporter.Errorf("Failed to create machine pools for cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+	}
+
+	if args.dryRun {
+		groups := []pricing.NodeGroup{}
+		for _, item := range machinePools {
+			groups = append(groups, machinePoolNodeGroup(item))
+		}
+		printCostEstimate(r, cluster, name, groups...)
+		os.Exit(0)
+	}
+
+	createdMachinePools := []*cmv1.MachinePool{}
+	for _, item := range machinePools {
+		createdMachinePool, err := r.OCMClient.CreateMachinePool(cluster.ID(), item)
+		if err != nil {
+			r.Reporter.Errorf("Failed to add machine pool '%s' to cluster '%s': %v", item.ID(), clusterKey, err)
+			for _, created := range createdMachinePools {
+				r.Reporter.Warnf("Machine pool '%s' was already created on cluster '%s'", created.ID(), clusterKey)
+			}
+			os.Exit(1)
+		}
+		createdMachinePools = append(createdMachinePools, createdMachinePool)
 	}
 
 	if output.HasFlag() {
-		if err = output.Print(createdMachinePool); err != nil {
+		if capacityStrategy != nil {
+			err = output.Print(createdMachinePools)
+		} else {
+			err = output.Print(createdMachinePools[0])
+		}
+		if err != nil {
 			r.Reporter.Errorf("Unable to print machine pool: %v", err)
 			os.Exit(1)
 		}
 	} else {
-		r.Reporter.Infof("Machine pool '%s' created successfully on cluster '%s'", name, clusterKey)
+		for _, created := range createdMachinePools {
+			r.Reporter.Infof("Machine pool '%s' created successfully on cluster '%s'", created.ID(), clusterKey)
+		}
 		r.Reporter.Infof("To view all machine pools, run 'rosa list machinepools -c %s'", clusterKey)
 	}
 }
@@ -530,39 +578,7 @@ func LabelValidator(val interface{}) error {
 }
 
 func ParseLabels(labels string) (map[string]string, error) {
-	labelMap := make(map[string]string)
-	if labels == "" {
-		return labelMap, nil
-	}
-	for _, label := range strings.Split(labels, ",") {
-		if !strings.Contains(label, "=") {
-			return nil, fmt.Errorf("Expected key=value format for labels")
-		}
-		tokens := strings.Split(label, "=")
-		err := validateLabelKeyValuePair(tokens[0], tokens[1])
-		if err != nil {
-			return nil, err
-		}
-		key := strings.TrimSpace(tokens[0])
-		value := strings.TrimSpace(tokens[1])
-		if _, exists := labelMap[key]; exists {
-			return nil, fmt.Errorf("Duplicated label key '%s' used", key)
-		}
-		labelMap[key] = value
-	}
-	return labelMap, nil
-}
-
-func validateLabelKeyValuePair(key, value string) error {
-	if errs := validation.IsQualifiedName(key); len(errs) != 0 {
-		return fmt.Errorf("Invalid label key '%s': %s", key, strings.Join(errs, "; "))
-	}
-
-	if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
-		return fmt.Errorf("Invalid label value '%s': at key: '%s': %s",
-			value, key, strings.Join(errs, "; "))
-	}
-	return nil
+	return machinepools.ParseLabels(labels)
 }
 
 func taintValidator(val interface{}) error {
@@ -577,38 +593,7 @@ func taintValidator(val interface{}) error {
 }
 
 func parseTaints(taints string) ([]*cmv1.TaintBuilder, error) {
-	taintBuilders := []*cmv1.TaintBuilder{}
-	if taints == "" {
-		return taintBuilders, nil
-	}
-	var errs []error
-	for _, taint := range strings.Split(taints, ",") {
-		if !strings.Contains(taint, "=") || !strings.Contains(taint, ":") {
-			return nil, fmt.Errorf("Expected key=value:scheduleType format for taints. Got '%s'", taint)
-		}
-		// First split effect
-		splitEffect := strings.Split(taint, ":")
-		// Then split key and value
-		splitKeyValue := strings.Split(splitEffect[0], "=")
-		newTaintBuilder := cmv1.NewTaint().Key(splitKeyValue[0]).Value(splitKeyValue[1]).Effect(splitEffect[1])
-		newTaint, _ := newTaintBuilder.Build()
-		if err := validateLabelKeyValuePair(newTaint.Key(), newTaint.Value()); err != nil {
-			errs = append(errs, err)
-			continue
-		}
-		if newTaint.Effect() == "" {
-			// Note: an empty effect means any effect. For the moment this is not supported
-			errs = append(errs, fmt.Errorf("Expected a not empty effect"))
-			continue
-		}
-		taintBuilders = append(taintBuilders, newTaintBuilder)
-	}
-
-	if len(errs) > 0 {
-		return nil, errors.NewAggregate(errs)
-	}
-
-	return taintBuilders, nil
+	return machinepools.ParseTaints(taints)
 }
 
 func isBYOVPC(cluster *cmv1.Cluster) bool {
@@ -3,6 +3,10 @@ package machinepool
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper/machinepools"
 )
 
 var _ = Describe("MachinePool", func() {
@@ -48,3 +52,44 @@ var _ = Describe("MachinePool", func() {
 			"Invalid label value 'node-role.kubernetes.io/infra': at key: 'key'", 0),
 	)
 })
+
+var _ = Describe("Capacity strategy", func() {
+	It("Creates one machine pool per instance type and market", func() {
+		machinePool, err := cmv1.NewMachinePool().
+			ID("mp-1").
+			InstanceType("m5.xlarge").
+			Labels(map[string]string{"a": "b"}).
+			Autoscaling(cmv1.NewMachinePoolAutoscaling().MinReplicas(2).MaxReplicas(6)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		maxPrice := 0.1
+		strategy := &machinepools.CapacityStrategy{
+			OnDemandBaseCapacity: 2,
+			SpotPercentage:       100,
+			SpotInstanceTypes:    []string{"m5.xlarge", "m5a.xlarge"},
+		}
+
+		machinePools, err := buildCapacityMachinePools(machinePool, strategy, &maxPrice, 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(machinePools).To(HaveLen(3))
+
+		Expect(machinePools[0].ID()).To(Equal("mp-1"))
+		Expect(machinePools[0].AWS().SpotMarketOptions()).To(BeNil())
+		Expect(machinePools[0].Autoscaling().MinReplicas()).To(Equal(2))
+		Expect(machinePools[0].Autoscaling().MaxReplicas()).To(Equal(2))
+
+		Expect(machinePools[1].ID()).To(Equal("mp-1-spot-1"))
+		Expect(machinePools[1].Labels()).To(Equal(map[string]string{"a": "b"}))
+		Expect(machinePools[1].AWS().SpotMarketOptions().MaxPrice()).To(Equal(maxPrice))
+		Expect(machinePools[1].Autoscaling().MinReplicas()).To(Equal(0))
+		Expect(machinePools[1].Autoscaling().MaxReplicas()).To(Equal(2))
+
+		Expect(machinePools[2].ID()).To(Equal("mp-1-spot-2"))
+		Expect(machinePools[2].InstanceType()).To(Equal("m5a.xlarge"))
+
+		group := machinePoolNodeGroup(machinePools[2])
+		Expect(group.Spot).To(BeTrue())
+		Expect(*group.SpotMaxPrice).To(Equal(maxPrice))
+		Expect(group.MaxReplicas).To(Equal(2))
+	})
+})
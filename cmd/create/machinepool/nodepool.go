@@ -14,6 +14,7 @@ import (
 	"github.com/openshift/rosa/pkg/helper/versions"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/pricing"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -28,6 +29,15 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		os.Exit(1)
 	}
 
+	// Spot instances aren't available for hosted machine pools
+	for _, flag := range append([]string{"use-spot-instances", "spot-max-price"}, capacityStrategyFlags...) {
+		if cmd.Flags().Changed(flag) {
+			r.Reporter.Errorf("Setting the `%s` flag is not supported for hosted clusters, "+
+				"spot instances are only available for classic machine pools", flag)
+			os.Exit(1)
+		}
+	}
+
 	// Machine pool name:
 	name := strings.Trim(args.name, " \t")
 	if name == "" && !interactive.Enabled() {
@@ -315,6 +325,22 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		os.Exit(1)
 	}
 
+	if args.dryRun {
+		group := pricing.NodeGroup{
+			Name:         name,
+			Role:         pricing.ComputeRole,
+			InstanceType: instanceType,
+			MinReplicas:  replicas,
+			MaxReplicas:  replicas,
+		}
+		if autoscaling {
+			group.MinReplicas = minReplicas
+			group.MaxReplicas = maxReplicas
+		}
+		printCostEstimate(r, cluster, name, group)
+		os.Exit(0)
+	}
+
 	createdNodePool, err := r.OCMClient.CreateNodePool(cluster.ID(), nodePool)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add machine pool to hosted cluster '%s': %v", clusterKey, err)
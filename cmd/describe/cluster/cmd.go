@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/spf13/cobra"
@@ -29,6 +30,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/pricing"
 	"github.com/openshift/rosa/pkg/properties"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -40,18 +42,31 @@ const (
 	ProductionEnv = "https://api.openshift.com"
 )
 
+var args struct {
+	cost bool
+}
+
 var Cmd = &cobra.Command{
 	Use:   "cluster",
 	Short: "Show details of a cluster",
 	Long:  "Show details of a cluster",
 	Example: `  # Describe a cluster named "mycluster"
-  rosa describe cluster --cluster=mycluster`,
+  rosa describe cluster --cluster=mycluster
+
+  # Describe a cluster named "mycluster" including its estimated cost
+  rosa describe cluster --cluster=mycluster --cost`,
 	Run: run,
 }
 
 func init() {
 	output.AddFlag(Cmd)
 	ocm.AddClusterFlag(Cmd)
+	Cmd.Flags().BoolVar(
+		&args.cost,
+		"cost",
+		false,
+		"Show the estimated hourly and monthly cost of the cluster nodes.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -86,6 +101,11 @@ func run(cmd *cobra.Command, argv []string) {
 				r.Reporter.Errorf("%s", err)
 				os.Exit(1)
 			}
+			if args.cost {
+				if estimate := getCostEstimate(r, cluster); estimate != nil {
+					f["costEstimate"] = estimate
+				}
+			}
 			err = output.Print(f)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
@@ -106,6 +126,11 @@ func run(cmd *cobra.Command, argv []string) {
 				r.Reporter.Errorf("%s", err)
 				os.Exit(1)
 			}
+			if args.cost {
+				if estimate := getCostEstimate(r, cluster); estimate != nil {
+					f["costEstimate"] = estimate
+				}
+			}
 			err = output.Print(f)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
@@ -351,6 +376,10 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 	}
 
+	if !isHypershift {
+		str = describeHibernation(r, cluster, str)
+	}
+
 	if cluster.Status().State() == cmv1.ClusterStateError {
 		str = fmt.Sprintf("%s"+
 			"Provisioning Error Code:    %s\n"+
@@ -379,6 +408,18 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Print short cluster description:
 	fmt.Print(str)
+
+	if args.cost {
+		// The cost is an extra, a node type missing from the price catalog doesn't fail the description
+		estimate, err := estimateClusterCost(cluster, machinePools, nodePools)
+		if err != nil {
+			r.Reporter.Warnf("Failed to estimate the cost of cluster '%s': %v", clusterKey, err)
+		} else {
+			fmt.Print("Estimated Cost (excluding storage, networking and data transfer):\n")
+			estimate.Print(os.Stdout)
+			fmt.Print("\n")
+		}
+	}
 }
 
 func controlPlaneConfig(cluster *cmv1.Cluster) string {
@@ -568,3 +609,155 @@ func formatClusterHypershift(cluster *cmv1.Cluster,
 
 	return ret, nil
 }
+
+// getCostEstimate returns the estimated cost of the cluster, or nil after warning when it can't
+// be estimated
+func getCostEstimate(r *rosa.Runtime, cluster *cmv1.Cluster) *pricing.Estimate {
+	var machinePools []*cmv1.MachinePool
+	var nodePools []*cmv1.NodePool
+	var err error
+	if cluster.Hypershift().Enabled() {
+		nodePools, err = r.OCMClient.GetNodePools(cluster.ID())
+	} else {
+		machinePools, err = r.OCMClient.GetMachinePools(cluster.ID())
+	}
+	if err != nil {
+		r.Reporter.Warnf("Failed to get machine pools to estimate the cost of cluster '%s': %v",
+			r.ClusterKey, err)
+		return nil
+	}
+	estimate, err := estimateClusterCost(cluster, machinePools, nodePools)
+	if err != nil {
+		r.Reporter.Warnf("Failed to estimate the cost of cluster '%s': %v", r.ClusterKey, err)
+		return nil
+	}
+	return estimate
+}
+
+// estimateClusterCost builds the node groups of the cluster from its nodes and pools and
+// estimates their cost using the bundled price catalog
+func estimateClusterCost(cluster *cmv1.Cluster, machinePools []*cmv1.MachinePool,
+	nodePools []*cmv1.NodePool) (*pricing.Estimate, error) {
+	catalog, err := pricing.LoadCatalog()
+	if err != nil {
+		return nil, err
+	}
+	var groups []pricing.NodeGroup
+	if cluster.Hypershift().Enabled() {
+		for _, nodePool := range nodePools {
+			group := pricing.NodeGroup{
+				Name:         nodePool.ID(),
+				Role:         pricing.ComputeRole,
+				InstanceType: nodePool.AWSNodePool().InstanceType(),
+				MinReplicas:  nodePool.Replicas(),
+				MaxReplicas:  nodePool.Replicas(),
+			}
+			if nodePool.Autoscaling() != nil {
+				group.MinReplicas = nodePool.Autoscaling().MinReplica()
+				group.MaxReplicas = nodePool.Autoscaling().MaxReplica()
+			}
+			groups = append(groups, group)
+		}
+	} else {
+		groups = catalog.ClusterNodeGroups(cluster.MultiAZ(), false)
+		if cluster.Nodes().Master() > 0 {
+			groups[0].MinReplicas = cluster.Nodes().Master()
+			groups[0].MaxReplicas = cluster.Nodes().Master()
+		}
+		if cluster.Nodes().MasterMachineType().ID() != "" {
+			groups[0].InstanceType = cluster.Nodes().MasterMachineType().ID()
+		}
+		if cluster.Nodes().Infra() > 0 {
+			groups[1].MinReplicas = cluster.Nodes().Infra()
+			groups[1].MaxReplicas = cluster.Nodes().Infra()
+		}
+		if cluster.Nodes().InfraMachineType().ID() != "" {
+			groups[1].InstanceType = cluster.Nodes().InfraMachineType().ID()
+		}
+		compute := pricing.NodeGroup{
+			Name:         "worker",
+			Role:         pricing.ComputeRole,
+			InstanceType: cluster.Nodes().ComputeMachineType().ID(),
+			MinReplicas:  cluster.Nodes().Compute(),
+			MaxReplicas:  cluster.Nodes().Compute(),
+		}
+		if cluster.Nodes().AutoscaleCompute() != nil {
+			compute.MinReplicas = cluster.Nodes().AutoscaleCompute().MinReplicas()
+			compute.MaxReplicas = cluster.Nodes().AutoscaleCompute().MaxReplicas()
+		}
+		groups = append(groups, compute)
+		for _, machinePool := range machinePools {
+			// The default machine pool is already counted with the compute nodes of the cluster
+			if isDefaultMachinePool(machinePool.ID()) {
+				continue
+			}
+			group := pricing.NodeGroup{
+				Name:         machinePool.ID(),
+				Role:         pricing.ComputeRole,
+				InstanceType: machinePool.InstanceType(),
+				MinReplicas:  machinePool.Replicas(),
+				MaxReplicas:  machinePool.Replicas(),
+			}
+			if machinePool.Autoscaling() != nil {
+				group.MinReplicas = machinePool.Autoscaling().MinReplicas()
+				group.MaxReplicas = machinePool.Autoscaling().MaxReplicas()
+			}
+			if machinePool.AWS().SpotMarketOptions() != nil {
+				group.Spot = true
+				if maxPrice, ok := machinePool.AWS().SpotMarketOptions().GetMaxPrice(); ok {
+					group.SpotMaxPrice = &maxPrice
+				}
+			}
+			groups = append(groups, group)
+		}
+	}
+	return catalog.Estimate(cluster.Region().ID(), cluster.Hypershift().Enabled(), true, groups)
+}
+
+// isDefaultMachinePool checks if the machine pool is the one created with the cluster, which some
+// versions of the service also return in the list of machine pools
+func isDefaultMachinePool(id string) bool {
+	return id == "Default" || id == "worker"
+}
+
+// describeHibernation adds the hibernation schedule and history of the cluster to the description
+func describeHibernation(r *rosa.Runtime, cluster *cmv1.Cluster, str string) string {
+	schedule, err := r.OCMClient.GetHibernationSchedule(cluster)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get hibernation schedule for cluster '%s': %v", cluster.ID(), err)
+		os.Exit(1)
+	}
+	if !schedule.IsEmpty() {
+		str = fmt.Sprintf("%s"+"Hibernation Schedule:\n", str)
+		if schedule.Hibernate != nil {
+			str = fmt.Sprintf("%s"+
+				" - Hibernate:               %s\n", str, schedule.Hibernate)
+		}
+		if schedule.Resume != nil {
+			str = fmt.Sprintf("%s"+
+				" - Resume:                  %s\n", str, schedule.Resume)
+		}
+		// Nothing applies the schedule unless the user runs the commands periodically
+		str = fmt.Sprintf("%s"+
+			" - Applied By:              'rosa hibernate|resume cluster --apply-schedule'\n", str)
+		action, next := schedule.NextTransition(time.Now())
+		if action != "" {
+			str = fmt.Sprintf("%s"+
+				" - Next Transition:         %s on %s\n", str,
+				action, next.Format("2006-01-02 15:04 MST"))
+		}
+	}
+	history, err := r.OCMClient.GetHibernationHistory(cluster)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get hibernation history for cluster '%s': %v", cluster.ID(), err)
+		os.Exit(1)
+	}
+	if len(history) > 0 {
+		str = fmt.Sprintf("%s"+"Hibernation History:\n", str)
+	}
+	for _, event := range history {
+		str = fmt.Sprintf("%s"+
+			" - %-24s %s\n", str, event.Action+":", event.Timestamp.Format("2006-01-02 15:04 MST"))
+	}
+	return str
+}
@@ -10,6 +10,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/pricing"
 )
 
 const (
@@ -101,6 +103,31 @@ var _ = Describe("Cluster description", Ordered, func() {
 				func() *cmv1.UpgradePolicyState { return nil }, expectClusterWithNameAndIDValue, nil),
 		)
 	})
+	Context("when estimating the cost of a classic cluster", func() {
+		It("Counts the default machine pool only once", func() {
+			cluster, err := cmv1.NewCluster().
+				Region(cmv1.NewCloudRegion().ID("us-east-1")).
+				Nodes(cmv1.NewClusterNodes().
+					Compute(2).
+					ComputeMachineType(cmv1.NewMachineType().ID("m5.xlarge"))).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			defaultPool, err := cmv1.NewMachinePool().ID("worker").InstanceType("m5.xlarge").Replicas(2).Build()
+			Expect(err).NotTo(HaveOccurred())
+			extraPool, err := cmv1.NewMachinePool().ID("extra").InstanceType("m5.xlarge").Replicas(3).Build()
+			Expect(err).NotTo(HaveOccurred())
+
+			estimate, err := estimateClusterCost(cluster, []*cmv1.MachinePool{defaultPool, extraPool}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			var compute []string
+			for _, item := range estimate.Items {
+				if item.Role == pricing.ComputeRole {
+					compute = append(compute, item.Name)
+				}
+			}
+			Expect(compute).To(Equal([]string{"worker", "extra"}))
+		})
+	})
 })
 
 func printJson(cluster func() *cmv1.Cluster,
@@ -0,0 +1,237 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	helper "github.com/openshift/rosa/pkg/helper/ingresses"
+	"github.com/openshift/rosa/pkg/helper/verify"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	verify  bool
+	timeout time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:     "ingress ID",
+	Aliases: []string{"route"},
+	Short:   "Show details of an ingress",
+	Long:    "Show details of the application router of a cluster, including its router options.",
+	Example: `  # Describe the default ingress of a cluster named 'mycluster'
+  rosa describe ingress --cluster=mycluster apps
+
+  # Describe the ingress with ID 'a1b2' in JSON format
+  rosa describe ingress --cluster=mycluster a1b2 -o json
+
+  # Check that the DNS records and the load balancer of the default ingress are healthy
+  rosa describe ingress --cluster=mycluster apps --verify`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the id of the ingress",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.BoolVar(
+		&args.verify,
+		"verify",
+		false,
+		"Check that the wildcard DNS records of the ingress resolve and that its load balancer "+
+			"completes TLS handshakes with a certificate valid for its routes.",
+	)
+
+	flags.DurationVar(
+		&args.timeout,
+		"timeout",
+		10*time.Second,
+		"Timeout of each of the checks run with --verify.",
+	)
+
+	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	ingressID := argv[0]
+	if !helper.IngressKeyRE.MatchString(ingressID) {
+		r.Reporter.Errorf(
+			"Ingress identifier '%s' isn't valid: it must contain only letters or digits",
+			ingressID,
+		)
+		os.Exit(1)
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	r.Reporter.Debugf("Loading ingresses for cluster '%s'", clusterKey)
+	ingresses, err := r.OCMClient.GetIngresses(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	ingress := helper.GetIngress(ingresses, ingressID)
+	if ingress == nil {
+		r.Reporter.Errorf("Failed to get ingress '%s' for cluster '%s'", ingressID, clusterKey)
+		os.Exit(1)
+	}
+	routerOptions, err := r.OCMClient.GetIngressRouterOptions(cluster.ID(), ingress.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get router options of ingress '%s' for cluster '%s': %v",
+			ingress.ID(), clusterKey, err)
+		os.Exit(1)
+	}
+
+	var checks []verify.Check
+	if args.verify {
+		if !output.HasFlag() && r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Verifying ingress '%s' on cluster '%s'...", ingress.ID(), clusterKey)
+		}
+		checks = helper.VerifyIngress(ingress, helper.VerifyOptions{Timeout: args.timeout})
+	}
+
+	if output.HasFlag() {
+		f, err := formatIngress(ingress, routerOptions)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if args.verify {
+			f["checks"] = checks
+		}
+		err = output.Print(f)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if verify.HasFailures(checks) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	private := "No"
+	if ingress.Listening() == cmv1.ListeningMethodInternal {
+		private = "Yes"
+	}
+	isDefault := "No"
+	if ingress.Default() {
+		isDefault = "Yes"
+	}
+	routeSelectors := []string{}
+	for key, value := range ingress.RouteSelectors() {
+		routeSelectors = append(routeSelectors, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(routeSelectors)
+	excludedNamespaces := []string{}
+	if routerOptions.ExcludedNamespaces != nil {
+		excludedNamespaces = *routerOptions.ExcludedNamespaces
+	}
+
+	fmt.Printf(`%-32s%s
+%-32s%s
+%-32s%s
+%-32s%s
+%-32s%s
+%-32s%s
+%-32s%s
+%-32s%s
+%-32s%s
+%-32s%s
+`,
+		"ID:", ingress.ID(),
+		"Cluster ID:", cluster.ID(),
+		"Application router:", "https://"+ingress.DNSName(),
+		"Default:", isDefault,
+		"Private:", private,
+		"Load balancer type:", stringValue(routerOptions.LoadBalancerType),
+		"Route selectors:", strings.Join(routeSelectors, ", "),
+		"Excluded namespaces:", strings.Join(excludedNamespaces, ", "),
+		"Wildcard policy:", stringValue(routerOptions.RouteWildcardPolicy),
+		"Namespace ownership policy:", stringValue(routerOptions.RouteNamespaceOwnershipPolicy),
+	)
+	if stringValue(routerOptions.ClusterRoutesHostname) != "" {
+		fmt.Printf(`%-32s%s
+%-32s%s
+`,
+			"Cluster routes hostname:", stringValue(routerOptions.ClusterRoutesHostname),
+			"Cluster routes TLS secret:", stringValue(routerOptions.ClusterRoutesTLSSecretRef),
+		)
+	}
+
+	if args.verify {
+		fmt.Println()
+		verify.PrintReport(checks)
+		if verify.HasFailures(checks) {
+			r.Reporter.Errorf("Ingress '%s' failed verification", ingress.ID())
+			os.Exit(1)
+		}
+	}
+}
+
+func formatIngress(ingress *cmv1.Ingress, routerOptions *ocm.IngressRouterOptions) (map[string]interface{}, error) {
+	var b bytes.Buffer
+	err := cmv1.MarshalIngress(ingress, &b)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]interface{})
+	err = json.Unmarshal(b.Bytes(), &ret)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(routerOptions)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
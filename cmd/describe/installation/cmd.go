@@ -19,6 +19,7 @@ package installation
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -92,13 +93,32 @@ func describeAddonInstallation(r *rosa.Runtime, installationKey string) error {
 		return err
 	}
 
+	latestVersion := ""
+	addOn, err := r.OCMClient.GetAddOn(installation.ID())
+	if err != nil {
+		r.Reporter.Debugf("Failed to get add-on '%s': %v", installation.ID(), err)
+	} else {
+		latestVersion = addOn.Version().ID()
+	}
+
+	availableUpgrades := "None"
+	if len(installation.AddonVersion().AvailableUpgrades()) > 0 {
+		availableUpgrades = strings.Join(installation.AddonVersion().AvailableUpgrades(), ", ")
+	}
+
 	fmt.Printf(`%-28s %s
 %-28s %s
 %-28s %s
+%-28s %s
+%-28s %s
+%-28s %s
 `,
 		"Id:", installation.ID(),
 		"Href:", installation.HREF(),
 		"Addon state:", installation.State(),
+		"Installed version:", installation.AddonVersion().ID(),
+		"Latest version:", latestVersion,
+		"Available upgrades:", availableUpgrades,
 	)
 
 	parameters := installation.Parameters()
@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"os"
 
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	helper "github.com/openshift/rosa/pkg/helper/upgrades"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -49,13 +51,14 @@ func run(cmd *cobra.Command, argv []string) {
 	// Try to find the cluster:
 	r.Reporter.Debugf("Loading upgrade with id '%s'", cluster.ID())
 	if cluster.Hypershift().Enabled() {
-		returnHypershiftUpgrades(r, cluster.ID())
+		returnHypershiftUpgrades(r, cluster)
 	} else {
-		returnClassicUpgrades(r, cluster.ID())
+		returnClassicUpgrades(r, cluster)
 	}
 }
 
-func returnHypershiftUpgrades(r *rosa.Runtime, clusterID string) {
+func returnHypershiftUpgrades(r *rosa.Runtime, cluster *cmv1.Cluster) {
+	clusterID := cluster.ID()
 	upgrades, err := r.OCMClient.GetControlPlaneUpgradePolicies(clusterID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get upgrade with cluster id '%s': %v", clusterID, err)
@@ -80,14 +83,23 @@ func returnHypershiftUpgrades(r *rosa.Runtime, clusterID string) {
 			fmt.Printf(`                %-28s%s
 `, "Schedule At:", upgrade.Schedule())
 		}
-		if upgrade.Version() != "" {
+		if upgrade.ScheduleType() != "" {
 			fmt.Printf(`                %-28s%s
-`, "Version:", upgrade.Version())
+`, "Schedule Type:", upgrade.ScheduleType())
+		}
+		version := upgrade.Version()
+		if version == "" && upgrade.ScheduleType() == helper.ScheduleTypeAutomatic {
+			version = automaticUpgradeVersion(r, cluster, upgrade.EnableMinorVersionUpgrades())
+		}
+		if version != "" {
+			fmt.Printf(`                %-28s%s
+`, "Version:", version)
 		}
 	}
 }
 
-func returnClassicUpgrades(r *rosa.Runtime, clusterID string) {
+func returnClassicUpgrades(r *rosa.Runtime, cluster *cmv1.Cluster) {
+	clusterID := cluster.ID()
 	upgrades, err := r.OCMClient.GetUpgradePolicies(clusterID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get upgrade with cluster id '%s': %v", clusterID, err)
@@ -117,9 +129,32 @@ func returnClassicUpgrades(r *rosa.Runtime, clusterID string) {
 			fmt.Printf(`                %-28s%s
 `, "Schedule At:", upgrade.Schedule())
 		}
-		if upgrade.Version() != "" {
+		if upgrade.ScheduleType() != "" {
+			fmt.Printf(`                %-28s%s
+`, "Schedule Type:", upgrade.ScheduleType())
+		}
+		version := upgrade.Version()
+		if version == "" && upgrade.ScheduleType() == helper.ScheduleTypeAutomatic {
+			version = automaticUpgradeVersion(r, cluster, upgrade.EnableMinorVersionUpgrades())
+		}
+		if version != "" {
 			fmt.Printf(`                %-28s%s
-`, "Version:", upgrade.Version())
+`, "Version:", version)
 		}
 	}
 }
+
+// automaticUpgradeVersion returns the version that the next run of an automatic upgrade policy
+// would upgrade the cluster to, if there is one
+func automaticUpgradeVersion(r *rosa.Runtime, cluster *cmv1.Cluster, minorUpgrades bool) string {
+	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+	if err != nil {
+		r.Reporter.Errorf("Failed to find available upgrades: %v", err)
+		os.Exit(1)
+	}
+	version := helper.AutomaticUpgradeVersion(cluster.Version().RawID(), availableUpgrades, minorUpgrades)
+	if version == "" {
+		return "No upgrade available at the moment"
+	}
+	return version
+}
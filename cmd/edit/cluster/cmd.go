@@ -157,6 +157,18 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf(fmt.Sprintf("%s", err))
 		os.Exit(1)
 	}
+	if !expiration.IsZero() {
+		policy, err := r.OCMClient.GetExpirationPolicy()
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the expiration policy of the organization: %v", err)
+			os.Exit(1)
+		}
+		err = policy.CheckExpiration(cluster, expiration, time.Now())
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
 
 	if interactive.Enabled() {
 		r.Reporter.Infof("Interactive mode enabled.\n" +
@@ -21,9 +21,11 @@ import (
 
 	"github.com/openshift/rosa/cmd/edit/addon"
 	"github.com/openshift/rosa/cmd/edit/cluster"
+	"github.com/openshift/rosa/cmd/edit/idp"
 	"github.com/openshift/rosa/cmd/edit/ingress"
 	"github.com/openshift/rosa/cmd/edit/machinepool"
 	"github.com/openshift/rosa/cmd/edit/service"
+	"github.com/openshift/rosa/cmd/edit/upgrade"
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/interactive"
 )
@@ -38,9 +40,11 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.AddCommand(addon.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(idp.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
 	Cmd.AddCommand(machinepool.Cmd)
 	Cmd.AddCommand(service.Cmd)
+	Cmd.AddCommand(upgrade.Cmd)
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
@@ -0,0 +1,440 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"fmt"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	idpPack "github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	mappingMethod string
+	clientID      string
+	clientSecret  string
+	caPath        string
+
+	// GitHub
+	githubHostname      string
+	githubOrganizations string
+	githubTeams         string
+
+	// GitLab
+	gitlabURL string
+
+	// Google
+	googleHostedDomain string
+
+	// LDAP
+	ldapURL          string
+	ldapInsecure     bool
+	ldapBindDN       string
+	ldapBindPassword string
+	ldapIDs          string
+	ldapUsernames    string
+	ldapDisplayNames string
+	ldapEmails       string
+
+	// OpenID
+	openidIssuerURL string
+	openidEmail     string
+	openidName      string
+	openidUsername  string
+	openidGroups    string
+	openidScopes    string
+
+	// HTPasswd
+	syncFile string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "idp NAME",
+	Aliases: []string{"idps"},
+	Short:   "Edit cluster IDP",
+	Long: "Edit an identity provider of a cluster in place, keeping its ID and the sessions of its " +
+		"users. Interactive mode uses the current configuration as defaults.",
+	Example: `  # Rotate the client secret of the GitHub identity provider named 'github-1'
+  rosa edit idp github-1 --cluster=mycluster --client-secret=<secret>
+
+  # Edit the LDAP identity provider named 'ldap-1' following interactive prompts
+  rosa edit idp ldap-1 --cluster=mycluster --interactive
+
+  # Make the users of the HTPasswd identity provider named 'htpasswd' match a file
+  rosa edit idp htpasswd --cluster=mycluster --sync-file=users.htpasswd`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the name of the identity provider",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.mappingMethod,
+		"mapping-method",
+		"",
+		fmt.Sprintf("Specifies how new identities are mapped to users when they log in. Options are %s",
+			idpPack.ValidMappingMethods),
+	)
+	flags.StringVar(
+		&args.clientID,
+		"client-id",
+		"",
+		"Client ID from the registered application.",
+	)
+	flags.StringVar(
+		&args.clientSecret,
+		"client-secret",
+		"",
+		"Client Secret from the registered application.",
+	)
+	flags.StringVar(
+		&args.caPath,
+		"ca",
+		"",
+		"Path to PEM-encoded certificate file to use when making requests to the server.\n",
+	)
+
+	// GitHub
+	flags.StringVar(
+		&args.githubHostname,
+		"hostname",
+		"",
+		"GitHub: Optional domain to use with a hosted instance of GitHub Enterprise.",
+	)
+	flags.StringVar(
+		&args.githubOrganizations,
+		"organizations",
+		"",
+		"GitHub: Only users that are members of at least one of the listed organizations will be allowed to log in.",
+	)
+	flags.StringVar(
+		&args.githubTeams,
+		"teams",
+		"",
+		"GitHub: Only users that are members of at least one of the listed teams will be allowed to log in. "+
+			"The format is <org>/<team>.\n",
+	)
+
+	// GitLab
+	flags.StringVar(
+		&args.gitlabURL,
+		"host-url",
+		"",
+		"GitLab: The host URL of a GitLab provider.\n",
+	)
+
+	// Google
+	flags.StringVar(
+		&args.googleHostedDomain,
+		"hosted-domain",
+		"",
+		"Google: Restrict users to a Google Apps domain.\n",
+	)
+
+	// LDAP
+	flags.StringVar(
+		&args.ldapURL,
+		"url",
+		"",
+		"LDAP: An RFC 2255 URL which specifies the LDAP search parameters to use.",
+	)
+	flags.BoolVar(
+		&args.ldapInsecure,
+		"insecure",
+		false,
+		"LDAP: Do not make TLS connections to the server.",
+	)
+	flags.StringVar(
+		&args.ldapBindDN,
+		"bind-dn",
+		"",
+		"LDAP: DN to bind with during the search phase.",
+	)
+	flags.StringVar(
+		&args.ldapBindPassword,
+		"bind-password",
+		"",
+		"LDAP: Password to bind with during the search phase.",
+	)
+	flags.StringVar(
+		&args.ldapIDs,
+		"id-attributes",
+		"",
+		"LDAP: The list of attributes whose values should be used as the user ID.",
+	)
+	flags.StringVar(
+		&args.ldapUsernames,
+		"username-attributes",
+		"",
+		"LDAP: The list of attributes whose values should be used as the preferred username.",
+	)
+	flags.StringVar(
+		&args.ldapDisplayNames,
+		"name-attributes",
+		"",
+		"LDAP: The list of attributes whose values should be used as the display name.",
+	)
+	flags.StringVar(
+		&args.ldapEmails,
+		"email-attributes",
+		"",
+		"LDAP: The list of attributes whose values should be used as the email address.\n",
+	)
+
+	// OpenID
+	flags.StringVar(
+		&args.openidIssuerURL,
+		"issuer-url",
+		"",
+		"OpenID: The URL that the OpenID Provider asserts as the Issuer Identifier. "+
+			"It must use the https scheme with no URL query parameters or fragment.",
+	)
+	flags.StringVar(
+		&args.openidEmail,
+		"email-claims",
+		"",
+		"OpenID: List of claims to use as the email address.",
+	)
+	flags.StringVar(
+		&args.openidName,
+		"name-claims",
+		"",
+		"OpenID: List of claims to use as the display name.",
+	)
+	flags.StringVar(
+		&args.openidUsername,
+		"username-claims",
+		"",
+		"OpenID: List of claims to use as the preferred username when provisioning a user.",
+	)
+	flags.StringVar(
+		&args.openidGroups,
+		"groups-claims",
+		"",
+		"OpenID: List of claims to use as the groups names.",
+	)
+	flags.StringVar(
+		&args.openidScopes,
+		"extra-scopes",
+		"",
+		"OpenID: List of scopes to request, in addition to the 'openid' scope, during the authorization token request.\n",
+	)
+
+	// HTPasswd
+	flags.StringVar(
+		&args.syncFile,
+		"sync-file",
+		"",
+		"HTPasswd: Path to a file with the users the identity provider should have. Users missing from "+
+			"the file are deleted and the password of the other users is reset. Files with a '.csv' "+
+			"extension contain 'username,password' records, any other file is read as an htpasswd file "+
+			"with plain text 'username:password' lines.",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	idpName := argv[0]
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	idp := findIdentityProvider(r, cluster, clusterKey, idpName)
+	idpType := ocm.IdentityProviderType(idp)
+
+	// Only allow the flags that apply to the type of the identity provider
+	isFieldSet := false
+	for _, flags := range idpFlags {
+		for _, flag := range flags {
+			if !cmd.Flags().Changed(flag) {
+				continue
+			}
+			if !helper.Contains(idpFlags[idpType], flag) {
+				r.Reporter.Errorf("Setting `%s` is not supported for %s identity providers", flag, idpType)
+				os.Exit(1)
+			}
+			isFieldSet = true
+		}
+	}
+
+	if idpType == ocm.HTPasswdIDPType {
+		if args.syncFile == "" {
+			r.Reporter.Errorf("Expected the `sync-file` flag to edit HTPasswd identity provider '%s'", idpName)
+			os.Exit(1)
+		}
+		syncHTPasswdUsers(r, cluster, clusterKey, idp)
+		return
+	}
+
+	// Without any field to change, ask for all of them starting from the current values
+	if !isFieldSet {
+		interactive.Enable()
+	}
+	if interactive.Enabled() {
+		r.Reporter.Infof("Interactive mode enabled.\n" +
+			"Current values are used as defaults. Secrets are kept when left empty.")
+	}
+
+	var idpBuilder *cmv1.IdentityProviderBuilder
+	var err error
+	switch idpType {
+	case ocm.GithubIDPType:
+		idpBuilder, err = editGithubIdp(cmd, idp)
+	case ocm.GitlabIDPType:
+		idpBuilder, err = editGitlabIdp(cmd, idp)
+	case ocm.GoogleIDPType:
+		idpBuilder, err = editGoogleIdp(cmd, idp)
+	case ocm.LDAPIDPType:
+		idpBuilder, err = editLdapIdp(cmd, idp)
+	case ocm.OpenIDIDPType:
+		idpBuilder, err = editOpenidIdp(cmd, idp)
+	default:
+		err = fmt.Errorf("Identity providers of type '%s' can't be edited", idpType)
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to edit IDP '%s' of cluster '%s': %v", idpName, clusterKey, err)
+		os.Exit(1)
+	}
+
+	mappingMethod, err := getMappingMethod(cmd, string(idp.MappingMethod()))
+	if err != nil {
+		r.Reporter.Errorf("Failed to edit IDP '%s' of cluster '%s': %v", idpName, clusterKey, err)
+		os.Exit(1)
+	}
+
+	update, err := idpBuilder.
+		ID(idp.ID()).
+		Type(idp.Type()).
+		MappingMethod(cmv1.IdentityProviderMappingMethod(mappingMethod)).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Failed to edit IDP '%s' of cluster '%s': %v", idpName, clusterKey, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Updating identity provider '%s' on cluster '%s'", idpName, clusterKey)
+	_, err = r.OCMClient.UpdateIdentityProvider(cluster.ID(), update)
+	if err != nil {
+		r.Reporter.Errorf("Failed to update identity provider '%s' on cluster '%s': %v", idpName, clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Identity Provider '%s' has been updated. It will take a few minutes for the "+
+		"changes to apply on the cluster", idpName)
+}
+
+func findIdentityProvider(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string,
+	idpName string) *cmv1.IdentityProvider {
+	r.Reporter.Debugf("Loading identity provider '%s'", idpName)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, item := range idps {
+		if item.Name() == idpName {
+			return item
+		}
+	}
+	r.Reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", idpName, clusterKey)
+	os.Exit(1)
+	return nil
+}
+
+func syncHTPasswdUsers(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, idp *cmv1.IdentityProvider) {
+	if idp.Htpasswd().Username() != "" {
+		r.Reporter.Errorf("Users can't be synchronized with a single user HTPasswd IDP. Delete the IDP and " +
+			"recreate it as a multi user HTPasswd IDP")
+		os.Exit(1)
+	}
+
+	users, err := idpPack.ReadHTPasswdFile(args.syncFile)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading users of identity provider '%s'", idp.Name())
+	userList, err := r.OCMClient.GetHTPasswdUserList(cluster.ID(), idp.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get user list of the HTPasswd IDP of '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	plan := idpPack.PlanHTPasswdSync(userList.Slice(), users)
+
+	r.Reporter.Infof("Synchronizing identity provider '%s' will add %d, update %d and delete %d users",
+		idp.Name(), len(plan.Add), len(plan.Update), len(plan.Delete))
+	if !confirm.Confirm("synchronize the users of identity provider '%s' on cluster '%s'",
+		idp.Name(), clusterKey) {
+		os.Exit(0)
+	}
+
+	if len(plan.Add) > 0 {
+		newUsers, err := idpPack.BuildHTPasswdUsers(plan.Add)
+		if err == nil {
+			err = r.OCMClient.ImportHTPasswdUsers(cluster.ID(), idp.ID(), newUsers)
+		}
+		if err != nil {
+			r.Reporter.Errorf("Failed to add users to identity provider '%s': %v", idp.Name(), err)
+			os.Exit(1)
+		}
+		for _, user := range plan.Add {
+			r.Reporter.Infof("User '%s' added", user.Username)
+		}
+	}
+	for id, user := range plan.Update {
+		err = r.OCMClient.UpdateHTPasswdUserPassword(cluster.ID(), idp.ID(), id, user.Password)
+		if err != nil {
+			r.Reporter.Errorf("Failed to update password of user '%s': %v", user.Username, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Password of user '%s' updated", user.Username)
+	}
+	for _, user := range plan.Delete {
+		err = r.OCMClient.DeleteHTPasswdUser(user.Username(), cluster.ID(), idp)
+		if err != nil {
+			r.Reporter.Errorf("Failed to delete user '%s': %v", user.Username(), err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("User '%s' deleted", user.Username())
+	}
+}
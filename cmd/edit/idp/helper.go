@@ -0,0 +1,164 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	idpPack "github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/interactive"
+)
+
+// getString returns the value of the flag when it was set and the current value otherwise. In
+// interactive mode the user can change it, starting from that value.
+func getString(cmd *cobra.Command, flag string, question string, current string,
+	validators ...interactive.Validator) (string, error) {
+	value := current
+	if cmd.Flags().Changed(flag) {
+		value = cmd.Flags().Lookup(flag).Value.String()
+	}
+	var err error
+	if interactive.Enabled() {
+		value, err = interactive.GetString(interactive.Input{
+			Question:   question,
+			Help:       cmd.Flags().Lookup(flag).Usage,
+			Default:    value,
+			Validators: validators,
+		})
+		if err != nil {
+			return "", fmt.Errorf("Expected a valid value for %s: %s", flag, err)
+		}
+	}
+	for _, validator := range validators {
+		if value == "" {
+			break
+		}
+		if err = validator(value); err != nil {
+			return "", err
+		}
+	}
+	return value, nil
+}
+
+// getList works like getString for comma-separated lists
+func getList(cmd *cobra.Command, flag string, question string, current []string) ([]string, error) {
+	value, err := getString(cmd, flag, question, strings.Join(current, ","))
+	if err != nil {
+		return nil, err
+	}
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+	return list, nil
+}
+
+// getSecret returns the new value of a secret. Secrets can't be read back from the service, so an
+// empty value means that the current secret is kept.
+func getSecret(cmd *cobra.Command, flag string, question string) (string, error) {
+	value := ""
+	if cmd.Flags().Changed(flag) {
+		value = cmd.Flags().Lookup(flag).Value.String()
+	}
+	var err error
+	if interactive.Enabled() && value == "" {
+		value, err = interactive.GetPassword(interactive.Input{
+			Question: question,
+			Help:     cmd.Flags().Lookup(flag).Usage + " Leave empty to keep the current value.",
+		})
+		if err != nil {
+			return "", fmt.Errorf("Expected a valid value for %s: %s", flag, err)
+		}
+	}
+	return value, nil
+}
+
+func getBool(cmd *cobra.Command, flag string, question string, current bool) (bool, error) {
+	value := current
+	if cmd.Flags().Changed(flag) {
+		value = cmd.Flags().Lookup(flag).Value.String() == "true"
+	}
+	var err error
+	if interactive.Enabled() {
+		value, err = interactive.GetBool(interactive.Input{
+			Question: question,
+			Help:     cmd.Flags().Lookup(flag).Usage,
+			Default:  value,
+		})
+		if err != nil {
+			return false, fmt.Errorf("Expected a valid value for %s: %s", flag, err)
+		}
+	}
+	return value, nil
+}
+
+// getCA returns the contents of the certificate bundle given with the 'ca' flag, or the current
+// bundle when no new one is given
+func getCA(cmd *cobra.Command, current string) (string, error) {
+	caPath := args.caPath
+	var err error
+	if interactive.Enabled() && caPath == "" {
+		caPath, err = interactive.GetCert(interactive.Input{
+			Question: "CA file path",
+			Help:     cmd.Flags().Lookup("ca").Usage + " Leave empty to keep the current certificate bundle.",
+		})
+		if err != nil {
+			return "", fmt.Errorf("Expected a valid certificate bundle: %s", err)
+		}
+	}
+	if caPath == "" {
+		return current, nil
+	}
+	cert, err := os.ReadFile(caPath)
+	if err != nil {
+		return "", fmt.Errorf("Expected a valid certificate bundle: %s", err)
+	}
+	return string(cert), nil
+}
+
+func getMappingMethod(cmd *cobra.Command, current string) (string, error) {
+	mappingMethod := current
+	if cmd.Flags().Changed("mapping-method") {
+		mappingMethod = args.mappingMethod
+	}
+	var err error
+	if interactive.Enabled() {
+		mappingMethod, err = interactive.GetOption(interactive.Input{
+			Question: "Mapping method",
+			Help:     cmd.Flags().Lookup("mapping-method").Usage,
+			Options:  idpPack.ValidMappingMethods,
+			Default:  mappingMethod,
+			Required: true,
+		})
+		if err != nil {
+			return "", fmt.Errorf("Expected a valid mapping method: %s", err)
+		}
+	}
+	for _, validMappingMethod := range idpPack.ValidMappingMethods {
+		if mappingMethod == validMappingMethod {
+			return mappingMethod, nil
+		}
+	}
+	return "", fmt.Errorf("Expected a valid mapping method. Options are %s", idpPack.ValidMappingMethods)
+}
@@ -0,0 +1,283 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"errors"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	idpPack "github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Flags that can be used to edit each type of identity provider
+var idpFlags = map[string][]string{
+	ocm.GithubIDPType: {"mapping-method", "client-id", "client-secret", "ca", "hostname", "organizations",
+		"teams"},
+	ocm.GitlabIDPType: {"mapping-method", "client-id", "client-secret", "ca", "host-url"},
+	ocm.GoogleIDPType: {"mapping-method", "client-id", "client-secret", "hosted-domain"},
+	ocm.LDAPIDPType: {"mapping-method", "ca", "url", "insecure", "bind-dn", "bind-password", "id-attributes",
+		"username-attributes", "name-attributes", "email-attributes"},
+	ocm.OpenIDIDPType: {"mapping-method", "client-id", "client-secret", "ca", "issuer-url", "email-claims",
+		"name-claims", "username-claims", "groups-claims", "extra-scopes"},
+	ocm.HTPasswdIDPType: {"sync-file"},
+}
+
+func editGithubIdp(cmd *cobra.Command, idp *cmv1.IdentityProvider) (*cmv1.IdentityProviderBuilder, error) {
+	github := idp.Github()
+	githubIDP := cmv1.NewGithubIdentityProvider().Copy(github)
+
+	clientID, err := getString(cmd, "client-id", "Client ID", github.ClientID())
+	if err != nil {
+		return nil, err
+	}
+	githubIDP = githubIDP.ClientID(clientID)
+	clientSecret, err := getSecret(cmd, "client-secret", "Client Secret")
+	if err != nil {
+		return nil, err
+	}
+	if clientSecret != "" {
+		githubIDP = githubIDP.ClientSecret(clientSecret)
+	}
+
+	organizations, err := getList(cmd, "organizations", "GitHub organizations", github.Organizations())
+	if err != nil {
+		return nil, err
+	}
+	teams, err := getList(cmd, "teams", "GitHub teams", github.Teams())
+	if err != nil {
+		return nil, err
+	}
+	// Replacing the organizations with teams, or the other way around, clears the previous ones
+	if cmd.Flags().Changed("teams") && !cmd.Flags().Changed("organizations") {
+		organizations = []string{}
+	}
+	if cmd.Flags().Changed("organizations") && !cmd.Flags().Changed("teams") {
+		teams = []string{}
+	}
+	if len(organizations) > 0 && len(teams) > 0 {
+		return nil, errors.New("GitHub IDP only allows either organizations or teams, but not both")
+	}
+	if len(organizations) == 0 && len(teams) == 0 {
+		return nil, errors.New("GitHub IdP requires either organizations or teams")
+	}
+	githubIDP = githubIDP.Organizations(organizations...).Teams(teams...)
+
+	hostname, err := getString(cmd, "hostname", "GitHub Enterprise Hostname", github.Hostname(),
+		interactive.IsURL)
+	if err != nil {
+		return nil, err
+	}
+	githubIDP = githubIDP.Hostname(hostname)
+	if hostname != "" {
+		ca, err := getCA(cmd, github.CA())
+		if err != nil {
+			return nil, err
+		}
+		githubIDP = githubIDP.CA(ca)
+	} else if args.caPath != "" {
+		return nil, errors.New("CA is not expected when not using a hosted instance of Github Enterprise")
+	}
+
+	return cmv1.NewIdentityProvider().Github(githubIDP), nil
+}
+
+func editGitlabIdp(cmd *cobra.Command, idp *cmv1.IdentityProvider) (*cmv1.IdentityProviderBuilder, error) {
+	gitlab := idp.Gitlab()
+	gitlabIDP := cmv1.NewGitlabIdentityProvider().Copy(gitlab)
+
+	gitlabURL, err := getString(cmd, "host-url", "URL", gitlab.URL(), idpPack.ValidateGitlabHostURL)
+	if err != nil {
+		return nil, err
+	}
+	gitlabIDP = gitlabIDP.URL(gitlabURL)
+	clientID, err := getString(cmd, "client-id", "Application ID", gitlab.ClientID())
+	if err != nil {
+		return nil, err
+	}
+	gitlabIDP = gitlabIDP.ClientID(clientID)
+	clientSecret, err := getSecret(cmd, "client-secret", "Secret")
+	if err != nil {
+		return nil, err
+	}
+	if clientSecret != "" {
+		gitlabIDP = gitlabIDP.ClientSecret(clientSecret)
+	}
+	ca, err := getCA(cmd, gitlab.CA())
+	if err != nil {
+		return nil, err
+	}
+	gitlabIDP = gitlabIDP.CA(ca)
+
+	return cmv1.NewIdentityProvider().Gitlab(gitlabIDP), nil
+}
+
+func editGoogleIdp(cmd *cobra.Command, idp *cmv1.IdentityProvider) (*cmv1.IdentityProviderBuilder, error) {
+	google := idp.Google()
+	googleIDP := cmv1.NewGoogleIdentityProvider().Copy(google)
+
+	clientID, err := getString(cmd, "client-id", "Client ID", google.ClientID())
+	if err != nil {
+		return nil, err
+	}
+	googleIDP = googleIDP.ClientID(clientID)
+	clientSecret, err := getSecret(cmd, "client-secret", "Client Secret")
+	if err != nil {
+		return nil, err
+	}
+	if clientSecret != "" {
+		googleIDP = googleIDP.ClientSecret(clientSecret)
+	}
+	hostedDomain, err := getString(cmd, "hosted-domain", "Hosted domain", google.HostedDomain())
+	if err != nil {
+		return nil, err
+	}
+	googleIDP = googleIDP.HostedDomain(hostedDomain)
+
+	return cmv1.NewIdentityProvider().Google(googleIDP), nil
+}
+
+func editLdapIdp(cmd *cobra.Command, idp *cmv1.IdentityProvider) (*cmv1.IdentityProviderBuilder, error) {
+	ldap := idp.LDAP()
+	ldapIDP := cmv1.NewLDAPIdentityProvider().Copy(ldap)
+
+	ldapURL, err := getString(cmd, "url", "LDAP URL", ldap.URL(), idpPack.ValidateLdapURL)
+	if err != nil {
+		return nil, err
+	}
+	ldapIDP = ldapIDP.URL(ldapURL)
+	insecure, err := getBool(cmd, "insecure", "Insecure", ldap.Insecure())
+	if err != nil {
+		return nil, err
+	}
+	ldapIDP = ldapIDP.Insecure(insecure)
+	if !insecure {
+		ca, err := getCA(cmd, ldap.CA())
+		if err != nil {
+			return nil, err
+		}
+		ldapIDP = ldapIDP.CA(ca)
+	} else if args.caPath != "" {
+		return nil, errors.New("Cannot use certificate bundle with an insecure connection")
+	}
+
+	bindDN, err := getString(cmd, "bind-dn", "Bind DN", ldap.BindDN())
+	if err != nil {
+		return nil, err
+	}
+	ldapIDP = ldapIDP.BindDN(bindDN)
+	if bindDN != "" {
+		bindPassword, err := getSecret(cmd, "bind-password", "Bind password")
+		if err != nil {
+			return nil, err
+		}
+		if bindPassword != "" {
+			ldapIDP = ldapIDP.BindPassword(bindPassword)
+		}
+	}
+
+	attributes := ldap.Attributes()
+	ids, err := getList(cmd, "id-attributes", "ID", attributes.ID())
+	if err != nil {
+		return nil, err
+	}
+	usernames, err := getList(cmd, "username-attributes", "Preferred username", attributes.PreferredUsername())
+	if err != nil {
+		return nil, err
+	}
+	names, err := getList(cmd, "name-attributes", "Name", attributes.Name())
+	if err != nil {
+		return nil, err
+	}
+	emails, err := getList(cmd, "email-attributes", "Email", attributes.Email())
+	if err != nil {
+		return nil, err
+	}
+	ldapIDP = ldapIDP.Attributes(cmv1.NewLDAPAttributes().
+		ID(ids...).
+		PreferredUsername(usernames...).
+		Name(names...).
+		Email(emails...))
+
+	return cmv1.NewIdentityProvider().LDAP(ldapIDP), nil
+}
+
+func editOpenidIdp(cmd *cobra.Command, idp *cmv1.IdentityProvider) (*cmv1.IdentityProviderBuilder, error) {
+	openID := idp.OpenID()
+	openIDIDP := cmv1.NewOpenIDIdentityProvider().Copy(openID)
+
+	issuerURL, err := getString(cmd, "issuer-url", "Issuer URL", openID.Issuer(),
+		idpPack.ValidateOpenidIssuerURL)
+	if err != nil {
+		return nil, err
+	}
+	openIDIDP = openIDIDP.Issuer(issuerURL)
+	clientID, err := getString(cmd, "client-id", "Client ID", openID.ClientID())
+	if err != nil {
+		return nil, err
+	}
+	openIDIDP = openIDIDP.ClientID(clientID)
+	clientSecret, err := getSecret(cmd, "client-secret", "Client Secret")
+	if err != nil {
+		return nil, err
+	}
+	if clientSecret != "" {
+		openIDIDP = openIDIDP.ClientSecret(clientSecret)
+	}
+	ca, err := getCA(cmd, openID.CA())
+	if err != nil {
+		return nil, err
+	}
+	openIDIDP = openIDIDP.CA(ca)
+
+	claims := openID.Claims()
+	emails, err := getList(cmd, "email-claims", "Email claims", claims.Email())
+	if err != nil {
+		return nil, err
+	}
+	names, err := getList(cmd, "name-claims", "Name claims", claims.Name())
+	if err != nil {
+		return nil, err
+	}
+	usernames, err := getList(cmd, "username-claims", "Preferred username claims", claims.PreferredUsername())
+	if err != nil {
+		return nil, err
+	}
+	groups, err := getList(cmd, "groups-claims", "Groups claims", claims.Groups())
+	if err != nil {
+		return nil, err
+	}
+	if len(emails) == 0 && len(names) == 0 && len(usernames) == 0 {
+		return nil, errors.New("At least one claim is required: [email-claims name-claims username-claims]")
+	}
+	openIDIDP = openIDIDP.Claims(cmv1.NewOpenIDClaims().
+		Email(emails...).
+		Name(names...).
+		PreferredUsername(usernames...).
+		Groups(groups...))
+
+	scopes, err := getList(cmd, "extra-scopes", "Additional scopes", openID.ExtraScopes())
+	if err != nil {
+		return nil, err
+	}
+	openIDIDP = openIDIDP.ExtraScopes(scopes...)
+
+	return cmv1.NewIdentityProvider().OpenID(openIDIDP), nil
+}
@@ -20,25 +20,22 @@ import (
 	"fmt"
 	"os"
 	"reflect"
-	"regexp"
 	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	helper "github.com/openshift/rosa/pkg/helper/ingresses"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
-// Regular expression to used to make sure that the identifier given by the
-// user is safe and that it there is no risk of SQL injection:
-var ingressKeyRE = regexp.MustCompile(`^[a-z0-9]{3,5}$`)
-
 var args struct {
 	private    bool
 	labelMatch string
+	router     helper.RouterArgs
 }
 
 var Cmd = &cobra.Command{
@@ -53,7 +50,13 @@ var Cmd = &cobra.Command{
   rosa edit ingress --label-match=foo=bar --cluster=mycluster a1b2
 
   # Update the default ingress using the sub-domain identifier
-  rosa edit ingress --private=false --cluster=mycluster apps`,
+  rosa edit ingress --private=false --cluster=mycluster apps
+
+  # Exclude namespaces from the default ingress and allow wildcard routes
+  rosa edit ingress --excluded-namespaces=stage,dev --wildcard-policy=WildcardsAllowed --cluster=mycluster apps
+
+  # Use a network load balancer for the default ingress
+  rosa edit ingress --lb-type=nlb --cluster=mycluster apps`,
 	Run: run,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
@@ -97,6 +100,8 @@ func init() {
 		"Label match for ingress. Format should be a comma-separated list of 'key=value'. "+
 			"If no label is specified, all routes will be exposed on both routers.",
 	)
+
+	helper.AddRouterFlags(flags, &args.router)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -104,7 +109,7 @@ func run(cmd *cobra.Command, argv []string) {
 	defer r.Cleanup()
 
 	ingressID := argv[0]
-	if !ingressKeyRE.MatchString(ingressID) {
+	if !helper.IngressKeyRE.MatchString(ingressID) {
 		r.Reporter.Errorf(
 			"Ingress  identifier '%s' isn't valid: it must contain only letters or digits",
 			ingressID,
@@ -114,7 +119,8 @@ func run(cmd *cobra.Command, argv []string) {
 
 	clusterKey := r.GetClusterKey()
 
-	if !interactive.Enabled() && shouldEnableInteractive(cmd.Flags(), []string{labelMatchFlag, privateFlag}) {
+	if !interactive.Enabled() && shouldEnableInteractive(cmd.Flags(),
+		append([]string{labelMatchFlag, privateFlag}, helper.RouterFlags...)) {
 		interactive.Enable()
 	}
 
@@ -151,13 +157,22 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	cluster := r.FetchCluster()
-	if cluster.AWS().PrivateLink() {
+	// The ingresses of hosted control plane clusters run on the data plane, so they don't depend on
+	// how the API is exposed
+	if cluster.AWS().PrivateLink() && !cluster.Hypershift().Enabled() {
 		r.Reporter.Errorf("Cluster '%s' is PrivateLink and does not support updating ingresses", clusterKey)
 		os.Exit(1)
 	}
 
 	// Edit API endpoint instead of ingresses
 	if ingressID == "api" {
+		for _, flag := range helper.RouterFlags {
+			if cmd.Flags().Changed(flag) {
+				r.Reporter.Errorf("Setting '%s' is not supported for the API endpoint", flag)
+				os.Exit(1)
+			}
+		}
+
 		clusterConfig := ocm.Spec{
 			Private: private,
 		}
@@ -179,23 +194,25 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
-	var ingress *cmv1.Ingress
-	for _, item := range ingresses {
-		if ingressID == "apps" && item.Default() {
-			ingress = item
-		}
-		if ingressID == "apps2" && !item.Default() {
-			ingress = item
-		}
-		if item.ID() == ingressID {
-			ingress = item
-		}
-	}
+	ingress := helper.GetIngress(ingresses, ingressID)
 	if ingress == nil {
 		r.Reporter.Errorf("Failed to get ingress '%s' for cluster '%s'", ingressID, clusterKey)
 		os.Exit(1)
 	}
 
+	curRouterOptions, err := r.OCMClient.GetIngressRouterOptions(cluster.ID(), ingress.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get router options of ingress '%s' for cluster '%s': %v",
+			ingress.ID(), clusterKey, err)
+		os.Exit(1)
+	}
+	routerOptions, err := helper.GetRouterOptions(cmd.Flags(), &args.router, curRouterOptions)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	routerOptions = helper.ChangedRouterOptions(routerOptions, curRouterOptions)
+
 	curListening := ingress.Listening()
 	curRouteSelectors := ingress.RouteSelectors()
 
@@ -231,13 +248,13 @@ func run(cmd *cobra.Command, argv []string) {
 	// If private arg is nil no change to listening method will be made anyway
 	sameListeningMethod := private == nil || curListening == ingress.Listening()
 
-	if sameListeningMethod && sameRouteSelectors {
+	if sameListeningMethod && sameRouteSelectors && routerOptions.IsEmpty() {
 		r.Reporter.Warnf("No need to update ingress as there are no changes")
 		os.Exit(0)
 	}
 
 	r.Reporter.Debugf("Updating ingress '%s' on cluster '%s'", ingress.ID(), clusterKey)
-	_, err = r.OCMClient.UpdateIngress(cluster.ID(), ingress)
+	_, err = r.OCMClient.UpdateIngress(cluster.ID(), ingress, routerOptions)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update ingress '%s' on cluster '%s': %s",
 			ingress.ID(), clusterKey, err)
@@ -18,9 +18,11 @@ package machinepool
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -34,6 +36,11 @@ var args struct {
 	taints             string
 	version            string
 	autorepair         bool
+	addLabels          string
+	removeLabels       string
+	addTaints          string
+	removeTaints       string
+	all                bool
 }
 
 var Cmd = &cobra.Command{
@@ -44,9 +51,19 @@ var Cmd = &cobra.Command{
 	Example: `  # Set 4 replicas on machine pool 'mp1' on cluster 'mycluster'
   rosa edit machinepool --replicas=4 --cluster=mycluster mp1
   # Enable autoscaling and Set 3-5 replicas on machine pool 'mp1' on cluster 'mycluster'
-  rosa edit machinepool --enable-autoscaling --min-replicas=3 --max-replicas=5 --cluster=mycluster mp1`,
+  rosa edit machinepool --enable-autoscaling --min-replicas=3 --max-replicas=5 --cluster=mycluster mp1
+  # Add a label and remove a taint on machine pool 'mp1' on cluster 'mycluster'
+  rosa edit machinepool --add-labels=team=a --remove-taints=dedicated:NoSchedule --cluster=mycluster mp1
+  # Add a label to all machine pools on cluster 'mycluster'
+  rosa edit machinepools --all --add-labels=team=a --cluster=mycluster`,
 	Run: run,
 	Args: func(_ *cobra.Command, argv []string) error {
+		if args.all {
+			if len(argv) != 0 {
+				return fmt.Errorf("The id of the machine pool can't be set when using '--all'")
+			}
+			return nil
+		}
 		if len(argv) != 1 {
 			return fmt.Errorf(
 				"Expected exactly one command line parameter containing the id of the machine pool",
@@ -119,16 +136,70 @@ func init() {
 		true,
 		"Select auto-repair behaviour for a machinepool in a hosted cluster.",
 	)
+
+	flags.StringVar(
+		&args.addLabels,
+		"add-labels",
+		"",
+		"Labels to add to the machine pool, keeping the existing ones. Format should be a "+
+			"comma-separated list of 'key=value'. Existing labels with the same key are overwritten.",
+	)
+
+	flags.StringVar(
+		&args.removeLabels,
+		"remove-labels",
+		"",
+		"Labels to remove from the machine pool. Format should be a comma-separated list of keys.",
+	)
+
+	flags.StringVar(
+		&args.addTaints,
+		"add-taints",
+		"",
+		"Taints to add to the machine pool, keeping the existing ones. Format should be a comma-separated "+
+			"list of 'key=value:Effect'. Existing taints with the same key and effect are overwritten.",
+	)
+
+	flags.StringVar(
+		&args.removeTaints,
+		"remove-taints",
+		"",
+		"Taints to remove from the machine pool. Format should be a comma-separated list of 'key' "+
+			"or 'key:Effect'.",
+	)
+
+	flags.BoolVar(
+		&args.all,
+		"all",
+		false,
+		"Apply the label and taint changes to all machine pools of the cluster.",
+	)
+
+	confirm.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, argv []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
-	machinePoolID := argv[0]
 	clusterKey := r.GetClusterKey()
 	cluster := r.FetchCluster()
 
+	if cmd.Flags().Changed("labels") && hasLabelChanges(cmd) {
+		r.Reporter.Errorf("Setting `labels` is not supported together with `add-labels` or `remove-labels`")
+		os.Exit(1)
+	}
+	if cmd.Flags().Changed("taints") && hasTaintChanges(cmd) {
+		r.Reporter.Errorf("Setting `taints` is not supported together with `add-taints` or `remove-taints`")
+		os.Exit(1)
+	}
+
+	if args.all {
+		editAllMachinePools(cmd, clusterKey, cluster, r)
+		return
+	}
+
+	machinePoolID := argv[0]
 	if cluster.Hypershift().Enabled() {
 		editNodePool(cmd, machinePoolID, clusterKey, cluster, r)
 	} else {
@@ -6,27 +6,24 @@ import (
 	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/spf13/cobra"
 )
 
 func getTaints(cmd *cobra.Command, r *rosa.Runtime, inputTaints []*cmv1.Taint) []*cmv1.TaintBuilder {
-	taintBuilders := []*cmv1.TaintBuilder{}
+	if hasTaintChanges(cmd) {
+		return mergeTaints(r.Reporter, inputTaints)
+	}
 	taints := args.taints
 	var err error
 	if interactive.Enabled() {
 		if taints == "" {
-			for _, taint := range inputTaints {
-				if taint == nil {
-					continue
-				}
-				if taints != "" {
-					taints += ","
-				}
-				taints += fmt.Sprintf("%s=%s:%s", taint.Key(), taint.Value(), taint.Effect())
-			}
+			taints = machinepools.TaintsString(inputTaints)
 		}
 		taints, err = interactive.GetString(interactive.Input{
 			Question: "Taints",
@@ -38,16 +35,10 @@ func getTaints(cmd *cobra.Command, r *rosa.Runtime, inputTaints []*cmv1.Taint) [
 			os.Exit(1)
 		}
 	}
-	taints = strings.Trim(taints, " ")
-	if taints != "" {
-		for _, taint := range strings.Split(taints, ",") {
-			if !strings.Contains(taint, "=") || !strings.Contains(taint, ":") {
-				r.Reporter.Errorf("Expected key=value:scheduleType format for taints")
-				os.Exit(1)
-			}
-			tokens := strings.FieldsFunc(taint, Split)
-			taintBuilders = append(taintBuilders, cmv1.NewTaint().Key(tokens[0]).Value(tokens[1]).Effect(tokens[2]))
-		}
+	taintBuilders, err := machinepools.ParseTaints(strings.Trim(taints, " "))
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
 	}
 	return taintBuilders
 }
@@ -55,17 +46,14 @@ func getTaints(cmd *cobra.Command, r *rosa.Runtime, inputTaints []*cmv1.Taint) [
 func getLabels(cmd *cobra.Command,
 	reporter *rprtr.Object,
 	existingLabels map[string]string) map[string]string {
+	if hasLabelChanges(cmd) {
+		return mergeLabels(reporter, existingLabels)
+	}
 	var err error
 	labels := args.labels
-	labelMap := make(map[string]string)
 	if interactive.Enabled() {
 		if labels == "" {
-			for lk, lv := range existingLabels {
-				if labels != "" {
-					labels += ","
-				}
-				labels += fmt.Sprintf("%s=%s", lk, lv)
-			}
+			labels = machinepools.LabelsString(existingLabels)
 		}
 		labels, err = interactive.GetString(interactive.Input{
 			Question: "Labels",
@@ -77,17 +65,187 @@ func getLabels(cmd *cobra.Command,
 			os.Exit(1)
 		}
 	}
+	labelMap, err := machinepools.ParseLabels(strings.Trim(labels, " "))
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	return labelMap
+}
+
+func hasLabelChanges(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("add-labels") || cmd.Flags().Changed("remove-labels")
+}
 
-	labels = strings.Trim(labels, " ")
-	if labels != "" {
-		for _, label := range strings.Split(labels, ",") {
-			if !strings.Contains(label, "=") {
-				reporter.Errorf("Expected key=value format for labels")
-				os.Exit(1)
-			}
-			tokens := strings.Split(label, "=")
-			labelMap[strings.TrimSpace(tokens[0])] = strings.TrimSpace(tokens[1])
+func hasTaintChanges(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("add-taints") || cmd.Flags().Changed("remove-taints")
+}
+
+// mergeLabels applies the '--add-labels' and '--remove-labels' flags to the existing labels
+func mergeLabels(reporter *rprtr.Object, existingLabels map[string]string) map[string]string {
+	add, err := machinepools.ParseLabels(args.addLabels)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	remove, err := machinepools.ParseLabelKeys(args.removeLabels)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	return machinepools.MergeLabels(existingLabels, add, remove)
+}
+
+// mergeTaints applies the '--add-taints' and '--remove-taints' flags to the existing taints
+func mergeTaints(reporter *rprtr.Object, existingTaints []*cmv1.Taint) []*cmv1.TaintBuilder {
+	add, err := machinepools.ParseTaints(args.addTaints)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	remove, err := machinepools.ParseTaintSelectors(args.removeTaints)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	taintBuilders, err := machinepools.MergeTaints(existingTaints, add, remove)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	return taintBuilders
+}
+
+// editAllMachinePools applies the label and taint changes to every machine pool of the cluster
+func editAllMachinePools(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r *rosa.Runtime) {
+	for _, flag := range []string{"replicas", "enable-autoscaling", "min-replicas", "max-replicas",
+		"labels", "taints", "version", "autorepair"} {
+		if cmd.Flags().Changed(flag) {
+			r.Reporter.Errorf("Setting `%s` is not supported together with `all`. Only `add-labels`, "+
+				"`remove-labels`, `add-taints` and `remove-taints` can be used", flag)
+			os.Exit(1)
 		}
 	}
-	return labelMap
+	labelsChanged := hasLabelChanges(cmd)
+	taintsChanged := hasTaintChanges(cmd)
+	if !labelsChanged && !taintsChanged {
+		r.Reporter.Errorf("Expected at least one of `add-labels`, `remove-labels`, `add-taints` " +
+			"or `remove-taints` when using `all`")
+		os.Exit(1)
+	}
+	// Validate the changes before touching any machine pool
+	mergeLabels(r.Reporter, nil)
+	mergeTaints(r.Reporter, nil)
+
+	if !confirm.Confirm("update the labels and taints of all machine pools on cluster '%s'", clusterKey) {
+		os.Exit(0)
+	}
+
+	var failed []string
+	if cluster.Hypershift().Enabled() {
+		failed = editAllNodePools(r, clusterKey, cluster, labelsChanged, taintsChanged)
+	} else {
+		failed = editAllClassicMachinePools(r, clusterKey, cluster, labelsChanged, taintsChanged)
+	}
+	if len(failed) > 0 {
+		r.Reporter.Errorf("Failed to update machine pools %s on cluster '%s'",
+			strings.Join(failed, ", "), clusterKey)
+		os.Exit(1)
+	}
+}
+
+func editAllClassicMachinePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster,
+	labelsChanged bool, taintsChanged bool) []string {
+	failed := []string{}
+
+	// The labels of the default machine pool are part of the cluster
+	if labelsChanged {
+		clusterConfig := ocm.Spec{
+			ComputeLabels: mergeLabels(r.Reporter, cluster.Nodes().ComputeLabels()),
+		}
+		r.Reporter.Debugf("Updating machine pool 'Default' on cluster '%s'", clusterKey)
+		err := r.OCMClient.UpdateCluster(clusterKey, r.Creator, clusterConfig)
+		if err != nil {
+			r.Reporter.Warnf("Failed to update machine pool 'Default' on cluster '%s': %s", clusterKey, err)
+			failed = append(failed, "'Default'")
+		} else {
+			r.Reporter.Infof("Updated machine pool 'Default' on cluster '%s'", clusterKey)
+		}
+	}
+	if taintsChanged {
+		r.Reporter.Warnf("Taints are not supported on the Default machine pool, skipping it")
+	}
+
+	r.Reporter.Debugf("Loading machine pools for cluster '%s'", clusterKey)
+	machinePools, err := r.OCMClient.GetMachinePools(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, machinePool := range machinePools {
+		// The default machine pool was already updated through the cluster
+		if isDefaultMachinePool(machinePool.ID()) {
+			continue
+		}
+		mpBuilder := cmv1.NewMachinePool().ID(machinePool.ID())
+		if labelsChanged {
+			mpBuilder = mpBuilder.Labels(mergeLabels(r.Reporter, machinePool.Labels()))
+		}
+		if taintsChanged {
+			mpBuilder = mpBuilder.Taints(mergeTaints(r.Reporter, machinePool.Taints())...)
+		}
+		update, err := mpBuilder.Build()
+		if err == nil {
+			r.Reporter.Debugf("Updating machine pool '%s' on cluster '%s'", machinePool.ID(), clusterKey)
+			_, err = r.OCMClient.UpdateMachinePool(cluster.ID(), update)
+		}
+		if err != nil {
+			r.Reporter.Warnf("Failed to update machine pool '%s' on cluster '%s': %s",
+				machinePool.ID(), clusterKey, err)
+			failed = append(failed, fmt.Sprintf("'%s'", machinePool.ID()))
+			continue
+		}
+		r.Reporter.Infof("Updated machine pool '%s' on cluster '%s'", machinePool.ID(), clusterKey)
+	}
+	return failed
+}
+
+// isDefaultMachinePool checks if the machine pool is the one created with the cluster, which some
+// clusters return with the 'worker' ID
+func isDefaultMachinePool(id string) bool {
+	return id == "Default" || id == "worker"
+}
+
+func editAllNodePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster,
+	labelsChanged bool, taintsChanged bool) []string {
+	failed := []string{}
+
+	r.Reporter.Debugf("Loading machine pools for hosted cluster '%s'", clusterKey)
+	nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, nodePool := range nodePools {
+		npBuilder := cmv1.NewNodePool().ID(nodePool.ID())
+		if labelsChanged {
+			npBuilder = npBuilder.Labels(mergeLabels(r.Reporter, nodePool.Labels()))
+		}
+		if taintsChanged {
+			npBuilder = npBuilder.Taints(mergeTaints(r.Reporter, nodePool.Taints())...)
+		}
+		update, err := npBuilder.Build()
+		if err == nil {
+			r.Reporter.Debugf("Updating machine pool '%s' on hosted cluster '%s'", nodePool.ID(), clusterKey)
+			_, err = r.OCMClient.UpdateNodePool(cluster.ID(), update)
+		}
+		if err != nil {
+			r.Reporter.Warnf("Failed to update machine pool '%s' on hosted cluster '%s': %s",
+				nodePool.ID(), clusterKey, err)
+			failed = append(failed, fmt.Sprintf("'%s'", nodePool.ID()))
+			continue
+		}
+		r.Reporter.Infof("Updated machine pool '%s' on hosted cluster '%s'", nodePool.ID(), clusterKey)
+	}
+	return failed
 }
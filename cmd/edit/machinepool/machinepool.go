@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
@@ -40,8 +41,8 @@ func editMachinePool(cmd *cobra.Command, machinePoolID string, clusterKey string
 	isMaxReplicasSet := cmd.Flags().Changed("max-replicas")
 	isReplicasSet := cmd.Flags().Changed("replicas")
 	isAutoscalingSet := cmd.Flags().Changed("enable-autoscaling")
-	isLabelsSet := cmd.Flags().Changed("labels")
-	isTaintsSet := cmd.Flags().Changed("taints")
+	isLabelsSet := cmd.Flags().Changed("labels") || hasLabelChanges(cmd)
+	isTaintsSet := cmd.Flags().Changed("taints") || hasTaintChanges(cmd)
 
 	// if no value set enter interactive mode
 	if !(isMinReplicasSet || isMaxReplicasSet || isReplicasSet || isAutoscalingSet || isLabelsSet || isTaintsSet) {
@@ -62,21 +63,10 @@ func editMachinePool(cmd *cobra.Command, machinePoolID string, clusterKey string
 				cluster.Nodes().AutoscaleCompute(), !isLabelsSet)
 
 		if scalingUpdated {
-			if cluster.MultiAZ() {
-				if !autoscaling && replicas < 3 ||
-					(autoscaling && isMinReplicasSet && minReplicas < 3) {
-					r.Reporter.Errorf("Default machine pool for AZ cluster requires at least 3 compute nodes")
-					os.Exit(1)
-				}
-
-				if !autoscaling && replicas%3 != 0 ||
-					(autoscaling && (minReplicas%3 != 0 || maxReplicas%3 != 0)) {
-					r.Reporter.Errorf("Multi AZ clusters require that the number of compute nodes be a multiple of 3")
-					os.Exit(1)
-				}
-			} else if !autoscaling && replicas < 2 ||
-				(autoscaling && isMinReplicasSet && minReplicas < 2) {
-				r.Reporter.Errorf("Default machine pool requires at least 2 compute nodes")
+			err = machinepools.ValidateDefaultMachinePoolReplicas(cluster.MultiAZ(), autoscaling, replicas,
+				minReplicas, maxReplicas, isMinReplicasSet)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
 				os.Exit(1)
 			}
 
@@ -130,16 +120,10 @@ func editMachinePool(cmd *cobra.Command, machinePoolID string, clusterKey string
 			!isLabelsSet && !isTaintsSet)
 
 	if scalingUpdated {
-		if !autoscaling && replicas < 0 ||
-			(autoscaling && isMinReplicasSet && minReplicas < 0) {
-			r.Reporter.Errorf("The number of machine pool replicas needs to be a non-negative integer")
-			os.Exit(1)
-		}
-
-		if cluster.MultiAZ() && isMultiAZMachinePool(machinePool) &&
-			(!autoscaling && replicas%3 != 0 ||
-				(autoscaling && (minReplicas%3 != 0 || maxReplicas%3 != 0))) {
-			r.Reporter.Errorf("Multi AZ clusters require that the number of MachinePool replicas be a multiple of 3")
+		err = machinepools.ValidateMachinePoolReplicas(cluster.MultiAZ() && isMultiAZMachinePool(machinePool),
+			autoscaling, replicas, minReplicas, maxReplicas, isMinReplicasSet)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
 		}
 	}
@@ -296,10 +280,6 @@ func getMachinePoolReplicas(cmd *cobra.Command,
 	return
 }
 
-func Split(r rune) bool {
-	return r == '=' || r == ':'
-}
-
 // Single-AZ: AvailabilityZones == []string{"us-east-1a"}
 func isMultiAZMachinePool(machinePool *cmv1.MachinePool) bool {
 	return len(machinePool.AvailabilityZones()) != 1
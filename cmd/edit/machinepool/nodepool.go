@@ -20,8 +20,8 @@ func editNodePool(cmd *cobra.Command, nodePoolID string, clusterKey string, clus
 	isMaxReplicasSet := cmd.Flags().Changed("max-replicas")
 	isReplicasSet := cmd.Flags().Changed("replicas")
 	isAutoscalingSet := cmd.Flags().Changed("enable-autoscaling")
-	isLabelsSet := cmd.Flags().Changed("labels")
-	isTaintsSet := cmd.Flags().Changed("taints")
+	isLabelsSet := cmd.Flags().Changed("labels") || hasLabelChanges(cmd)
+	isTaintsSet := cmd.Flags().Changed("taints") || hasTaintChanges(cmd)
 	isLabelOrTaintSet := isLabelsSet || isTaintsSet
 	isVersionSet := cmd.Flags().Changed("version")
 	isAutorepairSet := cmd.Flags().Changed("autorepair")
@@ -18,11 +18,13 @@ package service
 
 import (
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -33,9 +35,14 @@ var Cmd = &cobra.Command{
 	Use:     "managed-service",
 	Aliases: []string{"appliance", "service"},
 	Short:   "Edit parameters of service",
-	Long:    "Edit the parameters of a Red Hat managed service",
+	Long: "Edit the parameters of a Red Hat managed service. Values are validated against the " +
+		"parameters of the add-on of the service. Without any parameter the command prompts for all " +
+		"the editable parameters, using the current values as defaults.",
 	Example: `  # Edit the parameters of the Red Hat OpenShift logging operator add-on installation
-  rosa edit managed-service --id=<service id> --parameter-key parameter-value`,
+  rosa edit managed-service --id=<service id> --parameter-key parameter-value
+
+  # Edit the parameters of a service following interactive prompts
+  rosa edit managed-service --id=<service id> --interactive`,
 	Run:                run,
 	Hidden:             true,
 	DisableFlagParsing: true,
@@ -50,6 +57,8 @@ func init() {
 		"",
 		"The id of the service to describe",
 	)
+
+	interactive.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -83,6 +92,10 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	addonParameters := addOn.Parameters()
+	if addonParameters.Len() == 0 {
+		r.Reporter.Errorf("Service %q has no parameters to edit", args.ID)
+		os.Exit(1)
+	}
 	addonParameters.Each(func(param *cmv1.AddOnParameter) bool {
 		arguments.AddStringFlag(cmd, param.ID())
 		return true
@@ -94,22 +107,69 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	currentValues := map[string]string{}
+	for _, param := range service.Parameters() {
+		currentValues[param.ID()] = param.Value()
+	}
+
+	// Prompt for all the editable parameters when none of them is set as a flag
+	isParameterSet := false
+	addonParameters.Each(func(param *cmv1.AddOnParameter) bool {
+		if cmd.Flags().Changed(param.ID()) {
+			isParameterSet = true
+		}
+		return true
+	})
+	if !isParameterSet {
+		interactive.Enable()
+	}
+
 	args.Parameters = map[string]string{}
 	addonParameters.Each(func(param *cmv1.AddOnParameter) bool {
+		current, isSet := currentValues[param.ID()]
+		if !isSet {
+			current = param.DefaultValue()
+		}
+		val := current
 		flag := cmd.Flags().Lookup(param.ID())
 		// Checking if the flag changed to ensure that the user set the value.
 		if flag != nil && flag.Changed {
-			args.Parameters[param.ID()] = flag.Value.String()
+			if isSet && !param.Editable() {
+				r.Reporter.Errorf("Parameter '%s' on service %q cannot be modified", param.ID(), args.ID)
+				os.Exit(1)
+			}
+			val = flag.Value.String()
+		}
+		if interactive.Enabled() && (!isSet || param.Editable()) {
+			val, err = interactive.GetAddonArgument(*param, val)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+		}
+		val = strings.Trim(val, " ")
+		err = ocm.ValidateAddOnParameter(param, val)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if val != current || (!isSet && val != "") {
+			args.Parameters[param.ID()] = val
 		}
 		return true
 	})
 
+	if len(args.Parameters) == 0 {
+		r.Reporter.Infof("No parameters of service %q have changed", args.ID)
+		return
+	}
+
 	r.Reporter.Debugf("Updating parameters for service %q", args.ID)
 	err = r.OCMClient.UpdateManagedService(args)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update service %q: %v", args.ID, err)
 		os.Exit(1)
 	}
-	r.Reporter.Infof("Service %q is now updating. To check the status run 'rosa describe service --id %s'",
+	r.Reporter.Infof("Service %q is now updating. To check the status run 'rosa logs service --id %s'",
 		args.ID, args.ID)
 }
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper/upgrades"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	schedule string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "upgrade",
+	Aliases: []string{"upgrades"},
+	Short:   "Edit the recurring upgrade policy of a cluster",
+	Long:    "Edit the schedule of the recurring automatic upgrades of a cluster",
+	Example: `  # Run the automatic upgrades of the cluster named "mycluster" every Saturday at 10pm UTC
+  rosa edit upgrade --cluster=mycluster --schedule="0 22 * * SAT"`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.schedule,
+		"schedule",
+		"",
+		"Cron expression in UTC of the recurring automatic upgrades of the cluster, for example '0 2 * * SUN'",
+	)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	var currentSchedule string
+	var classicPolicy *cmv1.UpgradePolicy
+	var controlPlanePolicy *cmv1.ControlPlaneUpgradePolicy
+	var err error
+	if cluster.Hypershift().Enabled() {
+		controlPlanePolicy, err = r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err == nil && controlPlanePolicy != nil {
+			currentSchedule = controlPlanePolicy.Schedule()
+			checkScheduleType(r, controlPlanePolicy.ScheduleType(), clusterKey)
+		}
+	} else {
+		classicPolicy, _, err = r.OCMClient.GetScheduledUpgrade(cluster.ID())
+		if err == nil && classicPolicy != nil {
+			currentSchedule = classicPolicy.Schedule()
+			checkScheduleType(r, classicPolicy.ScheduleType(), clusterKey)
+		}
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if classicPolicy == nil && controlPlanePolicy == nil {
+		r.Reporter.Errorf("There are no automatic upgrades scheduled for cluster '%s'. "+
+			"Run 'rosa upgrade cluster -c %s --schedule' to schedule them", clusterKey, clusterKey)
+		os.Exit(1)
+	}
+
+	schedule := args.schedule
+	if schedule == "" {
+		interactive.Enable()
+		schedule = currentSchedule
+	}
+	if interactive.Enabled() {
+		schedule, err = interactive.GetString(interactive.Input{
+			Question: "Schedule",
+			Help:     cmd.Flags().Lookup("schedule").Usage,
+			Default:  schedule,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid schedule: %s", err)
+			os.Exit(1)
+		}
+	}
+	err = upgrades.ValidateSchedule(schedule)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	if schedule == currentSchedule {
+		r.Reporter.Warnf("The schedule of the automatic upgrades of cluster '%s' is already '%s'",
+			clusterKey, schedule)
+		os.Exit(0)
+	}
+
+	r.Reporter.Debugf("Updating the schedule of the automatic upgrades of cluster '%s'", clusterKey)
+	if controlPlanePolicy != nil {
+		controlPlanePolicy, err = cmv1.NewControlPlaneUpgradePolicy().
+			ID(controlPlanePolicy.ID()).
+			Schedule(schedule).
+			Build()
+		if err == nil {
+			err = r.OCMClient.UpdateControlPlaneUpgradePolicy(cluster.ID(), controlPlanePolicy)
+		}
+	} else {
+		classicPolicy, err = cmv1.NewUpgradePolicy().
+			ID(classicPolicy.ID()).
+			Schedule(schedule).
+			Build()
+		if err == nil {
+			err = r.OCMClient.UpdateUpgradePolicy(cluster.ID(), classicPolicy)
+		}
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to update the automatic upgrades of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Updated the schedule of the automatic upgrades of cluster '%s' to '%s'", clusterKey, schedule)
+}
+
+func checkScheduleType(r *rosa.Runtime, scheduleType string, clusterKey string) {
+	if scheduleType != upgrades.ScheduleTypeAutomatic {
+		r.Reporter.Errorf("The upgrade scheduled for cluster '%s' is not recurring. "+
+			"Run 'rosa delete upgrade -c %s' and schedule it again to change it", clusterKey, clusterKey)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	by time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Extend the expiration of a cluster",
+	Long: "Extend the expiration of a cluster. The extension starts from the current expiration time, " +
+		"and is limited by the cluster lifetime and extension maximums of the organization.",
+	Example: `  # Delay the expiration of cluster "mycluster" by 2 days
+  rosa extend cluster -c mycluster --by 48h`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.DurationVar(
+		&args.by,
+		"by",
+		0,
+		"Duration to extend the expiration of the cluster by, like 24h or 48h.",
+	)
+	Cmd.MarkFlagRequired("by")
+
+	confirm.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	policy, err := r.OCMClient.GetExpirationPolicy()
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the expiration policy of the organization: %v", err)
+		os.Exit(1)
+	}
+
+	expiration, err := policy.ExtendExpiration(cluster, args.by, time.Now())
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if !confirm.Confirm("extend the expiration of cluster %s to %s", clusterKey,
+		expiration.Format("2006-01-02 15:04 MST")) {
+		os.Exit(0)
+	}
+
+	r.Reporter.Debugf("Updating expiration of cluster '%s'", clusterKey)
+	err = r.OCMClient.UpdateCluster(clusterKey, r.Creator, ocm.Spec{Expiration: expiration})
+	if err != nil {
+		r.Reporter.Errorf("Failed to update cluster: %v", err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Cluster '%s' will now expire on %s", clusterKey, expiration.Format("2006-01-02 15:04 MST"))
+}
@@ -0,0 +1,208 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package group
+
+import (
+	"fmt"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/helper/groups"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	idpName      string
+	group        string
+	bindPassword string
+	githubToken  string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "group ROLE",
+	Aliases: []string{"groups"},
+	Short:   "Grant the members of an identity provider group access to cluster",
+	Long: "Grant a role to the members of a group of an identity provider. Groups are LDAP group " +
+		"DNs and GitHub teams. The members of the group are looked up when the command runs, run it " +
+		"again or use 'rosa sync users' to pick up later membership changes.",
+	Example: `  # Grant the dedicated-admins role to the members of an LDAP group
+  rosa grant group dedicated-admin --idp=ldap-1 --group=cn=admins,ou=groups,dc=example,dc=com \
+    --bind-password=<password> --cluster=mycluster
+
+  # Grant the cluster-admins role to the members of a GitHub team
+  GITHUB_TOKEN=<token> rosa grant group cluster-admin --idp=github-1 --group=myorg/sre --cluster=mycluster`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line argument containing the name " +
+					"of the group or role to grant the members of the group.",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.idpName,
+		"idp",
+		"",
+		"Name of the identity provider that the group belongs to (required).",
+	)
+	Cmd.MarkFlagRequired("idp")
+
+	flags.StringVar(
+		&args.group,
+		"group",
+		"",
+		"Group of the identity provider: the DN of an LDAP group or a GitHub team in '<org>/<team>' "+
+			"format (required).",
+	)
+	Cmd.MarkFlagRequired("group")
+
+	flags.StringVar(
+		&args.bindPassword,
+		"bind-password",
+		"",
+		"LDAP: Password of the bind DN of the identity provider.",
+	)
+
+	flags.StringVar(
+		&args.githubToken,
+		"github-token",
+		"",
+		"GitHub: Token used to list the members of the team. Defaults to the GITHUB_TOKEN environment variable.",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	// The token isn't used as the default value of the flag, as that would show it in the help
+	githubToken := args.githubToken
+	if githubToken == "" {
+		githubToken = os.Getenv("GITHUB_TOKEN")
+	}
+
+	role, err := ocm.GetUserRole(argv[0])
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	identityProvider := findIdentityProvider(r, cluster, clusterKey, args.idpName)
+
+	r.Reporter.Debugf("Loading members of group '%s' of identity provider '%s'", args.group, args.idpName)
+	usernames, err := groups.ResolveMembers(identityProvider, args.group, groups.Options{
+		LDAPBindPassword: args.bindPassword,
+		GithubToken:      githubToken,
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to get members of group '%s': %v", args.group, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading users with role '%s' in cluster '%s'", role, clusterKey)
+	users, err := r.OCMClient.GetUsers(cluster.ID(), role)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get users with role '%s' for cluster '%s': %v", role, clusterKey, err)
+		os.Exit(1)
+	}
+	existing := map[string]bool{}
+	for _, user := range users {
+		existing[user.ID()] = true
+	}
+
+	pending := []string{}
+	for _, username := range usernames {
+		if existing[username] {
+			continue
+		}
+		if !ocm.IsValidUsername(username) || username == idp.ClusterAdminUsername {
+			r.Reporter.Warnf("Skipping member '%s' of group '%s': username is not allowed", username, args.group)
+			continue
+		}
+		existing[username] = true
+		pending = append(pending, username)
+	}
+	if len(pending) == 0 {
+		r.Reporter.Infof("All %d members of group '%s' already have role '%s' on cluster '%s'",
+			len(usernames), args.group, role, clusterKey)
+		return
+	}
+
+	if !confirm.Confirm("grant role '%s' to %d members of group '%s' on cluster '%s'",
+		role, len(pending), args.group, clusterKey) {
+		os.Exit(0)
+	}
+
+	for _, username := range pending {
+		user, err := cmv1.NewUser().ID(username).Build()
+		if err != nil {
+			r.Reporter.Errorf("Failed to create user '%s' for cluster '%s'", username, clusterKey)
+			os.Exit(1)
+		}
+		r.Reporter.Debugf("Adding user '%s' to group '%s' in cluster '%s'", username, role, clusterKey)
+		_, err = r.OCMClient.CreateUser(cluster.ID(), role, user)
+		if err != nil {
+			r.Reporter.Errorf("Failed to grant '%s' to user '%s' to cluster '%s': %s",
+				role, username, clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Granted role '%s' to user '%s' on cluster '%s'", role, username, clusterKey)
+	}
+}
+
+func findIdentityProvider(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string,
+	idpName string) *cmv1.IdentityProvider {
+	r.Reporter.Debugf("Loading identity provider '%s'", idpName)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, item := range idps {
+		if item.Name() == idpName {
+			return item
+		}
+	}
+	r.Reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", idpName, clusterKey)
+	os.Exit(1)
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper/upgrades"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	// Certificates of new clusters are rotated for the first time after a day
+	minimumClusterAge = 24 * time.Hour
+	// Clusters whose certificates expire while hibernating may not be able to resume
+	certificateExpiryMargin = 7 * 24 * time.Hour
+	// Upgrades that run while a cluster is hibernating fail
+	upgradeMargin = 24 * time.Hour
+)
+
+// CheckHibernation returns the reasons why it isn't safe to hibernate the cluster now, and warnings
+// about the checks that couldn't be done. The warnings are returned instead of reported so that
+// callers that don't write to the terminal can show them.
+func CheckHibernation(r *rosa.Runtime, cluster *cmv1.Cluster) (reasons []string, warnings []string, err error) {
+	reasons = []string{}
+	warnings = []string{}
+	now := time.Now().UTC()
+
+	// Only a warning, so that new clusters can still be hibernated
+	if now.Sub(cluster.CreationTimestamp()) < minimumClusterAge {
+		warnings = append(warnings, fmt.Sprintf("The cluster was created less than %s ago and its "+
+			"certificates haven't been rotated yet, it may fail to resume", minimumClusterAge))
+	}
+
+	expiry, err := getAPICertificateExpiry(cluster.API().URL())
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Unable to check the certificate of the API of the cluster: %v", err))
+	} else if expiry.Sub(now) < certificateExpiryMargin {
+		reasons = append(reasons, fmt.Sprintf("The certificate of the API of the cluster expires on %s",
+			expiry.Format("2006-01-02 15:04 MST")))
+	}
+
+	scheduledUpgrade, upgradeState, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to get scheduled upgrades: %v", err)
+	}
+	if scheduledUpgrade != nil {
+		switch {
+		case upgradeState.Value() == cmv1.UpgradePolicyStateValueStarted:
+			reasons = append(reasons, fmt.Sprintf("The upgrade to version %s is in progress",
+				scheduledUpgrade.Version()))
+		case scheduledUpgrade.ScheduleType() != upgrades.ScheduleTypeAutomatic:
+			reasons = append(reasons, fmt.Sprintf("An upgrade to version %s is scheduled on %s",
+				scheduledUpgrade.Version(), scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST")))
+		case scheduledUpgrade.NextRun().Sub(now) < upgradeMargin:
+			reasons = append(reasons, fmt.Sprintf("An automatic upgrade is scheduled on %s",
+				scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST")))
+		}
+	}
+	return reasons, warnings, nil
+}
+
+// getAPICertificateExpiry returns when the certificate served by the API of the cluster expires
+func getAPICertificateExpiry(apiURL string) (time.Time, error) {
+	parsed, err := url.Parse(apiURL)
+	if err != nil || parsed.Host == "" {
+		return time.Time{}, fmt.Errorf("invalid API URL '%s'", apiURL)
+	}
+	host := parsed.Host
+	if parsed.Port() == "" {
+		host = net.JoinHostPort(parsed.Hostname(), "443")
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	// The certificate is only inspected, not trusted
+	// nolint:gosec
+	conn, err := tls.DialWithDialer(dialer, "tcp", host, &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer conn.Close()
+	certificates := conn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return time.Time{}, fmt.Errorf("the API didn't send a certificate")
+	}
+	return certificates[0].NotAfter, nil
+}
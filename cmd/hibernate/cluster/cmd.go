@@ -18,26 +18,66 @@ package cluster
 
 import (
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/helper/cron"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	schedule      string
+	applySchedule bool
+}
+
 var Cmd = &cobra.Command{
 	Use:   "cluster",
 	Short: "Hibernate cluster",
-	Long:  "Hibernate cluster.",
+	Long: "Hibernate cluster.\n\n" +
+		"Schedules set with '--schedule' are only stored with the cluster, nothing applies " +
+		"them automatically. Run 'rosa hibernate cluster --apply-schedule' periodically from a scheduler " +
+		"of your own, like cron or a CI pipeline, to hibernate the cluster when its schedule says so.",
 	Example: `  # Hibernate the cluster
-  rosa hibernate cluster -c mycluster`,
+  rosa hibernate cluster -c mycluster
+
+  # Hibernate the cluster every week day at 8pm UTC,
+  # together with 'rosa resume cluster --schedule'
+  rosa hibernate cluster -c mycluster --schedule "0 20 * * 1-5"
+
+  # Hibernate the cluster if its schedule says so, meant to be run periodically by an
+  # external scheduler, like cron
+  rosa hibernate cluster -c mycluster --apply-schedule`,
 	Run: run,
 }
 
 func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
 	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.schedule,
+		"schedule",
+		"",
+		"Cron expression in UTC of when the cluster should be hibernated. An empty value removes the schedule. "+
+			"The schedule isn't applied automatically, an external scheduler has to run the command with "+
+			"'--apply-schedule' periodically.",
+	)
+
+	flags.BoolVar(
+		&args.applySchedule,
+		"apply-schedule",
+		false,
+		"Hibernate the cluster only if its hibernation schedule ran more recently than its resume schedule.",
+	)
+
+	confirm.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -48,6 +88,36 @@ func run(cmd *cobra.Command, _ []string) {
 
 	cluster := r.FetchCluster()
 
+	if cmd.Flags().Changed("schedule") {
+		if args.applySchedule {
+			r.Reporter.Errorf("The '--schedule' and '--apply-schedule' options can't be used together")
+			os.Exit(1)
+		}
+		setSchedule(r, cluster, clusterKey, args.schedule)
+		return
+	}
+
+	if args.applySchedule {
+		schedule, err := r.OCMClient.GetHibernationSchedule(cluster)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get hibernation schedule of cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		if schedule.IsEmpty() {
+			r.Reporter.Errorf("Cluster '%s' has no hibernation schedule", clusterKey)
+			os.Exit(1)
+		}
+		hibernated, ok := schedule.Hibernated(time.Now())
+		if !ok || !hibernated {
+			r.Reporter.Infof("Cluster '%s' is not scheduled to be hibernated now", clusterKey)
+			os.Exit(0)
+		}
+		if cluster.State() == cmv1.ClusterStateHibernating || cluster.State() == cmv1.ClusterStatePoweringDown {
+			r.Reporter.Infof("Cluster '%s' is already hibernating", clusterKey)
+			os.Exit(0)
+		}
+	}
+
 	if cluster.State() != cmv1.ClusterStateReady {
 		r.Reporter.Errorf("Hibernating a cluster is only supported for 'Ready' clusters."+
 			" Cluster '%s' is in '%s' state",
@@ -55,14 +125,58 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	if !confirm.Confirm("hibernate cluster %s", clusterKey) {
+	reasons, warnings, err := CheckHibernation(r, cluster)
+	if err != nil {
+		r.Reporter.Errorf("Failed to check whether cluster '%s' can be hibernated: %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, warning := range warnings {
+		r.Reporter.Warnf("%s", warning)
+	}
+	if len(reasons) > 0 {
+		r.Reporter.Errorf("It isn't safe to hibernate cluster '%s':\n - %s", clusterKey,
+			strings.Join(reasons, "\n - "))
+		os.Exit(1)
+	}
+
+	if !args.applySchedule && !confirm.Confirm("hibernate cluster %s", clusterKey) {
 		os.Exit(1)
 	}
 
-	err := r.OCMClient.HibernateCluster(cluster.ID())
+	err = r.OCMClient.HibernateCluster(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
 		os.Exit(1)
 	}
+	err = r.OCMClient.RecordHibernationEvent(cluster, ocm.HibernationActionHibernate, time.Now())
+	if err != nil {
+		r.Reporter.Warnf("Failed to record hibernation of cluster '%s': %v", clusterKey, err)
+	}
 	r.Reporter.Infof("Cluster '%s' is hibernating.", clusterKey)
 }
+
+func setSchedule(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, value string) {
+	var err error
+	if value == "" {
+		err = r.OCMClient.DeleteSubscriptionLabel(cluster.Subscription().ID(), ocm.HibernateScheduleLabel)
+		if err != nil {
+			r.Reporter.Errorf("Failed to remove hibernation schedule of cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Removed hibernation schedule of cluster '%s'", clusterKey)
+		return
+	}
+	schedule, err := cron.Parse(value)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	err = r.OCMClient.SetSubscriptionLabel(cluster.Subscription().ID(), ocm.HibernateScheduleLabel, value)
+	if err != nil {
+		r.Reporter.Errorf("Failed to set hibernation schedule of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Set hibernation schedule '%s' of cluster '%s', next on %s. The schedule is only applied "+
+		"when 'rosa hibernate cluster -c %s --apply-schedule' runs, run it periodically with an external scheduler",
+		value, clusterKey, schedule.Next(time.Now()).Format("2006-01-02 15:04 MST"), clusterKey)
+}
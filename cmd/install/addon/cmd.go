@@ -32,7 +32,6 @@ import (
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/aws/tags"
-	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -42,11 +41,13 @@ import (
 const (
 	billingModelFlag          = "billing-model"
 	billingModelAccountIDFlag = "billing-model-account-id"
+	paramsFileFlag            = "params-file"
 )
 
 var args struct {
 	billingModel          string
 	billingModelAccountID string
+	paramsFile            string
 }
 
 var Cmd = &cobra.Command{
@@ -55,7 +56,10 @@ var Cmd = &cobra.Command{
 	Short:   "Install add-ons on cluster",
 	Long:    "Install Red Hat managed add-ons on a cluster",
 	Example: `  # Add the CodeReady Workspaces add-on installation to the cluster
-  rosa install addon --cluster=mycluster codeready-workspaces`,
+  rosa install addon --cluster=mycluster codeready-workspaces
+
+  # Add the add-on installation with the parameter values from a file
+  rosa install addon --cluster=mycluster --params-file=params.yaml codeready-workspaces`,
 	Run:                run,
 	DisableFlagParsing: true,
 	Args: func(cmd *cobra.Command, argv []string) error {
@@ -88,6 +92,14 @@ func init() {
 		"Account ID of associated billing model for the addon installation resource",
 	)
 
+	flags.StringVar(
+		&args.paramsFile,
+		paramsFileFlag,
+		"",
+		"Path to a YAML or JSON file mapping the IDs of the add-on parameters to their values. "+
+			"Values set as flags take precedence over the ones in the file.",
+	)
+
 	confirm.AddFlag(flags)
 	ocm.AddClusterFlag(Cmd)
 }
@@ -101,6 +113,20 @@ func run(cmd *cobra.Command, argv []string) {
 	argv = cmd.Flags().Args()
 	addOnID := argv[0]
 
+	paramValues := map[string]string{}
+	if args.paramsFile != "" {
+		data, err := os.ReadFile(args.paramsFile)
+		if err != nil {
+			r.Reporter.Errorf("Failed to read parameters file '%s': %v", args.paramsFile, err)
+			os.Exit(1)
+		}
+		paramValues, err = ocm.ParseAddOnParams(data)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
 	clusterKey := r.GetClusterKey()
 
 	cluster := r.FetchCluster()
@@ -117,13 +143,45 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	// The parameters in the file are checked before creating any resource, so that a typo doesn't
+	// leave machine pools or roles behind
+	addonParameters, err := r.OCMClient.GetAddOnParameters(cluster.ID(), addOnID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get add-on '%s' parameters: %v", addOnID, err)
+		os.Exit(1)
+	}
+
+	for id := range paramValues {
+		found := false
+		addonParameters.Each(func(param *cmv1.AddOnParameter) bool {
+			found = param.ID() == id
+			return !found
+		})
+		if !found {
+			r.Reporter.Errorf("Parameter '%s' in file '%s' is not a parameter of add-on '%s'",
+				id, args.paramsFile, addOnID)
+			os.Exit(1)
+		}
+	}
+
+	requiredMachinePools := checkRequirements(r, cluster, addOnID)
+
 	// Verify if addon requires STS authentication
 	isSTS := cluster.AWS().STS().RoleARN() != "" && len(addOn.CredentialsRequests()) > 0
 	if isSTS {
 		r.Reporter.Warnf("Addon '%s' needs access to resources in account '%s'", addOnID, r.Creator.AccountID)
 	}
 
-	if !confirm.Confirm("install add-on '%s' on cluster '%s'", addOnID, clusterKey) {
+	if len(requiredMachinePools) > 0 {
+		ids := []string{}
+		for _, machinePool := range requiredMachinePools {
+			ids = append(ids, machinePool.ID())
+		}
+		if !confirm.Confirm("install add-on '%s' on cluster '%s', creating machine pools '%s'", addOnID,
+			clusterKey, strings.Join(ids, "', '")) {
+			os.Exit(0)
+		}
+	} else if !confirm.Confirm("install add-on '%s' on cluster '%s'", addOnID, clusterKey) {
 		os.Exit(0)
 	}
 
@@ -168,22 +226,24 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 	}
 
-	addonParameters, err := r.OCMClient.GetAddOnParameters(cluster.ID(), addOnID)
-	if err != nil {
-		r.Reporter.Errorf("Failed to get add-on '%s' parameters: %v", addOnID, err)
-		os.Exit(1)
-	}
-
 	var addonArguments []ocm.AddOnParam
 	if addonParameters.Len() > 0 {
-		// Determine if all required parameters have already been set as flags and ensure
-		// that interactive mode is enabled if they have not. If there are no parameters
-		// set as flags, then we also ensure that interactive mode is enabled so that the
-		// user gets prompted.
-		if arguments.HasUnknownFlags() {
+		// Values set as flags override the ones from the parameters file
+		addonParameters.Each(func(param *cmv1.AddOnParameter) bool {
+			flag := cmd.Flags().Lookup(param.ID())
+			if flag != nil && flag.Changed {
+				paramValues[param.ID()] = flag.Value.String()
+			}
+			return true
+		})
+
+		// Determine if all required parameters have already been set as flags or in the
+		// parameters file and ensure that interactive mode is enabled if they have not. If
+		// there are no parameters set at all, then we also ensure that interactive mode is
+		// enabled so that the user gets prompted.
+		if arguments.HasUnknownFlags() || args.paramsFile != "" {
 			addonParameters.Each(func(param *cmv1.AddOnParameter) bool {
-				flag := cmd.Flags().Lookup(param.ID())
-				if param.Required() && (flag == nil || flag.Value.String() == "") {
+				if param.Required() && paramValues[param.ID()] == "" {
 					interactive.Enable()
 					return false
 				}
@@ -194,23 +254,14 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 
 		addonParameters.Each(func(param *cmv1.AddOnParameter) bool {
-			var val string
-			var options []string
-			var values []string
-
-			parameterOptions, _ := param.GetOptions()
-			for _, opt := range parameterOptions {
-				options = append(options, opt.Name())
-				values = append(values, opt.Value())
-			}
-
-			// If value is already set in the CLI, ignore interactive prompt
-			flag := cmd.Flags().Lookup(param.ID())
-			if flag != nil {
-				val = flag.Value.String()
-			}
+			// If value is already set in the CLI or in the file, use it as the default of the prompt
+			val, ok := paramValues[param.ID()]
 			if interactive.Enabled() {
-				val, err = interactive.GetAddonArgument(*param, param.DefaultValue())
+				dflt := param.DefaultValue()
+				if ok {
+					dflt = val
+				}
+				val, err = interactive.GetAddonArgument(*param, dflt)
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
 					os.Exit(1)
@@ -218,17 +269,11 @@ func run(cmd *cobra.Command, argv []string) {
 			}
 
 			val = strings.Trim(val, " ")
-			if len(options) > 0 && !helper.Contains(values, val) {
-				r.Reporter.Errorf("Expected %v to match one of the options /%v/", val, options)
+			err = ocm.ValidateAddOnParameter(param, val)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
 				os.Exit(1)
 			}
-			if val != "" && param.Validation() != "" {
-				isValid, err := regexp.MatchString(param.Validation(), val)
-				if err != nil || !isValid {
-					r.Reporter.Errorf("Expected %v to match /%s/", val, param.Validation())
-					os.Exit(1)
-				}
-			}
 			addonArguments = append(addonArguments, ocm.AddOnParam{Key: param.ID(), Val: val})
 
 			return true
@@ -273,10 +318,15 @@ func run(cmd *cobra.Command, argv []string) {
 		BillingAccountID: billingModelAccountID,
 	}
 
+	// The machine pools are created as late as possible, and removed if the installation fails, so
+	// that no pool is left behind without the add-on that needs it
+	createdMachinePools := createRequiredMachinePools(r, cluster, requiredMachinePools)
+
 	r.Reporter.Debugf("Installing add-on '%s' on cluster '%s'", addOnID, clusterKey)
 	err = r.OCMClient.InstallAddOn(cluster.ID(), addOnID, addonArguments, billing)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add add-on installation '%s' for cluster '%s': %v", addOnID, clusterKey, err)
+		deleteMachinePools(r, cluster, createdMachinePools)
 		os.Exit(1)
 	}
 	r.Reporter.Infof("Add-on '%s' is now installing. To check the status run 'rosa list addons -c %s'",
@@ -299,6 +349,132 @@ func ensureAddonNotInstalled(r *rosa.Runtime, clusterID, addOnID string) {
 	}
 }
 
+// checkRequirements makes sure that the cluster meets the requirements of the add-on before
+// installing it, offering to create the machine pools that are missing. It returns the machine
+// pools to create, which are only created once the installation is confirmed.
+func checkRequirements(r *rosa.Runtime, cluster *cmv1.Cluster, addOnID string) []*cmv1.MachinePool {
+	clusterKey := r.GetClusterKey()
+
+	addOn, err := r.OCMClient.GetAddOnInquiry(cluster.ID(), addOnID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get add-on '%s' for cluster '%s': %v", addOnID, clusterKey, err)
+		os.Exit(1)
+	}
+	if len(addOn.Requirements()) == 0 {
+		return nil
+	}
+
+	// Hosted control plane clusters have node pools instead of machine pools
+	var machinePools []*cmv1.MachinePool
+	if !cluster.Hypershift().Enabled() {
+		machinePools, err = r.OCMClient.GetMachinePools(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+	}
+	clusterAddOns, err := r.OCMClient.GetClusterAddOns(cluster)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get add-ons for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	results, err := ocm.CheckAddOnRequirements(addOn, cluster, machinePools, clusterAddOns)
+	if err != nil {
+		r.Reporter.Errorf("Failed to check requirements of add-on '%s': %v", addOnID, err)
+		os.Exit(1)
+	}
+
+	requiredMachinePools := []*cmv1.MachinePool{}
+	unmet := false
+	for _, result := range results {
+		if result.Fulfilled {
+			continue
+		}
+		requirement := result.Requirement
+		if requirement.Resource() == ocm.AddOnRequirementMachinePool {
+			if cluster.Hypershift().Enabled() {
+				r.Reporter.Warnf("Add-on '%s' requires a node pool with %s", addOnID, strings.Join(result.Reasons, ", "))
+				continue
+			}
+			machinePool := requiredMachinePool(r, addOnID, requirement)
+			if machinePool != nil {
+				requiredMachinePools = append(requiredMachinePools, machinePool)
+				continue
+			}
+		}
+		r.Reporter.Errorf("Cluster '%s' does not meet requirement '%s' of add-on '%s':",
+			clusterKey, requirement.ID(), addOnID)
+		for _, reason := range result.Reasons {
+			fmt.Printf("  - %s\n", reason)
+		}
+		unmet = true
+	}
+	if unmet {
+		os.Exit(1)
+	}
+	return requiredMachinePools
+}
+
+// requiredMachinePool returns the machine pool that meets the requirement, or nil if it can't be
+// built or the user doesn't want to create it
+func requiredMachinePool(r *rosa.Runtime, addOnID string, requirement *cmv1.AddOnRequirement) *cmv1.MachinePool {
+	machinePool, err := ocm.MachinePoolForRequirement(generateMachinePoolName(addOnID), requirement)
+	if err != nil {
+		r.Reporter.Warnf("%s", err)
+		return nil
+	}
+	if !confirm.Prompt(false, "Create machine pool '%s' with %d '%s' replicas to meet requirement '%s'?",
+		machinePool.ID(), machinePool.Replicas(), machinePool.InstanceType(), requirement.ID()) {
+		return nil
+	}
+	return machinePool
+}
+
+// createRequiredMachinePools creates the machine pools needed by the add-on, removing the ones
+// already created if one fails. It returns the IDs of the machine pools created.
+func createRequiredMachinePools(r *rosa.Runtime, cluster *cmv1.Cluster, machinePools []*cmv1.MachinePool) []string {
+	clusterKey := r.GetClusterKey()
+
+	created := []string{}
+	for _, machinePool := range machinePools {
+		r.Reporter.Debugf("Creating machine pool '%s' on cluster '%s'", machinePool.ID(), clusterKey)
+		_, err := r.OCMClient.CreateMachinePool(cluster.ID(), machinePool)
+		if err != nil {
+			r.Reporter.Errorf("Failed to add machine pool to cluster '%s': %v", clusterKey, err)
+			deleteMachinePools(r, cluster, created)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Machine pool '%s' created successfully on cluster '%s'", machinePool.ID(), clusterKey)
+		created = append(created, machinePool.ID())
+	}
+	return created
+}
+
+// deleteMachinePools removes the machine pools created for an add-on that couldn't be installed
+func deleteMachinePools(r *rosa.Runtime, cluster *cmv1.Cluster, machinePoolIDs []string) {
+	clusterKey := r.GetClusterKey()
+
+	for _, machinePoolID := range machinePoolIDs {
+		r.Reporter.Debugf("Deleting machine pool '%s' on cluster '%s'", machinePoolID, clusterKey)
+		err := r.OCMClient.DeleteMachinePool(cluster.ID(), machinePoolID)
+		if err != nil {
+			r.Reporter.Warnf("Failed to delete machine pool '%s' of cluster '%s', delete it with "+
+				"'rosa delete machinepool -c %s %s': %v", machinePoolID, clusterKey, clusterKey, machinePoolID, err)
+			continue
+		}
+		r.Reporter.Infof("Deleted machine pool '%s' of cluster '%s'", machinePoolID, clusterKey)
+	}
+}
+
+func generateMachinePoolName(addOnID string) string {
+	name := regexp.MustCompile(`[^-a-z0-9]+`).ReplaceAllString(strings.ToLower(addOnID), "-")
+	if len(name) > 30 {
+		name = name[0:30]
+	}
+	return strings.Trim(name, "-")
+}
+
 func createAddonRole(r *rosa.Runtime, roleName string, cr *cmv1.CredentialRequest, cmd *cobra.Command,
 	cluster *cmv1.Cluster) error {
 	policy := aws.NewPolicyDocument()
@@ -20,20 +20,33 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	expiringWithin   time.Duration
+	expirationReport bool
+}
+
 var Cmd = &cobra.Command{
 	Use:     "clusters",
 	Aliases: []string{"cluster"},
 	Short:   "List clusters",
 	Long:    "List clusters.",
 	Example: `  # List all clusters
-  rosa list clusters`,
+  rosa list clusters
+
+  # List the clusters that expire in the next 3 days
+  rosa list clusters --expiring-within 72h
+
+  # Print a JSON report of all clusters with their expiration time
+  rosa list clusters --expiration-report -o json`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
@@ -42,6 +55,20 @@ func init() {
 	flags := Cmd.Flags()
 	flags.SortFlags = false
 
+	flags.DurationVar(
+		&args.expiringWithin,
+		"expiring-within",
+		0,
+		"List only the clusters that expire within a duration like 24h or 72h.",
+	)
+
+	flags.BoolVar(
+		&args.expirationReport,
+		"expiration-report",
+		false,
+		"Print a report of the clusters with their expiration time.",
+	)
+
 	output.AddFlag(Cmd)
 }
 
@@ -56,6 +83,28 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	now := time.Now()
+	if args.expiringWithin != 0 {
+		clusters = ocm.ExpiringClusters(clusters, now.Add(args.expiringWithin))
+	}
+
+	if args.expirationReport {
+		report := NewExpirationReport(clusters, now)
+		if output.HasFlag() {
+			err = output.Print(map[string]interface{}{
+				"timestamp": report.Timestamp,
+				"clusters":  report.Clusters,
+			})
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+		printExpirationReport(report)
+		os.Exit(0)
+	}
+
 	if output.HasFlag() {
 		err = output.Print(clusters)
 		if err != nil {
@@ -72,7 +121,11 @@ func run(_ *cobra.Command, _ []string) {
 
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tNAME\tSTATE\tTOPOLOGY\n")
+	if args.expiringWithin != 0 {
+		fmt.Fprintf(writer, "ID\tNAME\tSTATE\tTOPOLOGY\tEXPIRES\n")
+	} else {
+		fmt.Fprintf(writer, "ID\tNAME\tSTATE\tTOPOLOGY\n")
+	}
 	for _, cluster := range clusters {
 		typeOutput := "Classic"
 		if cluster.AWS() != nil && cluster.AWS().STS() != nil && cluster.AWS().STS().Enabled() {
@@ -81,6 +134,18 @@ func run(_ *cobra.Command, _ []string) {
 		if cluster.Hypershift().Enabled() {
 			typeOutput = "Hosted CP"
 		}
+		if args.expiringWithin != 0 {
+			fmt.Fprintf(
+				writer,
+				"%s\t%s\t%s\t%s\t%s\n",
+				cluster.ID(),
+				cluster.Name(),
+				cluster.State(),
+				typeOutput,
+				cluster.ExpirationTimestamp().Format("2006-01-02 15:04 MST"),
+			)
+			continue
+		}
 		fmt.Fprintf(
 			writer,
 			"%s\t%s\t%s\t%s\n",
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/properties"
+)
+
+// ExpirationReport lists clusters with their expiration time, meant to be consumed by cleanup
+// automation
+type ExpirationReport struct {
+	Timestamp time.Time                `json:"timestamp"`
+	Clusters  []ClusterExpirationEntry `json:"clusters"`
+}
+
+type ClusterExpirationEntry struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	State      string     `json:"state"`
+	Creator    string     `json:"creator,omitempty"`
+	Expiration *time.Time `json:"expiration_timestamp"`
+	// Seconds until the cluster expires, negative when it already expired
+	ExpiresIn *int64 `json:"expires_in_seconds,omitempty"`
+}
+
+// NewExpirationReport builds the expiration report of the clusters. Clusters that don't expire
+// have a null expiration timestamp.
+func NewExpirationReport(clusters []*cmv1.Cluster, now time.Time) *ExpirationReport {
+	report := &ExpirationReport{
+		Timestamp: now.UTC().Round(time.Second),
+		Clusters:  []ClusterExpirationEntry{},
+	}
+	for _, cluster := range clusters {
+		entry := ClusterExpirationEntry{
+			ID:    cluster.ID(),
+			Name:  cluster.Name(),
+			State: string(cluster.State()),
+		}
+		if arn, ok := cluster.Properties()[properties.CreatorARN]; ok {
+			entry.Creator = arn
+		}
+		expiration, ok := cluster.GetExpirationTimestamp()
+		if ok && !expiration.IsZero() {
+			expiration = expiration.UTC()
+			expiresIn := int64(expiration.Sub(now).Seconds())
+			entry.Expiration = &expiration
+			entry.ExpiresIn = &expiresIn
+		}
+		report.Clusters = append(report.Clusters, entry)
+	}
+	return report
+}
+
+func printExpirationReport(report *ExpirationReport) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tNAME\tSTATE\tCREATOR\tEXPIRES\tEXPIRES IN\n")
+	for _, entry := range report.Clusters {
+		expires := "Never"
+		expiresIn := ""
+		if entry.Expiration != nil {
+			expires = entry.Expiration.Format("2006-01-02 15:04 MST")
+			expiresIn = (time.Duration(*entry.ExpiresIn) * time.Second).String()
+			if *entry.ExpiresIn < 0 {
+				expiresIn = "Expired"
+			}
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.ID, entry.Name, entry.State, entry.Creator,
+			expires, expiresIn)
+	}
+	writer.Flush()
+}
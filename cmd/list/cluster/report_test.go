@@ -0,0 +1,31 @@
+package cluster_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/cmd/list/cluster"
+)
+
+var _ = Describe("Expiration report", func() {
+	now := time.Date(2023, time.May, 15, 10, 0, 0, 0, time.UTC)
+
+	It("Reports the expiration of each cluster", func() {
+		expiring, err := cmv1.NewCluster().ID("a").Name("expiring").State(cmv1.ClusterStateReady).
+			ExpirationTimestamp(now.Add(2 * time.Hour)).Build()
+		Expect(err).NotTo(HaveOccurred())
+		permanent, err := cmv1.NewCluster().ID("b").Name("permanent").State(cmv1.ClusterStateReady).Build()
+		Expect(err).NotTo(HaveOccurred())
+
+		report := cluster.NewExpirationReport([]*cmv1.Cluster{expiring, permanent}, now)
+		Expect(report.Timestamp).To(Equal(now))
+		Expect(report.Clusters).To(HaveLen(2))
+		Expect(*report.Clusters[0].Expiration).To(Equal(now.Add(2 * time.Hour)))
+		Expect(*report.Clusters[0].ExpiresIn).To(Equal(int64(7200)))
+		Expect(report.Clusters[1].Expiration).To(BeNil())
+		Expect(report.Clusters[1].ExpiresIn).To(BeNil())
+	})
+})
@@ -19,26 +19,147 @@ package instancetypes
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/object"
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/pricing"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var sortKeys = []string{"id", "cpu", "memory"}
+
+var args struct {
+	minCPU         int
+	minMemory      string
+	gpu            bool
+	architecture   string
+	families       []string
+	availableInAZ  string
+	sortBy         string
+	recommend      bool
+	pods           int
+	cpuRequest     string
+	memoryRequest  string
+	maxPodsPerNode int
+}
+
 var Cmd = &cobra.Command{
 	Use:     "instance-types",
 	Aliases: []string{"instancetypes"},
 	Short:   "List Instance types",
 	Long:    "List Instance types that are available for use with ROSA.",
 	Example: `  # List all instance types
-  rosa list instance-types`,
+  rosa list instance-types
+
+  # List ARM instance types with at least 8 vCPUs and 32GiB of memory sorted by memory
+  rosa list instance-types --arch arm64 --min-cpu 8 --min-memory 32Gi --sort-by memory
+
+  # List instance types available in an availability zone of the cluster "mycluster"
+  rosa list instance-types -c mycluster --available-in-az us-east-1a
+
+  # Recommend an instance type and replica count to run 200 pods on the cluster "mycluster"
+  rosa list instance-types -c mycluster --recommend --pods 200 --cpu-request 500m --memory-request 1Gi`,
 	Run: run,
 }
 
 func init() {
+	flags := Cmd.Flags()
+
+	ocm.AddOptionalClusterFlag(Cmd)
+
+	flags.IntVar(
+		&args.minCPU,
+		"min-cpu",
+		0,
+		"Only list instance types with at least this number of vCPUs.",
+	)
+
+	flags.StringVar(
+		&args.minMemory,
+		"min-memory",
+		"",
+		"Only list instance types with at least this amount of memory, for example '16Gi'.",
+	)
+
+	flags.BoolVar(
+		&args.gpu,
+		"gpu",
+		false,
+		"Only list accelerated computing instance types.",
+	)
+
+	flags.StringVar(
+		&args.architecture,
+		"arch",
+		"",
+		fmt.Sprintf("Only list instance types with this CPU architecture. Allowed values are %s",
+			[]string{ocm.ArchitectureAMD64, ocm.ArchitectureARM64}),
+	)
+
+	flags.StringSliceVar(
+		&args.families,
+		"family",
+		nil,
+		"Only list instance types of these families, for example 'm5,r5'.",
+	)
+
+	flags.StringVar(
+		&args.availableInAZ,
+		"available-in-az",
+		"",
+		"Only list instance types available in this availability zone. Uses the cluster region "+
+			"when '--cluster' is set, and the current AWS region otherwise.",
+	)
+
+	flags.StringVar(
+		&args.sortBy,
+		"sort-by",
+		"",
+		fmt.Sprintf("Sort the instance types. Allowed values are %s", sortKeys),
+	)
+
+	flags.BoolVar(
+		&args.recommend,
+		"recommend",
+		false,
+		"Recommend instance types and replica counts for the workload described by "+
+			"'--pods', '--cpu-request' and '--memory-request'.",
+	)
+
+	flags.IntVar(
+		&args.pods,
+		"pods",
+		0,
+		"Number of pods the recommended machine pool should fit.",
+	)
+
+	flags.StringVar(
+		&args.cpuRequest,
+		"cpu-request",
+		"",
+		"CPU requested by each pod, for example '500m'.",
+	)
+
+	flags.StringVar(
+		&args.memoryRequest,
+		"memory-request",
+		"",
+		"Memory requested by each pod, for example '512Mi'.",
+	)
+
+	flags.IntVar(
+		&args.maxPodsPerNode,
+		"max-pods-per-node",
+		0,
+		"Maximum number of pods per node. Defaults to the limit derived from the cluster host prefix.",
+	)
+
 	output.AddFlag(Cmd)
 }
 
@@ -46,11 +167,52 @@ func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
 
+	if args.architecture != "" &&
+		args.architecture != ocm.ArchitectureAMD64 && args.architecture != ocm.ArchitectureARM64 {
+		r.Reporter.Errorf("Invalid architecture '%s'. Allowed values are %s", args.architecture,
+			[]string{ocm.ArchitectureAMD64, ocm.ArchitectureARM64})
+		os.Exit(1)
+	}
+	minMemory, err := parseMemory(args.minMemory)
+	if err != nil {
+		r.Reporter.Errorf("Invalid value for '--min-memory': %v", err)
+		os.Exit(1)
+	}
+	isRecommendFlagSet := cmd.Flags().Changed("pods") || cmd.Flags().Changed("cpu-request") ||
+		cmd.Flags().Changed("memory-request") || cmd.Flags().Changed("max-pods-per-node")
+	if isRecommendFlagSet && !args.recommend {
+		r.Reporter.Errorf("Setting '--pods', '--cpu-request', '--memory-request' or '--max-pods-per-node' " +
+			"requires the '--recommend' flag")
+		os.Exit(1)
+	}
+	if args.recommend && args.pods <= 0 {
+		r.Reporter.Errorf("Expected a positive number of pods to recommend instance types for")
+		os.Exit(1)
+	}
+	cpuRequest, err := parseCPU(args.cpuRequest)
+	if err != nil {
+		r.Reporter.Errorf("Invalid value for '--cpu-request': %v", err)
+		os.Exit(1)
+	}
+	memoryRequest, err := parseMemory(args.memoryRequest)
+	if err != nil {
+		r.Reporter.Errorf("Invalid value for '--memory-request': %v", err)
+		os.Exit(1)
+	}
+
+	var cluster *cmv1.Cluster
+	multiAZ := false
+	if cmd.Flags().Changed("cluster") {
+		r.GetClusterKey()
+		cluster = r.FetchCluster()
+		multiAZ = cluster.MultiAZ()
+	}
+
 	r.Reporter.Debugf("Fetching instance types")
 
-	machineTypes, err := r.OCMClient.GetAvailableMachineTypes()
+	machineTypes, region, err := fetchMachineTypes(r, cluster, args.availableInAZ)
 	if err != nil {
-		r.Reporter.Errorf("Failed to fetch instance types: %v", err)
+		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
 
@@ -59,6 +221,27 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	machineTypes = machineTypes.Filter(filter{
+		minCPU:        args.minCPU,
+		minMemoryGiB:  minMemory,
+		gpu:           args.gpu,
+		architecture:  args.architecture,
+		families:      args.families,
+		onlyAvailable: args.recommend || !output.HasFlag(),
+	}.matches)
+	if cmd.Flags().Changed("sort-by") {
+		err = sortMachineTypes(machineTypes, args.sortBy)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
+	if args.recommend {
+		printRecommendations(r, machineTypes, cluster, region, multiAZ, cpuRequest, memoryRequest)
+		os.Exit(0)
+	}
+
 	if output.HasFlag() {
 		var instanceTypes []*cmv1.MachineType
 		for _, machine := range machineTypes {
@@ -72,20 +255,144 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(0)
 	}
 
+	if len(machineTypes) == 0 {
+		r.Reporter.Infof("There are no instance types matching the given filters")
+		os.Exit(0)
+	}
+
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tCATEGORY\tCPU_CORES\tMEMORY\t\n")
+	fmt.Fprintf(writer, "ID\tCATEGORY\tCPU_CORES\tMEMORY\tARCH\t\n")
 
 	for _, machine := range machineTypes {
-		if !machine.Available {
-			continue
-		}
 		availableMachine := machine.MachineType
 		fmt.Fprintf(writer,
-			"%s\t%s\t%d\t%s\n",
+			"%s\t%s\t%d\t%s\t%s\n",
 			availableMachine.ID(), availableMachine.Category(), int(availableMachine.CPU().Value()),
 			ByteCountIEC(int(availableMachine.Memory().Value()),
 				availableMachine.Memory().Unit()),
+			machine.Architecture(),
+		)
+	}
+	writer.Flush()
+}
+
+// fetchMachineTypes fetches the instance types, limited to the region and availability zones of the
+// cluster, or to the given availability zone, when either is set. It also returns the region used.
+func fetchMachineTypes(r *rosa.Runtime, cluster *cmv1.Cluster,
+	availableInAZ string) (machineTypes ocm.MachineTypeList, region string, err error) {
+	if cluster == nil && availableInAZ == "" {
+		machineTypes, err = r.OCMClient.GetAvailableMachineTypes()
+		if err != nil {
+			err = fmt.Errorf("Failed to fetch instance types: %v", err)
+		}
+		return
+	}
+	availabilityZones := []string{}
+	roleARN := ""
+	if cluster != nil {
+		region = cluster.Region().ID()
+		availabilityZones = cluster.Nodes().AvailabilityZones()
+		roleARN = cluster.AWS().STS().RoleARN()
+	}
+	// Without a role to assume the inquiry uses the AWS credentials of the user
+	if roleARN == "" {
+		r.WithAWS()
+	}
+	if cluster == nil {
+		region = r.AWSClient.GetRegion()
+	}
+	if availableInAZ != "" {
+		if !strings.HasPrefix(availableInAZ, region) {
+			err = fmt.Errorf("Availability zone '%s' doesn't belong to region '%s'", availableInAZ, region)
+			return
+		}
+		availabilityZones = []string{availableInAZ}
+	}
+	machineTypes, err = r.OCMClient.GetAvailableMachineTypesInRegion(region, availabilityZones, roleARN,
+		r.AWSClient)
+	if err != nil {
+		err = fmt.Errorf("Failed to fetch instance types: %v", err)
+	}
+	return
+}
+
+func printRecommendations(r *rosa.Runtime, machineTypes ocm.MachineTypeList, cluster *cmv1.Cluster,
+	region string, multiAZ bool, cpuRequest float64, memoryRequest float64) {
+	podsPerNodeLimit := args.maxPodsPerNode
+	if podsPerNodeLimit <= 0 {
+		hostPrefix := 0
+		if cluster != nil {
+			hostPrefix = cluster.Network().HostPrefix()
+		}
+		podsPerNodeLimit = maxPodsPerNode(hostPrefix)
+	}
+
+	// Prices are only used to rank the recommendations, so a missing catalog isn't fatal
+	catalog, err := pricing.LoadCatalog()
+	if err != nil {
+		r.Reporter.Debugf("Unable to load price catalog: %v", err)
+		catalog = nil
+	}
+	if region == "" && catalog != nil {
+		region = catalog.DefaultRegion
+	}
+
+	recommendations := recommend(machineTypes, workload{
+		pods:             args.pods,
+		cpuRequest:       cpuRequest,
+		memoryRequestGiB: memoryRequest,
+		maxPodsPerNode:   podsPerNodeLimit,
+		multiAZ:          multiAZ,
+	}, catalog, region)
+
+	if output.HasFlag() {
+		items := []object.Object{}
+		for _, option := range recommendations {
+			item := object.Object{
+				"id":          option.MachineType.MachineType.ID(),
+				"cpu":         option.MachineType.CPUCores(),
+				"memoryGiB":   option.MachineType.MemoryGiB(),
+				"podsPerNode": option.PodsPerNode,
+				"replicas":    option.Replicas,
+			}
+			if option.HourlyCost != nil {
+				item["hourlyCost"] = *option.HourlyCost
+			}
+			items = append(items, item)
+		}
+		err = output.Print(object.Object{
+			"maxPodsPerNode":  podsPerNodeLimit,
+			"recommendations": items,
+		})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(recommendations) == 0 {
+		r.Reporter.Warnf("None of the instance types can run a pod with the requested resources")
+		os.Exit(1)
+	}
+
+	r.Reporter.Infof("Recommended instance types to run %d pods with at most %d pods per node:",
+		args.pods, podsPerNodeLimit)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tCPU_CORES\tMEMORY\tPODS_PER_NODE\tREPLICAS\tEST_HOURLY_COST\t\n")
+	for _, option := range recommendations {
+		cost := "N/A"
+		if option.HourlyCost != nil {
+			cost = fmt.Sprintf("%.2f %s", *option.HourlyCost, catalog.Currency)
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%.1f GiB\t%d\t%d\t%s\n",
+			option.MachineType.MachineType.ID(),
+			option.MachineType.CPUCores(),
+			option.MachineType.MemoryGiB(),
+			option.PodsPerNode,
+			option.Replicas,
+			cost,
 		)
 	}
 	writer.Flush()
@@ -0,0 +1,112 @@
+package instancetypes
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// fakeAWSClient returns fixed credentials, the rest of the AWS client isn't used
+type fakeAWSClient struct {
+	aws.Client
+}
+
+func (c *fakeAWSClient) GetAWSAccessKeys() (*aws.AccessKey, error) {
+	return &aws.AccessKey{AccessKeyID: "key1", SecretAccessKey: "secret1"}, nil
+}
+
+func (c *fakeAWSClient) GetCreator() (*aws.Creator, error) {
+	return &aws.Creator{AccountID: "123456789012"}, nil
+}
+
+var _ = Describe("Fetch instance types", func() {
+	var apiServer *ghttp.Server
+	var r *rosa.Runtime
+	var inquiry string
+
+	BeforeEach(func() {
+		inquiry = ""
+		apiServer = MakeTCPServer()
+		apiServer.RouteToHandler(http.MethodPost, "/api/clusters_mgmt/v1/aws_inquiries/machine_types",
+			func(w http.ResponseWriter, req *http.Request) {
+				body, err := io.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+				inquiry = string(body)
+				RespondWithJSON(http.StatusOK, `{"page": 1, "size": 1, "total": 1, "items": [`+
+					`{"id": "m5.xlarge", "category": "general_purpose"}]}`)(w, req)
+			})
+		apiServer.RouteToHandler(http.MethodGet, "/api/accounts_mgmt/v1/current_account",
+			RespondWithJSON(http.StatusOK, `{"id": "account1", "organization": {"id": "org1"}}`))
+		apiServer.RouteToHandler(http.MethodGet, "/api/accounts_mgmt/v1/organizations/org1/quota_cost",
+			RespondWithJSON(http.StatusOK, `{"page": 1, "size": 0, "total": 0, "items": []}`))
+
+		client, err := ocm.NewClient().
+			Logger(logging.NewLogger()).
+			Config(&config.Config{Session: config.Session{
+				URL:         apiServer.URL(),
+				AccessToken: MakeTokenString("Bearer", 15*time.Minute),
+			}}).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		r = &rosa.Runtime{OCMClient: client, AWSClient: &fakeAWSClient{}}
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		Expect(r.OCMClient.Close()).To(Succeed())
+	})
+
+	It("Uses the AWS credentials of the user for clusters without STS", func() {
+		cluster, err := cmv1.NewCluster().
+			ID("cluster1").
+			Region(cmv1.NewCloudRegion().ID("us-east-1")).
+			Nodes(cmv1.NewClusterNodes().AvailabilityZones("us-east-1a")).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+
+		machineTypes, region, err := fetchMachineTypes(r, cluster, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(region).To(Equal("us-east-1"))
+		Expect(machineTypes).To(HaveLen(1))
+		Expect(r.Creator).NotTo(BeNil())
+		Expect(inquiry).To(ContainSubstring(`"access_key_id": "key1"`))
+		Expect(inquiry).To(ContainSubstring(`"us-east-1a"`))
+	})
+
+	It("Assumes the role of clusters with STS", func() {
+		cluster, err := cmv1.NewCluster().
+			ID("cluster1").
+			Region(cmv1.NewCloudRegion().ID("us-east-1")).
+			AWS(cmv1.NewAWS().STS(cmv1.NewSTS().RoleARN("arn:aws:iam::123456789012:role/installer"))).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = fetchMachineTypes(r, cluster, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inquiry).To(ContainSubstring(`"role_arn": "arn:aws:iam::123456789012:role/installer"`))
+		Expect(inquiry).NotTo(ContainSubstring("access_key_id"))
+	})
+
+	It("Rejects availability zones of other regions", func() {
+		cluster, err := cmv1.NewCluster().
+			ID("cluster1").
+			Region(cmv1.NewCloudRegion().ID("us-east-1")).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = fetchMachineTypes(r, cluster, "eu-west-1a")
+		Expect(err).To(MatchError("Availability zone 'eu-west-1a' doesn't belong to region 'us-east-1'"))
+	})
+})
@@ -0,0 +1,268 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancetypes
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/pricing"
+)
+
+const (
+	// Default maximum number of pods the kubelet runs on a single OpenShift node
+	defaultMaxPodsPerNode = 250
+	// Default host prefix of the cluster network
+	defaultHostPrefix = 23
+
+	// Resources reserved on every node for the kubelet and system daemons
+	systemReservedCPU       = 0.5
+	systemReservedMemoryGiB = 1.0
+
+	maxRecommendations = 5
+)
+
+type filter struct {
+	minCPU        int
+	minMemoryGiB  float64
+	gpu           bool
+	architecture  string
+	families      []string
+	onlyAvailable bool
+}
+
+func (f filter) matches(machineType *ocm.MachineType) bool {
+	if f.onlyAvailable && !machineType.Available {
+		return false
+	}
+	if machineType.CPUCores() < f.minCPU {
+		return false
+	}
+	if machineType.MemoryGiB() < f.minMemoryGiB {
+		return false
+	}
+	if f.gpu && !machineType.IsGPU() {
+		return false
+	}
+	if f.architecture != "" && machineType.Architecture() != f.architecture {
+		return false
+	}
+	if len(f.families) > 0 {
+		found := false
+		for _, family := range f.families {
+			if machineType.Family() == family {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func sortMachineTypes(machineTypes ocm.MachineTypeList, sortBy string) error {
+	var less func(a, b *ocm.MachineType) bool
+	switch sortBy {
+	case "", "id":
+		less = func(a, b *ocm.MachineType) bool {
+			return a.MachineType.ID() < b.MachineType.ID()
+		}
+	case "cpu":
+		less = func(a, b *ocm.MachineType) bool {
+			if a.CPUCores() == b.CPUCores() {
+				return a.MemoryGiB() < b.MemoryGiB()
+			}
+			return a.CPUCores() < b.CPUCores()
+		}
+	case "memory":
+		less = func(a, b *ocm.MachineType) bool {
+			if a.MemoryGiB() == b.MemoryGiB() {
+				return a.CPUCores() < b.CPUCores()
+			}
+			return a.MemoryGiB() < b.MemoryGiB()
+		}
+	default:
+		return fmt.Errorf("Invalid sort key '%s'. Valid keys are %s", sortBy, sortKeys)
+	}
+	sort.SliceStable(machineTypes, func(i, j int) bool {
+		return less(machineTypes[i], machineTypes[j])
+	})
+	return nil
+}
+
+type workload struct {
+	pods             int
+	cpuRequest       float64
+	memoryRequestGiB float64
+	maxPodsPerNode   int
+	multiAZ          bool
+}
+
+type recommendation struct {
+	MachineType *ocm.MachineType
+	PodsPerNode int
+	Replicas    int
+	HourlyCost  *float64
+}
+
+// maxPodsPerNode returns the maximum number of pods per node allowed by the kubelet and the
+// number of pod IPs available in the node subnet given by the host prefix
+func maxPodsPerNode(hostPrefix int) int {
+	if hostPrefix <= 0 {
+		hostPrefix = defaultHostPrefix
+	}
+	addresses := int(math.Pow(2, float64(32-hostPrefix))) - 2
+	if addresses < defaultMaxPodsPerNode {
+		return addresses
+	}
+	return defaultMaxPodsPerNode
+}
+
+// recommend sizes a machine pool for the workload with each of the machine types and returns
+// the cheapest options first. When there is no price available for a machine type, the options
+// with the fewest total vCPUs are preferred.
+func recommend(machineTypes ocm.MachineTypeList, load workload, catalog *pricing.Catalog,
+	region string) []recommendation {
+	recommendations := []recommendation{}
+	for _, machineType := range machineTypes {
+		podsPerNode := load.maxPodsPerNode
+		if load.cpuRequest > 0 {
+			allocatable := float64(machineType.CPUCores()) - systemReservedCPU
+			podsPerNode = minInt(podsPerNode, int(math.Floor(allocatable/load.cpuRequest)))
+		}
+		if load.memoryRequestGiB > 0 {
+			allocatable := machineType.MemoryGiB() - systemReservedMemoryGiB
+			podsPerNode = minInt(podsPerNode, int(math.Floor(allocatable/load.memoryRequestGiB)))
+		}
+		if podsPerNode < 1 {
+			continue
+		}
+		replicas := int(math.Ceil(float64(load.pods) / float64(podsPerNode)))
+		if load.multiAZ {
+			replicas = int(math.Ceil(float64(replicas)/3)) * 3
+		}
+		replicas = maxInt(replicas, minReplicas(load.multiAZ))
+		option := recommendation{
+			MachineType: machineType,
+			PodsPerNode: podsPerNode,
+			Replicas:    replicas,
+		}
+		if catalog != nil {
+			price, err := catalog.OnDemandPrice(region, machineType.MachineType.ID())
+			if err == nil {
+				cost := price * float64(replicas)
+				option.HourlyCost = &cost
+			}
+		}
+		recommendations = append(recommendations, option)
+	}
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.HourlyCost != nil && b.HourlyCost != nil && *a.HourlyCost != *b.HourlyCost {
+			return *a.HourlyCost < *b.HourlyCost
+		}
+		if (a.HourlyCost == nil) != (b.HourlyCost == nil) {
+			return a.HourlyCost != nil
+		}
+		return a.Replicas*a.MachineType.CPUCores() < b.Replicas*b.MachineType.CPUCores()
+	})
+	if len(recommendations) > maxRecommendations {
+		recommendations = recommendations[:maxRecommendations]
+	}
+	return recommendations
+}
+
+func minReplicas(multiAZ bool) int {
+	if multiAZ {
+		return 3
+	}
+	return 2
+}
+
+// parseCPU parses a Kubernetes CPU quantity such as '500m' or '2' into cores
+func parseCPU(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	scale := 1.0
+	if strings.HasSuffix(value, "m") {
+		scale = 1000
+		value = strings.TrimSuffix(value, "m")
+	}
+	cores, err := strconv.ParseFloat(value, 64)
+	if err != nil || cores < 0 {
+		return 0, fmt.Errorf("Expected a valid CPU quantity such as '500m' or '2'")
+	}
+	return cores / scale, nil
+}
+
+var memorySuffixes = map[string]float64{
+	"Ki": math.Pow(1024, 1),
+	"Mi": math.Pow(1024, 2),
+	"Gi": math.Pow(1024, 3),
+	"Ti": math.Pow(1024, 4),
+	"K":  math.Pow(1000, 1),
+	"M":  math.Pow(1000, 2),
+	"G":  math.Pow(1000, 3),
+	"T":  math.Pow(1000, 4),
+}
+
+// parseMemory parses a Kubernetes memory quantity such as '512Mi' or '2G' into GiB
+func parseMemory(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	scale := 1.0
+	// Check the two letter suffixes first so that 'Mi' isn't mistaken for 'M'
+	for _, length := range []int{2, 1} {
+		if len(value) <= length {
+			continue
+		}
+		if multiplier, ok := memorySuffixes[value[len(value)-length:]]; ok {
+			scale = multiplier
+			value = value[:len(value)-length]
+			break
+		}
+	}
+	quantity, err := strconv.ParseFloat(value, 64)
+	if err != nil || quantity < 0 {
+		return 0, fmt.Errorf("Expected a valid memory quantity such as '512Mi' or '2Gi'")
+	}
+	return quantity * scale / math.Pow(1024, 3), nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
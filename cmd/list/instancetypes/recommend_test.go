@@ -0,0 +1,115 @@
+package instancetypes
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/pricing"
+)
+
+func newMachineType(id string, cpu float64, memoryGiB float64, category cmv1.MachineTypeCategory) *ocm.MachineType {
+	machineType, err := cmv1.NewMachineType().
+		ID(id).
+		Category(category).
+		CPU(cmv1.NewValue().Value(cpu).Unit("vCPU")).
+		Memory(cmv1.NewValue().Value(memoryGiB * 1024 * 1024 * 1024).Unit("B")).
+		Build()
+	Expect(err).To(BeNil())
+	return &ocm.MachineType{MachineType: machineType, Available: true}
+}
+
+var _ = Describe("Instance types", func() {
+	var machineTypes ocm.MachineTypeList
+
+	BeforeEach(func() {
+		machineTypes = ocm.MachineTypeList{
+			newMachineType("r5.xlarge", 4, 32, "memory_optimized"),
+			newMachineType("m5.xlarge", 4, 16, "general_purpose"),
+			newMachineType("m6g.2xlarge", 8, 32, "general_purpose"),
+			newMachineType("g4dn.xlarge", 4, 16, ocm.AcceleratedComputing),
+		}
+	})
+
+	Context("Filters", func() {
+		It("Filters by architecture", func() {
+			list := machineTypes.Filter(filter{architecture: ocm.ArchitectureARM64}.matches)
+			Expect(list.IDs()).To(Equal([]string{"m6g.2xlarge"}))
+		})
+		It("Filters by CPU and memory", func() {
+			list := machineTypes.Filter(filter{minCPU: 4, minMemoryGiB: 32}.matches)
+			Expect(list.IDs()).To(Equal([]string{"r5.xlarge", "m6g.2xlarge"}))
+		})
+		It("Filters by GPU and family", func() {
+			list := machineTypes.Filter(filter{gpu: true}.matches)
+			Expect(list.IDs()).To(Equal([]string{"g4dn.xlarge"}))
+			list = machineTypes.Filter(filter{families: []string{"m5", "r5"}}.matches)
+			Expect(list.IDs()).To(Equal([]string{"r5.xlarge", "m5.xlarge"}))
+		})
+	})
+
+	Context("Sorting", func() {
+		It("Sorts by memory and then CPU", func() {
+			Expect(sortMachineTypes(machineTypes, "memory")).To(Succeed())
+			Expect(machineTypes.IDs()).To(Equal([]string{"m5.xlarge", "g4dn.xlarge", "r5.xlarge", "m6g.2xlarge"}))
+		})
+		It("Rejects unknown keys", func() {
+			Expect(sortMachineTypes(machineTypes, "price")).ToNot(Succeed())
+		})
+	})
+
+	DescribeTable("Parses quantities",
+		func(parse func(string) (float64, error), value string, expected float64) {
+			quantity, err := parse(value)
+			Expect(err).To(BeNil())
+			Expect(quantity).To(BeNumerically("~", expected))
+		},
+		Entry("Millicores", parseCPU, "500m", 0.5),
+		Entry("Cores", parseCPU, "2", 2.0),
+		Entry("Mebibytes", parseMemory, "512Mi", 0.5),
+		Entry("Gibibytes", parseMemory, "16Gi", 16.0),
+		Entry("Gigabytes", parseMemory, "1G", 1e9/(1024*1024*1024)),
+	)
+
+	It("Limits pods per node by host prefix", func() {
+		Expect(maxPodsPerNode(0)).To(Equal(250))
+		Expect(maxPodsPerNode(25)).To(Equal(126))
+	})
+
+	It("Recommends the cheapest option that fits the workload", func() {
+		catalog, err := pricing.ParseCatalog([]byte(`{
+			"hoursPerMonth": 730,
+			"regions": {"us-east-1": 1.0},
+			"instanceTypes": {
+				"r5.xlarge": {"vcpu": 4, "memoryGiB": 32, "onDemand": 0.25},
+				"m5.xlarge": {"vcpu": 4, "memoryGiB": 16, "onDemand": 0.2}
+			}
+		}`))
+		Expect(err).To(BeNil())
+		recommendations := recommend(machineTypes, workload{
+			pods:             20,
+			cpuRequest:       0.5,
+			memoryRequestGiB: 2,
+			maxPodsPerNode:   250,
+		}, catalog, "us-east-1")
+		Expect(recommendations).To(HaveLen(4))
+		// m5.xlarge: 7 pods by CPU, 7 by memory -> 3 replicas for 0.6/hour
+		// r5.xlarge: 7 pods by CPU, 15 by memory -> 3 replicas for 0.75/hour
+		Expect(recommendations[0].MachineType.MachineType.ID()).To(Equal("m5.xlarge"))
+		Expect(recommendations[0].PodsPerNode).To(Equal(7))
+		Expect(recommendations[0].Replicas).To(Equal(3))
+		Expect(recommendations[1].MachineType.MachineType.ID()).To(Equal("r5.xlarge"))
+		// Options without a price come last
+		Expect(recommendations[2].HourlyCost).To(BeNil())
+	})
+
+	It("Rounds replicas up to a multiple of three for multi-AZ clusters", func() {
+		recommendations := recommend(machineTypes[:1], workload{
+			pods:           10,
+			maxPodsPerNode: 250,
+			multiAZ:        true,
+		}, nil, "")
+		Expect(recommendations[0].Replicas).To(Equal(3))
+	})
+})
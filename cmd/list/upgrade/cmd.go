@@ -30,19 +30,47 @@ import (
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	graph   bool
+	version string
+}
+
 var Cmd = &cobra.Command{
 	Use:     "upgrades",
 	Aliases: []string{"upgrade"},
 	Short:   "List available cluster upgrades",
 	Long:    "List available and scheduled cluster version upgrades",
-	Run:     run,
+	Example: `  # List available upgrades for the cluster named "mycluster"
+  rosa list upgrades -c mycluster
+
+  # Show the upgrades needed to upgrade the cluster named "mycluster" to version 4.14.5
+  rosa list upgrades -c mycluster --graph --version 4.14.5`,
+	Run: run,
 }
 
 func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
 	ocm.AddClusterFlag(Cmd)
+
+	flags.BoolVar(
+		&args.graph,
+		"graph",
+		false,
+		"Show the path of upgrades from the current version of the cluster to the target version, "+
+			"including the gates and role policies that need attention before each of them.",
+	)
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Target version of the upgrade path. Defaults to the latest version that can be reached.",
+	)
 }
 
-func run(_ *cobra.Command, _ []string) {
+func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
@@ -54,6 +82,15 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if cmd.Flags().Changed("version") && !args.graph {
+		r.Reporter.Errorf("The '--version' option is only supported together with '--graph'")
+		os.Exit(1)
+	}
+	if args.graph {
+		runGraph(r, cluster, clusterKey, args.version)
+		return
+	}
+
 	// Load available upgrades for this cluster
 	r.Reporter.Debugf("Loading available upgrades for cluster '%s'", clusterKey)
 	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
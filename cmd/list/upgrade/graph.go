@@ -0,0 +1,159 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/versions"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// runGraph prints the upgrades needed to go from the current version of the cluster to the target
+// version, and what has to be done before each of them
+func runGraph(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, target string) {
+	current := cluster.Version().RawID()
+	if current == "" {
+		current = ocm.GetRawVersionId(ocm.GetVersionID(cluster))
+	}
+
+	r.Reporter.Debugf("Loading upgrade graph for cluster '%s'", clusterKey)
+	availableVersions, err := r.OCMClient.GetVersions(cluster.Version().ChannelGroup())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get versions: %v", err)
+		os.Exit(1)
+	}
+	enabled := []string{}
+	for _, version := range availableVersions {
+		enabled = append(enabled, version.RawID())
+	}
+	graph := versions.UpgradeGraph{}
+	for _, version := range availableVersions {
+		for _, upgrade := range version.AvailableUpgrades() {
+			if helper.Contains(enabled, upgrade) {
+				graph[version.RawID()] = append(graph[version.RawID()], upgrade)
+			}
+		}
+	}
+	// The current version of the cluster may no longer be enabled for new clusters
+	graph[current], err = r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+	if err != nil {
+		r.Reporter.Errorf("Failed to get available upgrades for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if target == "" {
+		target = versions.LatestReachableVersion(graph, current)
+		if target == "" {
+			r.Reporter.Infof("There are no available upgrades for cluster '%s'", clusterKey)
+			os.Exit(0)
+		}
+	}
+	path := versions.FindUpgradePath(graph, current, target)
+	if path == nil {
+		r.Reporter.Errorf("There is no upgrade path from version '%s' to version '%s' for cluster '%s'",
+			current, target, clusterKey)
+		os.Exit(1)
+	}
+
+	agreements, err := r.OCMClient.GetGateAgreements(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get acknowledged gates of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	_, isSTS := cluster.AWS().STS().GetRoleARN()
+	checkRoles := isSTS && !cluster.AWS().STS().ManagedPolicies()
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "HOP\tFROM\tTO\tNOTES\n")
+	for i := 1; i < len(path); i++ {
+		notes := []string{}
+		fromMinor := ocm.GetVersionMinor(path[i-1])
+		toMinor := ocm.GetVersionMinor(path[i])
+		if fromMinor != toMinor {
+			versionGates, err := r.OCMClient.ListAllOcpGates(toMinor)
+			if err != nil {
+				r.Reporter.Errorf("Failed to fetch gates for version %s: %v", toMinor, err)
+				os.Exit(1)
+			}
+			missingGates := ocm.FilterMissingGates(versionGates, agreements, isSTS)
+			if len(missingGates) > 0 {
+				notes = append(notes, fmt.Sprintf("%d gates to acknowledge", len(missingGates)))
+			}
+			if checkRoles && rolePoliciesNeedUpgrade(r, cluster, clusterKey, toMinor) {
+				notes = append(notes, "role policies to upgrade")
+			}
+		}
+		if versions.IsEUS(path[i]) && !versions.IsEUS(path[i-1]) {
+			notes = append(notes, "EUS")
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", i, path[i-1], path[i], strings.Join(notes, ", "))
+	}
+	writer.Flush()
+	if versions.IsEUS(current) && versions.IsEUS(target) && ocm.GetVersionMinor(current) != ocm.GetVersionMinor(target) {
+		intermediate := []string{}
+		for _, version := range path[1 : len(path)-1] {
+			minor := ocm.GetVersionMinor(version)
+			if minor != ocm.GetVersionMinor(current) && minor != ocm.GetVersionMinor(target) &&
+				!helper.Contains(intermediate, minor) {
+				intermediate = append(intermediate, minor)
+			}
+		}
+		if len(intermediate) > 0 {
+			r.Reporter.Infof("This is an EUS-to-EUS upgrade through the intermediate minor versions %s",
+				strings.Join(intermediate, ", "))
+		}
+	}
+	r.Reporter.Infof("Upgrading cluster '%s' from version '%s' to version '%s' takes %d upgrade cycles",
+		clusterKey, current, target, len(path)-1)
+}
+
+func rolePoliciesNeedUpgrade(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, minor string) bool {
+	upgradeNeeded, err := r.AWSClient.IsUpgradedNeededForAccountRolePoliciesUsingCluster(cluster, minor)
+	if err != nil {
+		r.Reporter.Errorf("Failed to check account role policies of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if upgradeNeeded {
+		return true
+	}
+	credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get operator credential requests: %v", err)
+		os.Exit(1)
+	}
+	prefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, r.AWSClient)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get operator role policy prefix of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	upgradeNeeded, err = r.AWSClient.IsUpgradedNeededForOperatorRolePoliciesUsingCluster(cluster,
+		r.Creator.AccountID, minor, credRequests, prefix)
+	if err != nil {
+		r.Reporter.Errorf("Failed to check operator role policies of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	return upgradeNeeded
+}
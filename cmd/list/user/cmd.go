@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
@@ -31,18 +32,32 @@ import (
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	idpName string
+}
+
 var Cmd = &cobra.Command{
 	Use:     "users",
 	Aliases: []string{"user"},
 	Short:   "List cluster users",
 	Long:    "List administrative cluster users.",
 	Example: `  # List all users on a cluster named "mycluster"
-  rosa list users --cluster=mycluster`,
+  rosa list users --cluster=mycluster
+
+  # List the users of the HTPasswd identity provider named "htpasswd" on a cluster named "mycluster"
+  rosa list users --cluster=mycluster --idp=htpasswd`,
 	Run: run,
 }
 
 func init() {
 	ocm.AddClusterFlag(Cmd)
+
+	Cmd.Flags().StringVar(
+		&args.idpName,
+		"idp",
+		"",
+		"Name of an HTPasswd identity provider to list the users of, instead of the administrative users.",
+	)
 }
 
 func run(_ *cobra.Command, _ []string) {
@@ -57,6 +72,11 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if args.idpName != "" {
+		listHTPasswdUsers(r, cluster, clusterKey)
+		return
+	}
+
 	var clusterAdmins []*cmv1.User
 	var err error
 	r.Reporter.Debugf("Loading users for cluster '%s'", clusterKey)
@@ -109,3 +129,52 @@ func run(_ *cobra.Command, _ []string) {
 		writer.Flush()
 	}
 }
+
+func listHTPasswdUsers(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string) {
+	r.Reporter.Debugf("Loading identity provider '%s'", args.idpName)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	var htpasswdIDP *cmv1.IdentityProvider
+	for _, item := range idps {
+		if item.Name() == args.idpName {
+			htpasswdIDP = item
+		}
+	}
+	if htpasswdIDP == nil {
+		r.Reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", args.idpName, clusterKey)
+		os.Exit(1)
+	}
+	if ocm.IdentityProviderType(htpasswdIDP) != ocm.HTPasswdIDPType {
+		r.Reporter.Errorf("Identity provider '%s' is not an HTPasswd identity provider", args.idpName)
+		os.Exit(1)
+	}
+
+	userList, err := r.OCMClient.GetHTPasswdUserList(cluster.ID(), htpasswdIDP.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get user list of the HTPasswd IDP of '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	users := userList.Slice()
+	// Single user HTPasswd IDPs keep the user in the IDP itself
+	if len(users) == 0 && htpasswdIDP.Htpasswd().Username() != "" {
+		user, _ := cmv1.NewHTPasswdUser().Username(htpasswdIDP.Htpasswd().Username()).Build()
+		users = append(users, user)
+	}
+	if len(users) == 0 {
+		r.Reporter.Warnf("There are no users in identity provider '%s'", args.idpName)
+		os.Exit(1)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username() < users[j].Username()
+	})
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tUSERNAME\t\n")
+	for _, user := range users {
+		fmt.Fprintf(writer, "%s\t%s\t\n", user.ID(), user.Username())
+	}
+	writer.Flush()
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package login
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -48,6 +49,8 @@ var args struct {
 	env          string
 	token        string
 	insecure     bool
+	name         string
+	tokenStorage string
 }
 
 var Cmd = &cobra.Command{
@@ -62,7 +65,16 @@ var Cmd = &cobra.Command{
 		"\t4. Configuration file\n"+
 		"\t5. Command-line prompt\n", uiTokenPage),
 	Example: fmt.Sprintf(`  # Login to the OpenShift API with an existing token generated from %s
-  rosa login --token=$OFFLINE_ACCESS_TOKEN`, uiTokenPage),
+  rosa login --token=$OFFLINE_ACCESS_TOKEN
+
+  # Login to FedRAMP in a separate session, keeping the current one
+  rosa login --govcloud --name gov
+
+  # Use the separate session
+  rosa whoami --session gov
+
+  # Move the tokens of all the sessions from the configuration file to the keyring
+  rosa login --token-storage keyring`, uiTokenPage),
 	Run: run,
 }
 
@@ -121,6 +133,22 @@ func init() {
 		"Enables insecure communication with the server. This disables verification of TLS "+
 			"certificates and host names.",
 	)
+	flags.StringVar(
+		&args.name,
+		"name",
+		"",
+		"Name of the session to save the credentials to, which becomes the current session. "+
+			"Sessions allow staying logged in to several accounts or environments at once.",
+	)
+	flags.StringVar(
+		&args.tokenStorage,
+		"token-storage",
+		"",
+		fmt.Sprintf("Where to store the tokens of all the sessions. Options: %s. The keyring is the Secret "+
+			"Service of the desktop session when available, otherwise a file encrypted with a passphrase "+
+			"taken from the ROSA_KEYRING_PASSPHRASE environment variable or asked for. "+
+			"Existing tokens are moved to the new storage.", strings.Join(config.TokenStorages, ", ")),
+	)
 	arguments.AddRegionFlag(flags)
 	fedramp.AddFlag(flags)
 }
@@ -130,19 +158,46 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Check mandatory options:
 	env := args.env
+	if !cmd.Flags().Changed("env") && arguments.GetEnv() != "" {
+		env = arguments.GetEnv()
+	}
 	if env == "" {
 		r.Reporter.Errorf("Option '--env' is mandatory")
 		os.Exit(1)
 	}
 
-	// Load the configuration file:
+	// Load the configuration file. A session that doesn't exist yet is created by logging in:
 	cfg, err := config.Load()
-	if err != nil {
+	if err != nil && !errors.Is(err, config.ErrSessionNotFound) {
 		r.Reporter.Errorf("Failed to load config file: %v", err)
 		os.Exit(1)
 	}
 	if cfg == nil {
-		cfg = new(config.Config)
+		cfg = config.New()
+	}
+	if cmd.Flags().Changed("name") {
+		err = config.ValidateSessionName(args.name)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		cfg.SwitchSession(args.name)
+		cfg.CurrentSession = args.name
+		if args.name == config.DefaultSession {
+			cfg.CurrentSession = ""
+		}
+	}
+	if cmd.Flags().Changed("token-storage") {
+		switch args.tokenStorage {
+		case config.TokenStorageFile:
+			cfg.TokenStorage = ""
+		case config.TokenStorageKeyring:
+			cfg.TokenStorage = config.TokenStorageKeyring
+		default:
+			r.Reporter.Errorf("Invalid token storage '%s'. Options are: %s", args.tokenStorage,
+				strings.Join(config.TokenStorages, ", "))
+			os.Exit(1)
+		}
 	}
 
 	token := args.token
@@ -309,6 +364,13 @@ func run(cmd *cobra.Command, argv []string) {
 		r.Reporter.Errorf("Failed to save config file: %v", err)
 		os.Exit(1)
 	}
+	if cmd.Flags().Changed("token-storage") {
+		if cfg.UsesKeyring() {
+			r.Reporter.Infof("Tokens are stored in the keyring")
+		} else {
+			r.Reporter.Infof("Tokens are stored in the configuration file")
+		}
+	}
 
 	username, err := cfg.GetData("username")
 	if err != nil {
@@ -316,7 +378,11 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
-	r.Reporter.Infof("Logged in as '%s' on '%s'", username, cfg.URL)
+	if cfg.SessionName() != config.DefaultSession {
+		r.Reporter.Infof("Logged in as '%s' on '%s' in session '%s'", username, cfg.URL, cfg.SessionName())
+	} else {
+		r.Reporter.Infof("Logged in as '%s' on '%s'", username, cfg.URL)
+	}
 	r.OCMClient.LogEvent("ROSALoginSuccess", map[string]string{
 		ocm.Response: ocm.Success,
 		ocm.Username: username,
@@ -370,7 +436,7 @@ func Call(cmd *cobra.Command, argv []string, reporter *rprtr.Object) error {
 	// Verify if user is already logged in:
 	isLoggedIn := false
 	cfg, err := config.Load()
-	if err != nil {
+	if err != nil && !errors.Is(err, config.ErrSessionNotFound) {
 		return fmt.Errorf("Failed to load config file: %v", err)
 	}
 	if cfg != nil {
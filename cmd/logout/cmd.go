@@ -28,14 +28,32 @@ import (
 var Cmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Log out",
-	Long:  "Log out, removing the configuration file.",
+	Long:  "Log out of the session in use, removing its credentials from the configuration file.",
 	Run:   run,
 }
 
 func run(cmd *cobra.Command, argv []string) {
 	reporter := rprtr.CreateReporterOrExit()
+	cfg, err := config.Load()
+	if err != nil {
+		reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	if cfg == nil {
+		return
+	}
+	// Remove the credentials of the session in use, keeping the rest of the configuration:
+	cfg.Logout()
+	if !cfg.IsEmpty() {
+		err = config.Save(cfg)
+		if err != nil {
+			reporter.Errorf("Failed to save config file: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
 	// Remove the configuration file:
-	err := config.Remove()
+	err = config.Remove()
 	if err != nil {
 		reporter.Errorf("Failed to remove config file: %v", err)
 		os.Exit(1)
@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/cmd/logs/install"
+	"github.com/openshift/rosa/cmd/logs/service"
 	"github.com/openshift/rosa/cmd/logs/uninstall"
 	"github.com/openshift/rosa/pkg/arguments"
 )
@@ -38,6 +39,7 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(install.Cmd)
+	Cmd.AddCommand(service.Cmd)
 	Cmd.AddCommand(uninstall.Cmd)
 
 	flags := Cmd.PersistentFlags()
@@ -0,0 +1,163 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	msv1 "github.com/openshift-online/ocm-sdk-go/servicemgmt/v1"
+	"github.com/spf13/cobra"
+	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	id    string
+	tail  int
+	watch bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "managed-service",
+	Aliases: []string{"appliance", "service"},
+	Short:   "Show managed service installation progress",
+	Long: "Show the state of a managed service and of its resources, followed by the installation " +
+		"logs of its cluster.",
+	Example: `  # Show the installation progress of a managed service
+  rosa logs managed-service --id=<service id>
+
+  # Watch the installation progress until the service is ready
+  rosa logs managed-service --id=<service id> --watch`,
+	Run:    run,
+	Hidden: true,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.id,
+		"id",
+		"",
+		"The id of the service to show the logs of",
+	)
+
+	flags.IntVar(
+		&args.tail,
+		"tail",
+		2000,
+		"Number of lines to get from the end of the cluster installation log.",
+	)
+
+	flags.BoolVarP(
+		&args.watch,
+		"watch",
+		"w",
+		false,
+		"After getting the logs, watch for changes until the service is ready or fails.",
+	)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	if args.id == "" {
+		r.Reporter.Errorf("Service id not specified.")
+		cmd.Help()
+		os.Exit(1)
+	}
+
+	states := map[string]string{}
+	lastLine := ""
+	for {
+		r.Reporter.Debugf("Loading service %q", args.id)
+		service, err := r.OCMClient.GetManagedService(ocm.DescribeManagedServiceArgs{ID: args.id})
+		if err != nil {
+			r.Reporter.Errorf("Failed to get service %q: %v", args.id, err)
+			os.Exit(1)
+		}
+
+		printStates(service, states)
+
+		// The cluster installation logs are only available once the service created its cluster
+		clusterID := service.Cluster().Id()
+		if clusterID != "" {
+			logs, err := r.OCMClient.GetInstallLogs(clusterID, args.tail)
+			if err != nil && errors.GetType(err) != errors.NotFound {
+				r.Reporter.Errorf("Failed to get logs for service %q: %v", args.id, err)
+				os.Exit(1)
+			}
+			if err == nil {
+				lastLine = printLog(logs.Content(), lastLine)
+			}
+		}
+
+		state := strings.ToLower(service.ServiceState())
+		if state == "ready" {
+			r.Reporter.Infof("Service %q is ready", args.id)
+			return
+		}
+		if strings.Contains(state, "error") || strings.Contains(state, "fail") {
+			r.Reporter.Errorf("There was an error installing service %q: %s", args.id, service.ServiceState())
+			os.Exit(1)
+		}
+		if !args.watch {
+			return
+		}
+		time.Sleep(30 * time.Second)
+	}
+}
+
+// printStates prints the state of the service, its add-on and its resources when they change
+func printStates(service *msv1.ManagedService, states map[string]string) {
+	printState := func(name string, state string) {
+		if state == "" || states[name] == state {
+			return
+		}
+		states[name] = state
+		fmt.Printf("%-40s%s\n", name+":", state)
+	}
+	printState("Service", service.ServiceState())
+	printState("Cluster "+service.Cluster().Name(), service.Cluster().State())
+	printState("Add-on "+service.Service(), service.Addon().State())
+	for _, resource := range service.Resources() {
+		printState(fmt.Sprintf("%s %s", resource.Kind(), resource.ID()), resource.State())
+	}
+}
+
+// printLog prints the lines of the log that come after the last printed line and returns the new
+// last line
+func printLog(content string, lastLine string) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	for i, line := range lines {
+		if lastLine != "" && line == lastLine {
+			lines = lines[i+1:]
+			break
+		}
+	}
+	if len(lines) == 0 || (len(lines) == 1 && lines[0] == "") {
+		return lastLine
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	return lines[len(lines)-1]
+}
@@ -18,26 +18,65 @@ package cluster
 
 import (
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/helper/cron"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+var args struct {
+	schedule      string
+	applySchedule bool
+}
+
 var Cmd = &cobra.Command{
 	Use:   "cluster",
 	Short: "Resume cluster",
-	Long:  "Resume cluster.",
+	Long: "Resume cluster.\n\n" +
+		"Schedules set with '--schedule' are only stored with the cluster, nothing applies " +
+		"them automatically. Run 'rosa resume cluster --apply-schedule' periodically from a scheduler " +
+		"of your own, like cron or a CI pipeline, to resume the cluster when its schedule says so.",
 	Example: `  # Resume the cluster
-  rosa resume cluster -c mycluster`,
+  rosa resume cluster -c mycluster
+
+  # Resume the cluster every week day at 8am UTC,
+  # together with 'rosa hibernate cluster --schedule'
+  rosa resume cluster -c mycluster --schedule "0 8 * * 1-5"
+
+  # Resume the cluster if its schedule says so, meant to be run periodically by an
+  # external scheduler, like cron
+  rosa resume cluster -c mycluster --apply-schedule`,
 	Run: run,
 }
 
 func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
 	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.schedule,
+		"schedule",
+		"",
+		"Cron expression in UTC of when the cluster should be resumed. An empty value removes the schedule. "+
+			"The schedule isn't applied automatically, an external scheduler has to run the command with "+
+			"'--apply-schedule' periodically.",
+	)
+
+	flags.BoolVar(
+		&args.applySchedule,
+		"apply-schedule",
+		false,
+		"Resume the cluster only if its resume schedule ran more recently than its hibernation schedule.",
+	)
+
+	confirm.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -47,13 +86,43 @@ func run(cmd *cobra.Command, _ []string) {
 	clusterKey := r.GetClusterKey()
 	cluster := r.FetchCluster()
 
+	if cmd.Flags().Changed("schedule") {
+		if args.applySchedule {
+			r.Reporter.Errorf("The '--schedule' and '--apply-schedule' options can't be used together")
+			os.Exit(1)
+		}
+		setSchedule(r, cluster, clusterKey, args.schedule)
+		return
+	}
+
+	if args.applySchedule {
+		schedule, err := r.OCMClient.GetHibernationSchedule(cluster)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get hibernation schedule of cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		if schedule.IsEmpty() {
+			r.Reporter.Errorf("Cluster '%s' has no hibernation schedule", clusterKey)
+			os.Exit(1)
+		}
+		hibernated, ok := schedule.Hibernated(time.Now())
+		if !ok || hibernated {
+			r.Reporter.Infof("Cluster '%s' is not scheduled to be resumed now", clusterKey)
+			os.Exit(0)
+		}
+		if cluster.State() != cmv1.ClusterStateHibernating {
+			r.Reporter.Infof("Cluster '%s' is not hibernating", clusterKey)
+			os.Exit(0)
+		}
+	}
+
 	if cluster.State() != cmv1.ClusterStateHibernating {
 		r.Reporter.Errorf("Resuming a cluster from hibernation is only supported for clusters in "+
 			"'Hibernating' state. Cluster '%s' is in '%s' state",
 			clusterKey, cluster.State())
 		os.Exit(1)
 	}
-	if !confirm.Confirm("resume cluster %s", clusterKey) {
+	if !args.applySchedule && !confirm.Confirm("resume cluster %s", clusterKey) {
 		os.Exit(1)
 	}
 	err := r.OCMClient.ResumeCluster(cluster.ID())
@@ -61,5 +130,35 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
 		os.Exit(1)
 	}
+	err = r.OCMClient.RecordHibernationEvent(cluster, ocm.HibernationActionResume, time.Now())
+	if err != nil {
+		r.Reporter.Warnf("Failed to record resumption of cluster '%s': %v", clusterKey, err)
+	}
 	r.Reporter.Infof("Cluster '%s' is resuming.", clusterKey)
 }
+
+func setSchedule(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, value string) {
+	var err error
+	if value == "" {
+		err = r.OCMClient.DeleteSubscriptionLabel(cluster.Subscription().ID(), ocm.ResumeScheduleLabel)
+		if err != nil {
+			r.Reporter.Errorf("Failed to remove resume schedule of cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Removed resume schedule of cluster '%s'", clusterKey)
+		return
+	}
+	schedule, err := cron.Parse(value)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	err = r.OCMClient.SetSubscriptionLabel(cluster.Subscription().ID(), ocm.ResumeScheduleLabel, value)
+	if err != nil {
+		r.Reporter.Errorf("Failed to set resume schedule of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Set resume schedule '%s' of cluster '%s', next on %s. The schedule is only applied "+
+		"when 'rosa resume cluster -c %s --apply-schedule' runs, run it periodically with an external scheduler",
+		value, clusterKey, schedule.Next(time.Now()).Format("2006-01-02 15:04 MST"), clusterKey)
+}
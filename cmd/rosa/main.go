@@ -23,13 +23,16 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/ack"
 	"github.com/openshift/rosa/cmd/completion"
+	"github.com/openshift/rosa/cmd/config"
 	"github.com/openshift/rosa/cmd/create"
 	"github.com/openshift/rosa/cmd/describe"
 	"github.com/openshift/rosa/cmd/dlt"
 	"github.com/openshift/rosa/cmd/docs"
 	"github.com/openshift/rosa/cmd/download"
 	"github.com/openshift/rosa/cmd/edit"
+	"github.com/openshift/rosa/cmd/extend"
 	"github.com/openshift/rosa/cmd/grant"
 	"github.com/openshift/rosa/cmd/hibernate"
 	"github.com/openshift/rosa/cmd/initialize"
@@ -41,6 +44,8 @@ import (
 	"github.com/openshift/rosa/cmd/logs"
 	"github.com/openshift/rosa/cmd/resume"
 	"github.com/openshift/rosa/cmd/revoke"
+	"github.com/openshift/rosa/cmd/sync"
+	"github.com/openshift/rosa/cmd/ui"
 	"github.com/openshift/rosa/cmd/uninstall"
 	"github.com/openshift/rosa/cmd/unlink"
 	"github.com/openshift/rosa/cmd/upgrade"
@@ -57,6 +62,10 @@ var root = &cobra.Command{
 	Long: "Command line tool for Red Hat OpenShift Service on AWS.\n" +
 		"For further documentation visit " +
 		"https://access.redhat.com/documentation/en-us/red_hat_openshift_service_on_aws\n",
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		// Apply the defaults of the current context of the configuration file
+		return arguments.LoadContext(cmd)
+	},
 }
 
 func init() {
@@ -64,8 +73,10 @@ func init() {
 	fs := root.PersistentFlags()
 	color.AddFlag(root)
 	arguments.AddDebugFlag(fs)
+	arguments.AddSessionFlag(fs)
 
 	// Register the subcommands:
+	root.AddCommand(ack.Cmd)
 	root.AddCommand(completion.Cmd)
 	root.AddCommand(create.Cmd)
 	root.AddCommand(describe.Cmd)
@@ -81,6 +92,7 @@ func init() {
 	root.AddCommand(logout.Cmd)
 	root.AddCommand(logs.Cmd)
 	root.AddCommand(revoke.Cmd)
+	root.AddCommand(sync.Cmd)
 	root.AddCommand(uninstall.Cmd)
 	root.AddCommand(upgrade.Cmd)
 	root.AddCommand(verify.Cmd)
@@ -88,6 +100,9 @@ func init() {
 	root.AddCommand(whoami.Cmd)
 	root.AddCommand(hibernate.Cmd)
 	root.AddCommand(resume.Cmd)
+	root.AddCommand(extend.Cmd)
+	root.AddCommand(ui.Cmd)
+	root.AddCommand(config.Cmd)
 	root.AddCommand(link.Cmd)
 	root.AddCommand(unlink.Cmd)
 }
@@ -0,0 +1,250 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package users
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper/groups"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	file         string
+	bindPassword string
+	githubToken  string
+	dryRun       bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "users",
+	Aliases: []string{"user"},
+	Short:   "Synchronize cluster users with a file",
+	Long: "Make the users of the cluster-admins and dedicated-admins roles match a YAML file. Users " +
+		"missing from the file are removed from the role, and members of the groups listed in the file " +
+		"are added to it. Roles that are not in the file are not changed.",
+	Example: `  # Synchronize the users of cluster 'mycluster' with a file like:
+  #   cluster-admins:
+  #     users:
+  #     - alice
+  #   dedicated-admins:
+  #     users:
+  #     - bob
+  #     groups:
+  #     - idp: ldap-1
+  #       group: cn=admins,ou=groups,dc=example,dc=com
+  rosa sync users --from-file=admins.yaml --bind-password=<password> --cluster=mycluster
+
+  # Show the changes without applying them
+  rosa sync users --from-file=admins.yaml --cluster=mycluster --dry-run`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.file,
+		"from-file",
+		"",
+		"Path to a YAML file with the 'users' and identity provider 'groups' of each role (required).",
+	)
+	Cmd.MarkFlagRequired("from-file")
+
+	flags.StringVar(
+		&args.bindPassword,
+		"bind-password",
+		"",
+		"LDAP: Password of the bind DN of the identity providers of the groups.",
+	)
+
+	flags.StringVar(
+		&args.githubToken,
+		"github-token",
+		"",
+		"GitHub: Token used to list the members of teams. Defaults to the GITHUB_TOKEN environment variable.",
+	)
+
+	flags.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Show the changes without applying them.",
+	)
+
+	output.AddFlag(Cmd)
+	confirm.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	// The token isn't used as the default value of the flag, as that would show it in the help
+	githubToken := args.githubToken
+	if githubToken == "" {
+		githubToken = os.Getenv("GITHUB_TOKEN")
+	}
+
+	roles, err := ReadUsersFile(args.file)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	desired := map[string][]string{}
+	existing := map[string][]string{}
+	var idps map[string]*cmv1.IdentityProvider
+	for role, members := range roles {
+		desired[role] = append([]string{}, members.Users...)
+		for _, group := range members.Groups {
+			if idps == nil {
+				idps = getIdentityProviders(r, cluster, clusterKey)
+			}
+			identityProvider, ok := idps[group.IDP]
+			if !ok {
+				r.Reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", group.IDP, clusterKey)
+				os.Exit(1)
+			}
+			r.Reporter.Debugf("Loading members of group '%s' of identity provider '%s'", group.Group, group.IDP)
+			usernames, err := groups.ResolveMembers(identityProvider, group.Group, groups.Options{
+				LDAPBindPassword: args.bindPassword,
+				GithubToken:      githubToken,
+			})
+			if err != nil {
+				r.Reporter.Errorf("Failed to get members of group '%s': %v", group.Group, err)
+				os.Exit(1)
+			}
+			for _, username := range usernames {
+				if validateUsername(username) != nil {
+					r.Reporter.Warnf("Skipping member '%s' of group '%s': username is not allowed",
+						username, group.Group)
+					continue
+				}
+				desired[role] = append(desired[role], username)
+			}
+		}
+
+		r.Reporter.Debugf("Loading users with role '%s' in cluster '%s'", role, clusterKey)
+		users, err := r.OCMClient.GetUsers(cluster.ID(), role)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get users with role '%s' for cluster '%s': %v", role, clusterKey, err)
+			os.Exit(1)
+		}
+		for _, user := range users {
+			existing[role] = append(existing[role], user.ID())
+		}
+	}
+
+	changes := PlanUserSync(existing, desired)
+	if output.HasFlag() {
+		err = output.Print(map[string]interface{}{
+			"changes": changes,
+		})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	} else if len(changes) > 0 {
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "ROLE\tACTION\tUSER\n")
+		for _, change := range changes {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", change.Role, change.Action, change.Username)
+		}
+		writer.Flush()
+	}
+	if len(changes) == 0 {
+		if !output.HasFlag() {
+			r.Reporter.Infof("Users of cluster '%s' already match file '%s'", clusterKey, args.file)
+		}
+		return
+	}
+	if args.dryRun {
+		return
+	}
+
+	if !confirm.Confirm("apply %d changes to the users of cluster '%s'", len(changes), clusterKey) {
+		os.Exit(0)
+	}
+
+	added := 0
+	removed := 0
+	for _, change := range changes {
+		switch change.Action {
+		case ActionAdd:
+			user, err := cmv1.NewUser().ID(change.Username).Build()
+			if err != nil {
+				r.Reporter.Errorf("Failed to create user '%s' for cluster '%s'", change.Username, clusterKey)
+				os.Exit(1)
+			}
+			r.Reporter.Debugf("Adding user '%s' to group '%s' in cluster '%s'", change.Username, change.Role,
+				clusterKey)
+			_, err = r.OCMClient.CreateUser(cluster.ID(), change.Role, user)
+			if err != nil {
+				r.Reporter.Errorf("Failed to grant '%s' to user '%s' to cluster '%s': %s",
+					change.Role, change.Username, clusterKey, err)
+				os.Exit(1)
+			}
+			added++
+		case ActionRemove:
+			r.Reporter.Debugf("Removing user '%s' from group '%s' in cluster '%s'", change.Username,
+				change.Role, clusterKey)
+			err = r.OCMClient.DeleteUser(cluster.ID(), change.Role, change.Username)
+			if err != nil {
+				r.Reporter.Errorf("Failed to revoke '%s' from user '%s' in cluster '%s': %s",
+					change.Role, change.Username, clusterKey, err)
+				os.Exit(1)
+			}
+			removed++
+		}
+	}
+	r.Reporter.Infof("Added %d and removed %d users on cluster '%s'", added, removed, clusterKey)
+}
+
+func getIdentityProviders(r *rosa.Runtime, cluster *cmv1.Cluster,
+	clusterKey string) map[string]*cmv1.IdentityProvider {
+	r.Reporter.Debugf("Loading identity providers for cluster '%s'", clusterKey)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	result := map[string]*cmv1.IdentityProvider{}
+	for _, item := range idps {
+		result[item.Name()] = item
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package users
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const (
+	ActionAdd    = "add"
+	ActionRemove = "remove"
+)
+
+// RoleMembers are the users that should have a role, given directly or as groups of identity
+// providers
+type RoleMembers struct {
+	Users  []string      `json:"users,omitempty"`
+	Groups []GroupMember `json:"groups,omitempty"`
+}
+
+type GroupMember struct {
+	IDP   string `json:"idp"`
+	Group string `json:"group"`
+}
+
+// UserChange is a user that has to be added to or removed from a role
+type UserChange struct {
+	Role     string `json:"role"`
+	Action   string `json:"action"`
+	Username string `json:"username"`
+}
+
+// ReadUsersFile reads the members of each role from a YAML file. Roles can be named in singular
+// form, and roles missing from the file are not changed.
+func ReadUsersFile(path string) (map[string]*RoleMembers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read users file '%s': %v", path, err)
+	}
+	return ParseUsersFile(data)
+}
+
+func ParseUsersFile(data []byte) (map[string]*RoleMembers, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse users file: %v", err)
+	}
+	// Reject unknown fields, as a typo would otherwise remove all the users of a role
+	file := map[string]*RoleMembers{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse users file: %v", err)
+	}
+	roles := map[string]*RoleMembers{}
+	for name, members := range file {
+		role, err := ocm.GetUserRole(name)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid role '%s' in users file: %v", name, err)
+		}
+		if _, ok := roles[role]; ok {
+			return nil, fmt.Errorf("Role '%s' is duplicated in users file", role)
+		}
+		if members == nil {
+			members = &RoleMembers{}
+		}
+		for _, username := range members.Users {
+			err = validateUsername(username)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, group := range members.Groups {
+			if group.IDP == "" || group.Group == "" {
+				return nil, fmt.Errorf("Expected groups of role '%s' to have an 'idp' and a 'group'", role)
+			}
+		}
+		roles[role] = members
+	}
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("Expected at least one of %s in users file", ocm.UserRoles)
+	}
+	return roles, nil
+}
+
+func validateUsername(username string) error {
+	if !ocm.IsValidUsername(username) {
+		return fmt.Errorf(
+			"Username '%s' isn't valid: it must contain only letters, digits, dashes and underscores",
+			username,
+		)
+	}
+	if username == idp.ClusterAdminUsername {
+		return fmt.Errorf("Username '%s' is not allowed", idp.ClusterAdminUsername)
+	}
+	return nil
+}
+
+// PlanUserSync returns the changes needed for each role to have exactly the desired users. The
+// cluster-admin user created by 'rosa create admin' is never removed.
+func PlanUserSync(existing map[string][]string, desired map[string][]string) []UserChange {
+	changes := []UserChange{}
+	roles := []string{}
+	for role := range desired {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	for _, role := range roles {
+		current := map[string]bool{}
+		for _, username := range existing[role] {
+			current[username] = true
+		}
+		wanted := map[string]bool{}
+		for _, username := range desired[role] {
+			if wanted[username] {
+				continue
+			}
+			wanted[username] = true
+			if !current[username] {
+				changes = append(changes, UserChange{Role: role, Action: ActionAdd, Username: username})
+			}
+		}
+		for _, username := range existing[role] {
+			if !wanted[username] && username != idp.ClusterAdminUsername {
+				changes = append(changes, UserChange{Role: role, Action: ActionRemove, Username: username})
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,75 @@
+package users_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/cmd/sync/users"
+)
+
+var _ = Describe("Users file", func() {
+	Context("ParseUsersFile", func() {
+		It("Parses users and groups of each role", func() {
+			roles, err := users.ParseUsersFile([]byte(`
+cluster-admin:
+  users:
+  - alice
+dedicated-admins:
+  users:
+  - bob
+  groups:
+  - idp: ldap-1
+    group: cn=admins,dc=example,dc=com
+`))
+			Expect(err).To(BeNil())
+			Expect(roles).To(HaveLen(2))
+			Expect(roles["cluster-admins"].Users).To(Equal([]string{"alice"}))
+			Expect(roles["dedicated-admins"].Groups).To(Equal([]users.GroupMember{
+				{IDP: "ldap-1", Group: "cn=admins,dc=example,dc=com"},
+			}))
+		})
+		It("Keeps empty roles so that all their users are removed", func() {
+			roles, err := users.ParseUsersFile([]byte("dedicated-admins: {}\n"))
+			Expect(err).To(BeNil())
+			Expect(roles["dedicated-admins"].Users).To(BeEmpty())
+		})
+		It("Rejects unknown roles and fields", func() {
+			_, err := users.ParseUsersFile([]byte("admins:\n  users: [alice]\n"))
+			Expect(err).To(HaveOccurred())
+			_, err = users.ParseUsersFile([]byte("cluster-admins:\n  user: [alice]\n"))
+			Expect(err).To(HaveOccurred())
+		})
+		It("Rejects invalid usernames", func() {
+			_, err := users.ParseUsersFile([]byte("cluster-admins:\n  users: [cluster-admin]\n"))
+			Expect(err).To(MatchError("Username 'cluster-admin' is not allowed"))
+			_, err = users.ParseUsersFile([]byte("cluster-admins:\n  users: ['a:b']\n"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("PlanUserSync", func() {
+		It("Adds and removes users to match the desired users", func() {
+			changes := users.PlanUserSync(
+				map[string][]string{
+					"cluster-admins":   {"cluster-admin", "alice", "carol"},
+					"dedicated-admins": {"bob"},
+				},
+				map[string][]string{
+					"cluster-admins":   {"alice", "dave", "dave"},
+					"dedicated-admins": {"bob"},
+				},
+			)
+			Expect(changes).To(Equal([]users.UserChange{
+				{Role: "cluster-admins", Action: users.ActionAdd, Username: "dave"},
+				{Role: "cluster-admins", Action: users.ActionRemove, Username: "carol"},
+			}))
+		})
+		It("Doesn't change roles that aren't desired", func() {
+			changes := users.PlanUserSync(
+				map[string][]string{"dedicated-admins": {"bob"}},
+				map[string][]string{"cluster-admins": {}},
+			)
+			Expect(changes).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package users_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestUsers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sync Users Suite")
+}
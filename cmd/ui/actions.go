@@ -0,0 +1,234 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	hibernate "github.com/openshift/rosa/cmd/hibernate/cluster"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/machinepools"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// ID of the machine pool of the compute nodes of classic clusters
+const defaultMachinePool = "Default"
+
+// Upgrades are scheduled to run soon, leaving time to cancel them
+const upgradeDelay = 10 * time.Minute
+
+func (a *app) hibernateCluster(cluster *cmv1.Cluster) {
+	if cluster.State() != cmv1.ClusterStateReady {
+		a.status = fmt.Sprintf("Hibernating a cluster is only supported for 'Ready' clusters. "+
+			"Cluster '%s' is in '%s' state", cluster.Name(), cluster.State())
+		return
+	}
+	reasons, warnings, err := hibernate.CheckHibernation(a.r, cluster)
+	if err != nil {
+		a.status = fmt.Sprintf("Failed to check whether cluster '%s' can be hibernated: %v", cluster.Name(), err)
+		return
+	}
+	if len(reasons) > 0 {
+		a.status = fmt.Sprintf("It isn't safe to hibernate cluster '%s': %s", cluster.Name(),
+			strings.Join(reasons, "; "))
+		return
+	}
+	// Shown together with the confirmation, writing to the terminal would garble the dashboard
+	a.status = strings.Join(warnings, "; ")
+	a.confirm(fmt.Sprintf("Hibernate cluster '%s'", cluster.Name()), func() {
+		err := a.r.OCMClient.HibernateCluster(cluster.ID())
+		if err != nil {
+			a.status = fmt.Sprintf("Failed to hibernate cluster '%s': %v", cluster.Name(), err)
+			return
+		}
+		err = a.r.OCMClient.RecordHibernationEvent(cluster, ocm.HibernationActionHibernate, time.Now())
+		if err != nil {
+			a.status = fmt.Sprintf("Cluster '%s' is hibernating, but recording it failed: %v", cluster.Name(), err)
+		}
+		a.reload()
+		if err == nil {
+			a.status = fmt.Sprintf("Cluster '%s' is hibernating", cluster.Name())
+		}
+	})
+}
+
+func (a *app) scaleMachinePool(cluster *cmv1.Cluster, id string, replicas int, multiAZ bool) {
+	question := fmt.Sprintf("Replicas of machine pool '%s':", id)
+	a.ask(question, strconv.Itoa(replicas), func(input string) {
+		replicas, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil || replicas < 0 {
+			a.status = fmt.Sprintf("Expected a non-negative number of replicas, got '%s'", input)
+			return
+		}
+		err = validateReplicas(cluster, id, replicas, multiAZ)
+		if err != nil {
+			a.status = err.Error()
+			return
+		}
+		a.confirm(fmt.Sprintf("Scale machine pool '%s' to %d replicas", id, replicas), func() {
+			err := a.updateReplicas(cluster, id, replicas)
+			if err != nil {
+				a.status = fmt.Sprintf("Failed to update machine pool '%s' on cluster '%s': %v",
+					id, cluster.Name(), err)
+				return
+			}
+			a.reload()
+			a.status = fmt.Sprintf("Updated machine pool '%s' on cluster '%s'", id, cluster.Name())
+		})
+	})
+}
+
+// validateReplicas checks the number of replicas the same way 'rosa edit machinepool' does. The
+// multiAZ flag tells if the machine pool is spread over more than one availability zone.
+func validateReplicas(cluster *cmv1.Cluster, id string, replicas int, multiAZ bool) error {
+	if cluster.Hypershift().Enabled() {
+		return nil
+	}
+	if id == defaultMachinePool {
+		return machinepools.ValidateDefaultMachinePoolReplicas(cluster.MultiAZ(), false, replicas, 0, 0, false)
+	}
+	return machinepools.ValidateMachinePoolReplicas(cluster.MultiAZ() && multiAZ, false, replicas, 0, 0, false)
+}
+
+func (a *app) updateReplicas(cluster *cmv1.Cluster, id string, replicas int) error {
+	if cluster.Hypershift().Enabled() {
+		nodePool, err := cmv1.NewNodePool().ID(id).Replicas(replicas).Build()
+		if err != nil {
+			return err
+		}
+		_, err = a.r.OCMClient.UpdateNodePool(cluster.ID(), nodePool)
+		return err
+	}
+	if id == defaultMachinePool {
+		return a.r.OCMClient.UpdateCluster(cluster.ID(), a.r.Creator, ocm.Spec{ComputeNodes: replicas})
+	}
+	machinePool, err := cmv1.NewMachinePool().ID(id).Replicas(replicas).Build()
+	if err != nil {
+		return err
+	}
+	_, err = a.r.OCMClient.UpdateMachinePool(cluster.ID(), machinePool)
+	return err
+}
+
+func (a *app) upgradeCluster(cluster *cmv1.Cluster) {
+	availableUpgrades, err := a.r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+	if err != nil {
+		a.status = fmt.Sprintf("Failed to find available upgrades: %v", err)
+		return
+	}
+	if len(availableUpgrades) == 0 {
+		a.status = fmt.Sprintf("There are no available upgrades for cluster '%s'", cluster.Name())
+		return
+	}
+	scheduled, err := a.hasScheduledUpgrade(cluster)
+	if err != nil {
+		a.status = fmt.Sprintf("Failed to get scheduled upgrades for cluster '%s': %v", cluster.Name(), err)
+		return
+	}
+	if scheduled {
+		a.status = fmt.Sprintf("There is already a scheduled upgrade for cluster '%s'", cluster.Name())
+		return
+	}
+	question := fmt.Sprintf("Version to upgrade to (%s):", strings.Join(availableUpgrades, ", "))
+	a.ask(question, availableUpgrades[0], func(input string) {
+		version := strings.TrimSpace(input)
+		if !helper.Contains(availableUpgrades, version) {
+			a.status = fmt.Sprintf("Version '%s' isn't an available upgrade for cluster '%s'", version, cluster.Name())
+			return
+		}
+		// The roles of STS clusters may need to be upgraded for a new minor version, which
+		// 'rosa upgrade cluster' checks
+		if cluster.AWS().STS().Enabled() &&
+			ocm.GetVersionMinor(version) != ocm.GetVersionMinor(cluster.Version().RawID()) {
+			a.status = fmt.Sprintf("Run 'rosa upgrade cluster -c %s --version %s' to check the roles of the "+
+				"cluster before upgrading to a new minor version", cluster.Name(), version)
+			return
+		}
+		nextRun := time.Now().UTC().Add(upgradeDelay)
+		a.confirm(fmt.Sprintf("Upgrade cluster '%s' to version %s on %s", cluster.Name(), version,
+			nextRun.Format("2006-01-02 15:04 MST")), func() {
+			err := a.scheduleUpgrade(cluster, version, nextRun)
+			if err != nil {
+				a.status = err.Error()
+				return
+			}
+			a.reload()
+			a.status = fmt.Sprintf("Upgrade successfully scheduled for cluster '%s'", cluster.Name())
+		})
+	})
+}
+
+func (a *app) hasScheduledUpgrade(cluster *cmv1.Cluster) (bool, error) {
+	if cluster.Hypershift().Enabled() {
+		upgradePolicy, err := a.r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		return upgradePolicy != nil, err
+	}
+	upgradePolicy, _, err := a.r.OCMClient.GetScheduledUpgrade(cluster.ID())
+	return upgradePolicy != nil, err
+}
+
+// scheduleUpgrade schedules the upgrade unless it requires acknowledging version gates, which is
+// left to 'rosa upgrade cluster' and 'rosa ack gates'
+func (a *app) scheduleUpgrade(cluster *cmv1.Cluster, version string, nextRun time.Time) error {
+	var gates []*cmv1.VersionGate
+	if cluster.Hypershift().Enabled() {
+		upgradePolicy, err := cmv1.NewControlPlaneUpgradePolicy().ScheduleType("manual").
+			UpgradeType("ControlPlane").Version(version).NextRun(nextRun).Build()
+		if err != nil {
+			return err
+		}
+		gates, err = a.r.OCMClient.GetMissingGateAgreementsHypershift(cluster.ID(), upgradePolicy)
+		if err != nil {
+			return fmt.Errorf("Failed to check for missing gate agreements upgrade for cluster '%s': %v",
+				cluster.Name(), err)
+		}
+		if len(gates) == 0 {
+			err = a.r.OCMClient.ScheduleHypershiftControlPlaneUpgrade(cluster.ID(), upgradePolicy)
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to schedule upgrade for cluster '%s': %v", cluster.Name(), err)
+		}
+	} else {
+		upgradePolicy, err := cmv1.NewUpgradePolicy().ScheduleType("manual").
+			Version(version).NextRun(nextRun).Build()
+		if err != nil {
+			return err
+		}
+		gates, err = a.r.OCMClient.GetMissingGateAgreementsClassic(cluster.ID(), upgradePolicy)
+		if err != nil {
+			return fmt.Errorf("Failed to check for missing gate agreements upgrade for cluster '%s': %v",
+				cluster.Name(), err)
+		}
+		if len(gates) == 0 {
+			err = a.r.OCMClient.ScheduleUpgrade(cluster.ID(), upgradePolicy)
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to schedule upgrade for cluster '%s': %v", cluster.Name(), err)
+		}
+	}
+	if len(gates) > 0 {
+		return fmt.Errorf("Upgrading cluster '%s' to version %s requires acknowledging %d version gates, "+
+			"run 'rosa ack gates -c %s --version %s' first", cluster.Name(), version, len(gates),
+			cluster.Name(), ocm.GetVersionMinor(version))
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// Keys shown in the footer of every view
+const commonHelp = "↑/↓ move  enter open  r refresh  esc back  q quit"
+
+// view is a screen of the dashboard showing a table, like a list of clusters or logs
+type view struct {
+	title string
+	// Keys of the actions of the view shown in the footer
+	help  string
+	table *table
+	// load fetches the content of the table
+	load func() error
+	// Reload the view periodically when not zero
+	refresh  time.Duration
+	loadedAt time.Time
+	// open returns the view to show when the selected row is opened, if any
+	open func(row int) *view
+	// Actions run on the selected row by pressing their key
+	actions map[rune]func(row int)
+}
+
+// prompt asks the user for a line of text that is passed to submit when enter is pressed
+type prompt struct {
+	question string
+	input    string
+	submit   func(input string)
+}
+
+type app struct {
+	r      *rosa.Runtime
+	term   *terminal
+	views  []*view
+	prompt *prompt
+	status string
+	quit   bool
+}
+
+func (a *app) current() *view {
+	return a.views[len(a.views)-1]
+}
+
+// push loads a view and shows it on top of the current one
+func (a *app) push(v *view) {
+	a.status = fmt.Sprintf("Loading %s...", v.title)
+	a.draw()
+	err := a.loadView(v)
+	if err != nil {
+		a.status = err.Error()
+		return
+	}
+	a.views = append(a.views, v)
+	a.status = ""
+}
+
+func (a *app) loadView(v *view) error {
+	err := v.load()
+	v.loadedAt = time.Now()
+	return err
+}
+
+func (a *app) reload() {
+	a.status = "Refreshing..."
+	a.draw()
+	a.status = ""
+	err := a.loadView(a.current())
+	if err != nil {
+		a.status = err.Error()
+	}
+}
+
+// ask shows a prompt that asks the user for a value
+func (a *app) ask(question string, initial string, submit func(input string)) {
+	a.prompt = &prompt{
+		question: question,
+		input:    initial,
+		submit:   submit,
+	}
+}
+
+// confirm asks the user to confirm an action before running it
+func (a *app) confirm(question string, action func()) {
+	a.ask(fmt.Sprintf("%s? [y/N]", question), "", func(input string) {
+		if strings.EqualFold(strings.TrimSpace(input), "y") {
+			action()
+			return
+		}
+		a.status = "Cancelled"
+	})
+}
+
+// run shows the view until the user quits
+func (a *app) run(v *view) {
+	a.push(v)
+	if len(a.views) == 0 {
+		return
+	}
+	keys := make(chan keyEvent)
+	go a.term.readKeys(keys)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for !a.quit {
+		a.draw()
+		select {
+		case k, ok := <-keys:
+			if !ok {
+				return
+			}
+			a.handle(k)
+		case <-ticker.C:
+			current := a.current()
+			if a.prompt == nil && current.refresh != 0 && time.Since(current.loadedAt) >= current.refresh {
+				err := a.loadView(current)
+				if err != nil {
+					a.status = err.Error()
+				}
+			}
+		}
+	}
+}
+
+func (a *app) handle(k keyEvent) {
+	if k.key == keyCtrlC {
+		a.quit = true
+		return
+	}
+	if a.prompt != nil {
+		a.handlePrompt(k)
+		return
+	}
+	current := a.current()
+	switch k.key {
+	case keyUp:
+		current.table.move(-1)
+	case keyDown:
+		current.table.move(1)
+	case keyPageUp:
+		current.table.move(-current.table.page())
+	case keyPageDown:
+		current.table.move(current.table.page())
+	case keyHome:
+		current.table.move(-len(current.table.rows))
+	case keyEnd:
+		current.table.move(len(current.table.rows))
+	case keyEscape:
+		a.back()
+	case keyEnter:
+		if current.open != nil && len(current.table.rows) > 0 {
+			next := current.open(current.table.selected)
+			if next != nil {
+				a.push(next)
+			}
+		}
+	case keyRune:
+		a.handleRune(current, k.rune)
+	}
+}
+
+func (a *app) handleRune(current *view, r rune) {
+	if action, ok := current.actions[r]; ok {
+		if len(current.table.rows) > 0 {
+			a.status = ""
+			action(current.table.selected)
+		}
+		return
+	}
+	switch r {
+	case 'q':
+		a.quit = true
+	case 'r':
+		a.reload()
+	case 'k':
+		current.table.move(-1)
+	case 'j':
+		current.table.move(1)
+	}
+}
+
+func (a *app) handlePrompt(k keyEvent) {
+	switch k.key {
+	case keyEscape:
+		a.prompt = nil
+		a.status = "Cancelled"
+	case keyEnter:
+		p := a.prompt
+		a.prompt = nil
+		p.submit(p.input)
+	case keyBackspace:
+		runes := []rune(a.prompt.input)
+		if len(runes) > 0 {
+			a.prompt.input = string(runes[:len(runes)-1])
+		}
+	case keyRune:
+		a.prompt.input += string(k.rune)
+	}
+}
+
+// back closes the current view, or quits from the first one
+func (a *app) back() {
+	if len(a.views) == 1 {
+		a.quit = true
+		return
+	}
+	a.views = a.views[:len(a.views)-1]
+}
+
+func (a *app) draw() {
+	width, height := a.term.size()
+	a.term.draw(a.render(width, height))
+}
+
+// render returns the lines of the screen: a title bar, the table of the current view and a footer
+// with the status, the prompt and the keys of the view
+func (a *app) render(width int, height int) []string {
+	titles := []string{"ROSA"}
+	for _, v := range a.views {
+		titles = append(titles, v.title)
+	}
+	title := truncate(" "+strings.Join(titles, " > "), width)
+	lines := []string{reverseVideo + title + strings.Repeat(" ", width-len([]rune(title))) + resetStyle}
+
+	footer := []string{}
+	if a.status != "" {
+		footer = append(footer, truncate(a.status, width))
+	}
+	if a.prompt != nil {
+		footer = append(footer, truncate(fmt.Sprintf("%s %s_", a.prompt.question, a.prompt.input), width))
+	} else if len(a.views) > 0 {
+		help := commonHelp
+		if a.current().help != "" {
+			help = a.current().help + "  " + help
+		}
+		footer = append(footer, truncate(help, width))
+	}
+
+	body := []string{}
+	if len(a.views) > 0 {
+		body = a.current().table.render(width, height-len(lines)-len(footer)-1)
+	}
+	lines = append(lines, body...)
+	for len(lines) < height-len(footer) {
+		lines = append(lines, "")
+	}
+	return append(lines, footer...)
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prompts", func() {
+	var a *app
+
+	BeforeEach(func() {
+		a = &app{}
+	})
+
+	typeText := func(text string) {
+		for _, r := range text {
+			a.handle(keyEvent{key: keyRune, rune: r})
+		}
+	}
+
+	It("Edits the answer", func() {
+		answer := ""
+		a.ask("Replicas:", "3", func(input string) {
+			answer = input
+		})
+		a.handle(keyEvent{key: keyBackspace})
+		typeText("12")
+		a.handle(keyEvent{key: keyEnter})
+		Expect(answer).To(Equal("12"))
+		Expect(a.prompt).To(BeNil())
+	})
+
+	It("Runs confirmed actions", func() {
+		done := false
+		a.confirm("Hibernate cluster 'a'", func() {
+			done = true
+		})
+		Expect(a.prompt.question).To(Equal("Hibernate cluster 'a'? [y/N]"))
+		typeText("y")
+		a.handle(keyEvent{key: keyEnter})
+		Expect(done).To(BeTrue())
+	})
+
+	It("Doesn't run actions that aren't confirmed", func() {
+		done := false
+		a.confirm("Hibernate cluster 'a'", func() {
+			done = true
+		})
+		a.handle(keyEvent{key: keyEnter})
+		Expect(done).To(BeFalse())
+		Expect(a.status).To(Equal("Cancelled"))
+
+		a.confirm("Hibernate cluster 'a'", func() {
+			done = true
+		})
+		typeText("y")
+		a.handle(keyEvent{key: keyEscape})
+		Expect(done).To(BeFalse())
+		Expect(a.prompt).To(BeNil())
+	})
+})
@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Open an interactive dashboard",
+	Long: "Open a full screen dashboard to browse clusters, their machine pools, identity providers, " +
+		"ingresses, upgrades, add-ons and install logs, and to scale machine pools, hibernate clusters " +
+		"and schedule upgrades.",
+	Example: `  # Open the dashboard
+  rosa ui`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	t, err := openTerminal()
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	// Restores the terminal on panics too, it is also closed explicitly below as exiting skips
+	// deferred calls
+	defer t.close()
+
+	a := &app{
+		r:    r,
+		term: t,
+	}
+	a.run(a.clustersView())
+	t.close()
+
+	if len(a.views) == 0 {
+		r.Reporter.Errorf("%s", a.status)
+		os.Exit(1)
+	}
+}
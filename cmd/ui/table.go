@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Space between the columns of a table
+const columnGap = "  "
+
+// table is a list of rows that fits in the screen by scrolling. Rows can be selected, unless the
+// table is plain text like logs.
+type table struct {
+	headers []string
+	rows    [][]string
+	plain   bool
+	// Whether plain tables keep showing their last rows when they change
+	follow   bool
+	selected int
+	offset   int
+	// Number of rows shown by the last render, used to scroll by pages
+	visible int
+}
+
+func (t *table) setRows(rows [][]string) {
+	t.rows = rows
+	t.move(0)
+}
+
+// move moves the selection, or scrolls plain tables, by the given number of rows
+func (t *table) move(delta int) {
+	if t.plain {
+		last := clamp(len(t.rows)-t.visible, 0, len(t.rows))
+		if t.follow && delta == 0 {
+			t.offset = last
+		}
+		t.offset = clamp(t.offset+delta, 0, last)
+		t.follow = t.offset == last
+		return
+	}
+	t.selected = clamp(t.selected+delta, 0, len(t.rows)-1)
+	if t.selected < t.offset {
+		t.offset = t.selected
+	}
+	if t.visible > 0 && t.selected >= t.offset+t.visible {
+		t.offset = t.selected - t.visible + 1
+	}
+}
+
+// page returns the number of rows to move by to scroll a page
+func (t *table) page() int {
+	if t.visible > 1 {
+		return t.visible - 1
+	}
+	return 1
+}
+
+// render returns the lines of the table that fit in the given size
+func (t *table) render(width int, height int) []string {
+	lines := []string{}
+	if len(t.headers) > 0 {
+		height--
+	}
+	t.visible = height
+	t.move(0)
+
+	widths := t.columnWidths()
+	if len(t.headers) > 0 {
+		lines = append(lines, truncate(formatRow(t.headers, widths), width))
+	}
+	if len(t.rows) == 0 && !t.plain {
+		return append(lines, "No items")
+	}
+	for i := t.offset; i < len(t.rows) && i < t.offset+height; i++ {
+		line := truncate(formatRow(t.rows[i], widths), width)
+		if !t.plain && i == t.selected {
+			line = reverseVideo + line + strings.Repeat(" ", width-utf8.RuneCountInString(line)) + resetStyle
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (t *table) columnWidths() []int {
+	widths := make([]int, len(t.headers))
+	for _, row := range append([][]string{t.headers}, t.rows...) {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	return widths
+}
+
+func formatRow(row []string, widths []int) string {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		cells[i] = cell
+		if i < len(row)-1 {
+			cells[i] += strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		}
+	}
+	return strings.Join(cells, columnGap)
+}
+
+func truncate(line string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	return string([]rune(line)[:width])
+}
+
+func clamp(value int, min int, max int) int {
+	if value > max {
+		value = max
+	}
+	if value < min {
+		value = min
+	}
+	return value
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func buildRows(n int) [][]string {
+	rows := [][]string{}
+	for i := 0; i < n; i++ {
+		rows = append(rows, []string{fmt.Sprintf("row-%d", i)})
+	}
+	return rows
+}
+
+var _ = Describe("Table", func() {
+	It("Aligns columns", func() {
+		t := &table{headers: []string{"ID", "NAME"}}
+		t.setRows([][]string{{"abc", "x"}, {"a", "longer"}})
+		lines := t.render(80, 10)
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]).To(Equal("ID   NAME"))
+		Expect(lines[1]).To(Equal(reverseVideo + "abc  x" + fmt.Sprintf("%74s", "") + resetStyle))
+		Expect(lines[2]).To(Equal("a    longer"))
+	})
+
+	It("Truncates lines to the width", func() {
+		t := &table{headers: []string{"ID", "NAME"}, plain: true}
+		t.setRows([][]string{{"abc", "a long name"}})
+		Expect(t.render(8, 10)).To(Equal([]string{"ID   NAM", "abc  a l"}))
+	})
+
+	It("Shows when there are no rows", func() {
+		t := &table{headers: []string{"ID"}}
+		Expect(t.render(80, 10)).To(Equal([]string{"ID", "No items"}))
+	})
+
+	It("Scrolls to keep the selected row visible", func() {
+		t := &table{headers: []string{"ID"}}
+		t.setRows(buildRows(10))
+		t.render(80, 4)
+		t.move(5)
+		lines := t.render(80, 4)
+		Expect(lines).To(HaveLen(4))
+		Expect(lines[1]).To(Equal("row-3"))
+		Expect(lines[3]).To(ContainSubstring("row-5"))
+
+		t.move(-100)
+		Expect(t.selected).To(Equal(0))
+		Expect(t.render(80, 4)[1]).To(ContainSubstring("row-0"))
+
+		t.move(t.page())
+		Expect(t.selected).To(Equal(2))
+	})
+
+	It("Follows the end of plain tables until scrolled up", func() {
+		t := &table{plain: true, follow: true}
+		t.setRows(buildRows(10))
+		Expect(t.render(80, 3)).To(Equal([]string{"row-7", "row-8", "row-9"}))
+
+		t.setRows(buildRows(12))
+		Expect(t.render(80, 3)).To(Equal([]string{"row-9", "row-10", "row-11"}))
+
+		t.move(-2)
+		t.setRows(buildRows(14))
+		Expect(t.render(80, 3)).To(Equal([]string{"row-7", "row-8", "row-9"}))
+	})
+})
@@ -0,0 +1,163 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Escape sequences used to draw the screen
+const (
+	enterAlternateScreen = "\x1b[?1049h"
+	exitAlternateScreen  = "\x1b[?1049l"
+	hideCursor           = "\x1b[?25l"
+	showCursor           = "\x1b[?25h"
+	moveHome             = "\x1b[H"
+	clearLine            = "\x1b[K"
+	clearBelow           = "\x1b[J"
+	reverseVideo         = "\x1b[7m"
+	resetStyle           = "\x1b[0m"
+)
+
+type key int
+
+const (
+	keyUnknown key = iota
+	keyRune
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEscape
+	keyBackspace
+	keyCtrlC
+)
+
+type keyEvent struct {
+	key  key
+	rune rune
+}
+
+// parseKey translates the bytes read from a terminal in raw mode into a key
+func parseKey(data []byte) keyEvent {
+	switch string(data) {
+	case "\x1b[A", "\x1bOA":
+		return keyEvent{key: keyUp}
+	case "\x1b[B", "\x1bOB":
+		return keyEvent{key: keyDown}
+	case "\x1b[5~":
+		return keyEvent{key: keyPageUp}
+	case "\x1b[6~":
+		return keyEvent{key: keyPageDown}
+	case "\x1b[H", "\x1b[1~", "\x1bOH":
+		return keyEvent{key: keyHome}
+	case "\x1b[F", "\x1b[4~", "\x1bOF":
+		return keyEvent{key: keyEnd}
+	case "\r", "\n":
+		return keyEvent{key: keyEnter}
+	case "\x1b":
+		return keyEvent{key: keyEscape}
+	case "\x7f", "\b":
+		return keyEvent{key: keyBackspace}
+	case "\x03":
+		return keyEvent{key: keyCtrlC}
+	}
+	runes := []rune(string(data))
+	if len(runes) == 1 && runes[0] >= ' ' {
+		return keyEvent{key: keyRune, rune: runes[0]}
+	}
+	return keyEvent{key: keyUnknown}
+}
+
+// terminal draws full screen content on the terminal and reads keys from it, which requires the
+// terminal to be in raw mode until it is closed
+type terminal struct {
+	fd    int
+	state *term.State
+	out   *bufio.Writer
+	// Whether the terminal was already restored to its original state
+	closed bool
+}
+
+func openTerminal() (*terminal, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, fmt.Errorf("The interactive dashboard requires a terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to configure terminal: %v", err)
+	}
+	t := &terminal{
+		fd:    fd,
+		state: state,
+		out:   bufio.NewWriter(os.Stdout),
+	}
+	t.out.WriteString(enterAlternateScreen + hideCursor)
+	t.out.Flush()
+	return t, nil
+}
+
+// close restores the screen and the mode of the terminal as they were before opening it
+// close restores the terminal to its original state, it can be called more than once
+func (t *terminal) close() {
+	if t.closed {
+		return
+	}
+	t.closed = true
+	t.out.WriteString(showCursor + exitAlternateScreen)
+	t.out.Flush()
+	term.Restore(t.fd, t.state)
+}
+
+// size returns the width and height of the terminal
+func (t *terminal) size() (int, int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// readKeys sends the keys pressed to the channel until reading from the terminal fails
+func (t *terminal) readKeys(keys chan<- keyEvent) {
+	buffer := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buffer)
+		if err != nil {
+			close(keys)
+			return
+		}
+		keys <- parseKey(buffer[:n])
+	}
+}
+
+// draw replaces the content of the screen with the lines
+func (t *terminal) draw(lines []string) {
+	t.out.WriteString(moveHome)
+	t.out.WriteString(strings.Join(lines, clearLine+"\r\n"))
+	t.out.WriteString(clearLine + clearBelow)
+	t.out.Flush()
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Keys", func() {
+	DescribeTable("Parses keys",
+		func(data string, expected keyEvent) {
+			Expect(parseKey([]byte(data))).To(Equal(expected))
+		},
+		Entry("up", "\x1b[A", keyEvent{key: keyUp}),
+		Entry("up in application mode", "\x1bOA", keyEvent{key: keyUp}),
+		Entry("down", "\x1b[B", keyEvent{key: keyDown}),
+		Entry("page down", "\x1b[6~", keyEvent{key: keyPageDown}),
+		Entry("enter", "\r", keyEvent{key: keyEnter}),
+		Entry("escape", "\x1b", keyEvent{key: keyEscape}),
+		Entry("backspace", "\x7f", keyEvent{key: keyBackspace}),
+		Entry("ctrl-c", "\x03", keyEvent{key: keyCtrlC}),
+		Entry("letter", "q", keyEvent{key: keyRune, rune: 'q'}),
+		Entry("unicode letter", "é", keyEvent{key: keyRune, rune: 'é'}),
+		Entry("unknown sequence", "\x1b[15~", keyEvent{key: keyUnknown}),
+	)
+})
@@ -0,0 +1,13 @@
+package ui
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestUI(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "UI Suite")
+}
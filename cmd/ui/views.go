@@ -0,0 +1,405 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// How often logs of clusters being installed are fetched
+const logsRefresh = 5 * time.Second
+
+// Number of lines of logs shown
+const logsTail = 1000
+
+// Sections of a cluster, in the order they are shown
+const (
+	sectionMachinePools = "Machine pools"
+	sectionIDPs         = "Identity providers"
+	sectionIngresses    = "Ingresses"
+	sectionUpgrades     = "Upgrades"
+	sectionAddOns       = "Add-ons"
+	sectionInstallLogs  = "Install logs"
+)
+
+var sections = []string{
+	sectionMachinePools,
+	sectionIDPs,
+	sectionIngresses,
+	sectionUpgrades,
+	sectionAddOns,
+	sectionInstallLogs,
+}
+
+func (a *app) clustersView() *view {
+	var clusters []*cmv1.Cluster
+	v := &view{
+		title: "Clusters",
+		help:  "h hibernate",
+		table: &table{
+			headers: []string{"ID", "NAME", "STATE", "TOPOLOGY", "VERSION"},
+		},
+	}
+	v.load = func() error {
+		var err error
+		clusters, err = a.r.OCMClient.GetClusters(a.r.Creator, 1000)
+		if err != nil {
+			return fmt.Errorf("Failed to get clusters: %v", err)
+		}
+		rows := [][]string{}
+		for _, cluster := range clusters {
+			rows = append(rows, []string{
+				cluster.ID(),
+				cluster.Name(),
+				string(cluster.State()),
+				topology(cluster),
+				cluster.Version().RawID(),
+			})
+		}
+		v.table.setRows(rows)
+		return nil
+	}
+	v.open = func(row int) *view {
+		return a.clusterView(clusters[row])
+	}
+	v.actions = map[rune]func(int){
+		'h': func(row int) {
+			a.hibernateCluster(clusters[row])
+		},
+	}
+	return v
+}
+
+func (a *app) clusterView(cluster *cmv1.Cluster) *view {
+	v := &view{
+		title: cluster.Name(),
+		help:  "h hibernate  u upgrade",
+		table: &table{
+			headers: []string{"SECTION", "DETAILS"},
+		},
+	}
+	v.load = func() error {
+		var err error
+		cluster, err = a.r.OCMClient.GetCluster(cluster.ID(), a.r.Creator)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster '%s': %v", cluster.Name(), err)
+		}
+		v.title = fmt.Sprintf("%s (%s, %s)", cluster.Name(), cluster.State(), cluster.Version().RawID())
+		rows := [][]string{}
+		for _, section := range sections {
+			rows = append(rows, []string{section, sectionDetails(cluster, section)})
+		}
+		v.table.setRows(rows)
+		return nil
+	}
+	v.open = func(row int) *view {
+		switch sections[row] {
+		case sectionMachinePools:
+			return a.machinePoolsView(cluster)
+		case sectionIDPs:
+			return a.idpsView(cluster)
+		case sectionIngresses:
+			return a.ingressesView(cluster)
+		case sectionUpgrades:
+			return a.upgradesView(cluster)
+		case sectionAddOns:
+			return a.addOnsView(cluster)
+		case sectionInstallLogs:
+			return a.installLogsView(cluster)
+		}
+		return nil
+	}
+	v.actions = map[rune]func(int){
+		'h': func(int) {
+			a.hibernateCluster(cluster)
+		},
+		'u': func(int) {
+			a.upgradeCluster(cluster)
+		},
+	}
+	return v
+}
+
+func sectionDetails(cluster *cmv1.Cluster, section string) string {
+	switch section {
+	case sectionMachinePools:
+		if cluster.Hypershift().Enabled() {
+			return "Node pools of the hosted cluster"
+		}
+		return fmt.Sprintf("%d compute nodes in the default machine pool", cluster.Nodes().Compute())
+	case sectionIngresses:
+		return cluster.Console().URL()
+	case sectionInstallLogs:
+		if cluster.State() != cmv1.ClusterStateReady {
+			return fmt.Sprintf("Refreshed every %s while the cluster isn't ready", logsRefresh)
+		}
+	}
+	return ""
+}
+
+func (a *app) machinePoolsView(cluster *cmv1.Cluster) *view {
+	v := &view{
+		title: sectionMachinePools,
+		help:  "s scale",
+		table: &table{
+			headers: []string{"ID", "AUTOSCALING", "REPLICAS", "INSTANCE TYPE", "AVAILABILITY ZONES"},
+		},
+	}
+	var ids []string
+	var autoscaling []bool
+	var replicas []int
+	var multiAZ []bool
+	v.load = func() error {
+		ids, autoscaling, replicas, multiAZ = nil, nil, nil, nil
+		rows := [][]string{}
+		add := func(id string, scaling bool, count string, instanceType string, zones []string) {
+			ids = append(ids, id)
+			autoscaling = append(autoscaling, scaling)
+			multiAZ = append(multiAZ, len(zones) != 1)
+			rows = append(rows, []string{id, yesNo(scaling), count, instanceType, strings.Join(zones, ", ")})
+		}
+		if cluster.Hypershift().Enabled() {
+			nodePools, err := a.r.OCMClient.GetNodePools(cluster.ID())
+			if err != nil {
+				return fmt.Errorf("Failed to get machine pools for cluster '%s': %v", cluster.Name(), err)
+			}
+			for _, nodePool := range nodePools {
+				count := fmt.Sprintf("%d", nodePool.Replicas())
+				if nodePool.Autoscaling() != nil {
+					count = fmt.Sprintf("%d-%d", nodePool.Autoscaling().MinReplica(), nodePool.Autoscaling().MaxReplica())
+				}
+				replicas = append(replicas, nodePool.Replicas())
+				add(nodePool.ID(), nodePool.Autoscaling() != nil, count, nodePool.AWSNodePool().InstanceType(),
+					[]string{nodePool.AvailabilityZone()})
+			}
+			v.table.setRows(rows)
+			return nil
+		}
+		machinePools, err := a.r.OCMClient.GetMachinePools(cluster.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to get machine pools for cluster '%s': %v", cluster.Name(), err)
+		}
+		nodes := cluster.Nodes()
+		count := fmt.Sprintf("%d", nodes.Compute())
+		if nodes.AutoscaleCompute() != nil {
+			count = fmt.Sprintf("%d-%d", nodes.AutoscaleCompute().MinReplicas(), nodes.AutoscaleCompute().MaxReplicas())
+		}
+		replicas = append(replicas, nodes.Compute())
+		add(defaultMachinePool, nodes.AutoscaleCompute() != nil, count, nodes.ComputeMachineType().ID(),
+			nodes.AvailabilityZones())
+		for _, machinePool := range machinePools {
+			count := fmt.Sprintf("%d", machinePool.Replicas())
+			if machinePool.Autoscaling() != nil {
+				count = fmt.Sprintf("%d-%d", machinePool.Autoscaling().MinReplicas(),
+					machinePool.Autoscaling().MaxReplicas())
+			}
+			replicas = append(replicas, machinePool.Replicas())
+			add(machinePool.ID(), machinePool.Autoscaling() != nil, count, machinePool.InstanceType(),
+				machinePool.AvailabilityZones())
+		}
+		v.table.setRows(rows)
+		return nil
+	}
+	v.actions = map[rune]func(int){
+		's': func(row int) {
+			if autoscaling[row] {
+				a.status = fmt.Sprintf("Machine pool '%s' is autoscaled, use 'rosa edit machinepool' to change "+
+					"its limits", ids[row])
+				return
+			}
+			a.scaleMachinePool(cluster, ids[row], replicas[row], multiAZ[row])
+		},
+	}
+	return v
+}
+
+func (a *app) idpsView(cluster *cmv1.Cluster) *view {
+	v := &view{
+		title: sectionIDPs,
+		table: &table{
+			headers: []string{"NAME", "TYPE", "AUTH URL"},
+		},
+	}
+	v.load = func() error {
+		idps, err := a.r.OCMClient.GetIdentityProviders(cluster.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to get identity providers for cluster '%s': %v", cluster.Name(), err)
+		}
+		rows := [][]string{}
+		for _, idp := range idps {
+			// Clusters that aren't ready yet have no OAuth URL
+			oauthURL, _ := ocm.GetOAuthURL(cluster, idp)
+			rows = append(rows, []string{idp.Name(), ocm.IdentityProviderType(idp), oauthURL})
+		}
+		v.table.setRows(rows)
+		return nil
+	}
+	return v
+}
+
+func (a *app) ingressesView(cluster *cmv1.Cluster) *view {
+	v := &view{
+		title: sectionIngresses,
+		table: &table{
+			headers: []string{"ID", "APPLICATION ROUTER", "PRIVATE", "DEFAULT"},
+		},
+	}
+	v.load = func() error {
+		ingresses, err := a.r.OCMClient.GetIngresses(cluster.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to get ingresses for cluster '%s': %v", cluster.Name(), err)
+		}
+		rows := [][]string{}
+		for _, ingress := range ingresses {
+			rows = append(rows, []string{
+				ingress.ID(),
+				"https://" + ingress.DNSName(),
+				yesNo(ingress.Listening() == cmv1.ListeningMethodInternal),
+				yesNo(ingress.Default()),
+			})
+		}
+		v.table.setRows(rows)
+		return nil
+	}
+	return v
+}
+
+func (a *app) upgradesView(cluster *cmv1.Cluster) *view {
+	v := &view{
+		title: sectionUpgrades,
+		help:  "u upgrade",
+		table: &table{
+			headers: []string{"ID", "SCHEDULE TYPE", "VERSION", "STATE", "NEXT RUN"},
+		},
+	}
+	v.load = func() error {
+		rows := [][]string{}
+		if cluster.Hypershift().Enabled() {
+			policies, err := a.r.OCMClient.GetControlPlaneUpgradePolicies(cluster.ID())
+			if err != nil {
+				return fmt.Errorf("Failed to get upgrades for cluster '%s': %v", cluster.Name(), err)
+			}
+			for _, policy := range policies {
+				rows = append(rows, []string{
+					policy.ID(),
+					policy.ScheduleType(),
+					policy.Version(),
+					string(policy.State().Value()),
+					policy.NextRun().Format("2006-01-02 15:04 MST"),
+				})
+			}
+			v.table.setRows(rows)
+			return nil
+		}
+		policies, err := a.r.OCMClient.GetUpgradePolicies(cluster.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to get upgrades for cluster '%s': %v", cluster.Name(), err)
+		}
+		_, state, err := a.r.OCMClient.GetScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to get upgrades for cluster '%s': %v", cluster.Name(), err)
+		}
+		for _, policy := range policies {
+			rows = append(rows, []string{
+				policy.ID(),
+				policy.ScheduleType(),
+				policy.Version(),
+				string(state.Value()),
+				policy.NextRun().Format("2006-01-02 15:04 MST"),
+			})
+		}
+		v.table.setRows(rows)
+		return nil
+	}
+	v.actions = map[rune]func(int){
+		'u': func(int) {
+			a.upgradeCluster(cluster)
+		},
+	}
+	return v
+}
+
+func (a *app) addOnsView(cluster *cmv1.Cluster) *view {
+	v := &view{
+		title: sectionAddOns,
+		table: &table{
+			headers: []string{"ID", "NAME", "STATE"},
+		},
+	}
+	v.load = func() error {
+		addOns, err := a.r.OCMClient.GetClusterAddOns(cluster)
+		if err != nil {
+			return fmt.Errorf("Failed to get add-ons for cluster '%s': %v", cluster.Name(), err)
+		}
+		rows := [][]string{}
+		for _, addOn := range addOns {
+			rows = append(rows, []string{addOn.ID, addOn.Name, addOn.State})
+		}
+		v.table.setRows(rows)
+		return nil
+	}
+	return v
+}
+
+func (a *app) installLogsView(cluster *cmv1.Cluster) *view {
+	v := &view{
+		title: sectionInstallLogs,
+		table: &table{
+			plain:  true,
+			follow: true,
+		},
+	}
+	if cluster.State() != cmv1.ClusterStateReady {
+		v.refresh = logsRefresh
+	}
+	v.load = func() error {
+		logs, err := a.r.OCMClient.GetInstallLogs(cluster.ID(), logsTail)
+		if err != nil {
+			return fmt.Errorf("Failed to get install logs for cluster '%s': %v", cluster.Name(), err)
+		}
+		rows := [][]string{}
+		for _, line := range strings.Split(strings.TrimRight(logs.Content(), "\n"), "\n") {
+			rows = append(rows, []string{line})
+		}
+		v.table.setRows(rows)
+		return nil
+	}
+	return v
+}
+
+func topology(cluster *cmv1.Cluster) string {
+	if cluster.Hypershift().Enabled() {
+		return "Hosted CP"
+	}
+	if cluster.AWS().STS().Enabled() {
+		return "Classic (STS)"
+	}
+	return "Classic"
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
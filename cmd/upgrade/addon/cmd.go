@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"fmt"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	version string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "addon ID",
+	Aliases: []string{"addons", "add-on", "add-ons"},
+	Short:   "Upgrade add-on installation",
+	Long:    "Upgrade a Red Hat managed add-on installed on a cluster to one of its available versions",
+	Example: `  # Upgrade the CodeReady Workspaces add-on installation to version 1.2.0
+  rosa upgrade addon --cluster=mycluster codeready-workspaces --version=1.2.0`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf("Expected exactly one command line parameter containing the id of the add-on")
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Version of the add-on that the installation will be upgraded to",
+	)
+
+	confirm.AddFlag(flags)
+	ocm.AddClusterFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	addOnID := argv[0]
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	installation, _ := r.OCMClient.GetAddOnInstallation(cluster.ID(), addOnID)
+	if installation == nil {
+		r.Reporter.Errorf("Addon '%s' is not installed on cluster '%s'", addOnID, clusterKey)
+		os.Exit(1)
+	}
+
+	currentVersion := installation.AddonVersion().ID()
+	availableUpgrades := installation.AddonVersion().AvailableUpgrades()
+	if len(availableUpgrades) == 0 {
+		r.Reporter.Infof("Add-on '%s' is already running the latest version '%s'", addOnID, currentVersion)
+		return
+	}
+
+	version := args.version
+	var err error
+	if version == "" || interactive.Enabled() {
+		version, err = interactive.GetOption(interactive.Input{
+			Question: "Version",
+			Help:     cmd.Flags().Lookup("version").Usage,
+			Options:  availableUpgrades,
+			Default:  version,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid version: %s", err)
+			os.Exit(1)
+		}
+	}
+	if !helper.Contains(availableUpgrades, version) {
+		r.Reporter.Errorf("Expected a valid version for add-on '%s'. Available upgrades from version '%s' are %v",
+			addOnID, currentVersion, availableUpgrades)
+		os.Exit(1)
+	}
+
+	if !confirm.Confirm("upgrade add-on '%s' on cluster '%s' from version '%s' to '%s'",
+		addOnID, clusterKey, currentVersion, version) {
+		os.Exit(0)
+	}
+
+	r.Reporter.Debugf("Upgrading add-on '%s' on cluster '%s' to version '%s'", addOnID, clusterKey, version)
+	err = r.OCMClient.UpgradeAddOnInstallation(cluster.ID(), addOnID, version)
+	if err != nil {
+		r.Reporter.Errorf("Failed to upgrade add-on '%s' on cluster '%s': %v", addOnID, clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Add-on '%s' is now upgrading to version '%s'. To check the status run "+
+		"'rosa describe addon-installation -c %s --addon %s'", addOnID, version, clusterKey, addOnID)
+}
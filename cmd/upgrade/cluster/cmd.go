@@ -28,9 +28,11 @@ import (
 
 	"github.com/openshift/rosa/cmd/upgrade/roles"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper/upgrades"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -40,6 +42,8 @@ var args struct {
 	scheduleTime         string
 	nodeDrainGracePeriod string
 	controlPlane         bool
+	preflight            bool
+	schedule             string
 }
 
 var nodeDrainOptions = []string{
@@ -60,7 +64,13 @@ var Cmd = &cobra.Command{
   rosa upgrade cluster --cluster=mycluster --interactive
 
   # Schedule a cluster upgrade within the hour
-  rosa upgrade cluster -c mycluster --version 4.5.20`,
+  rosa upgrade cluster -c mycluster --version 4.5.20
+
+  # Schedule recurring automatic upgrades every Sunday at 2am UTC
+  rosa upgrade cluster -c mycluster --schedule "0 2 * * SUN"
+
+  # Check whether the cluster can be upgraded without scheduling the upgrade
+  rosa upgrade cluster -c mycluster --version 4.5.20 --preflight`,
 	Run: run,
 }
 
@@ -102,6 +112,14 @@ func init() {
 			"options are ['%s']", strings.Join(nodeDrainOptions, "','")),
 	)
 
+	flags.StringVar(
+		&args.schedule,
+		"schedule",
+		"",
+		"Cron expression in UTC of the recurring automatic upgrades of the cluster, for example '0 2 * * SUN'. "+
+			"Automatic upgrades use the latest available patch version at the time they run",
+	)
+
 	flags.BoolVar(
 		&args.controlPlane,
 		"control-plane",
@@ -109,6 +127,14 @@ func init() {
 		"For Hosted Control Plane, whether the upgrade should cover only the control plane",
 	)
 
+	flags.BoolVar(
+		&args.preflight,
+		"preflight",
+		false,
+		"Check whether the cluster is ready to be upgraded to the version without scheduling the upgrade",
+	)
+
+	output.AddFlag(Cmd)
 	confirm.AddFlag(flags)
 }
 
@@ -122,6 +148,25 @@ func run(cmd *cobra.Command, _ []string) {
 	scheduleTime := args.scheduleTime
 	isHypershift := cluster.Hypershift().Enabled()
 
+	if output.HasFlag() && !args.preflight {
+		r.Reporter.Errorf("The '--output' option is only supported together with '--preflight'")
+		os.Exit(1)
+	}
+
+	if args.schedule != "" {
+		for _, flag := range []string{"version", "schedule-date", "schedule-time", "preflight"} {
+			if cmd.Flags().Changed(flag) {
+				r.Reporter.Errorf("The '--%s' option can't be used together with '--schedule'", flag)
+				os.Exit(1)
+			}
+		}
+		err := upgrades.ValidateSchedule(args.schedule)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+	}
+
 	mode, err := aws.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
@@ -149,6 +194,18 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if args.preflight {
+		runPreflight(r, cluster, clusterKey)
+		return
+	}
+
+	if args.schedule != "" {
+		scheduleAutomaticUpgrades(r, cluster, clusterKey)
+		updateNodeDrainGracePeriod(r, cmd, cluster, clusterKey)
+		r.Reporter.Infof("Automatic upgrades successfully scheduled for cluster '%s'", clusterKey)
+		return
+	}
+
 	checkExistingScheduledUpgrade(r, cluster, clusterKey)
 
 	availableUpgrades, version := buildVersion(r, cmd, cluster, args.version)
@@ -190,15 +247,69 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	// Drain grace period config
+	updateNodeDrainGracePeriod(r, cmd, cluster, clusterKey)
+
+	r.Reporter.Infof("Upgrade successfully scheduled for cluster '%s'", clusterKey)
+}
+
+// scheduleAutomaticUpgrades creates a recurring upgrade policy that upgrades the cluster, or the
+// control plane of Hosted Control Planes, to the latest patch version on the schedule
+func scheduleAutomaticUpgrades(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string) {
+	if cluster.Hypershift().Enabled() {
+		scheduledUpgrade, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		if scheduledUpgrade != nil {
+			r.Reporter.Warnf("There is already a %s upgrade policy for cluster '%s'. "+
+				"Run 'rosa edit upgrade -c %s' to change its schedule",
+				scheduledUpgrade.ScheduleType(), clusterKey, clusterKey)
+			os.Exit(0)
+		}
+	} else {
+		checkExistingScheduledUpgrade(r, cluster, clusterKey)
+	}
+
+	if !confirm.Confirm("schedule automatic upgrades for cluster '%s' with schedule '%s'", clusterKey,
+		args.schedule) {
+		os.Exit(0)
+	}
+
+	var err error
+	if cluster.Hypershift().Enabled() {
+		var upgradePolicy *cmv1.ControlPlaneUpgradePolicy
+		upgradePolicy, err = cmv1.NewControlPlaneUpgradePolicy().
+			ScheduleType(upgrades.ScheduleTypeAutomatic).
+			UpgradeType("ControlPlane").
+			Schedule(args.schedule).
+			Build()
+		if err == nil {
+			err = r.OCMClient.ScheduleHypershiftControlPlaneUpgrade(cluster.ID(), upgradePolicy)
+		}
+	} else {
+		var upgradePolicy *cmv1.UpgradePolicy
+		upgradePolicy, err = cmv1.NewUpgradePolicy().
+			ScheduleType(upgrades.ScheduleTypeAutomatic).
+			Schedule(args.schedule).
+			Build()
+		if err == nil {
+			err = r.OCMClient.ScheduleUpgrade(cluster.ID(), upgradePolicy)
+		}
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to schedule automatic upgrades for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+}
+
+func updateNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster, clusterKey string) {
 	clusterSpec := buildNodeDrainGracePeriod(r, cmd, cluster)
-	err = r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, clusterSpec)
+	err := r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, clusterSpec)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
 	}
-
-	r.Reporter.Infof("Upgrade successfully scheduled for cluster '%s'", clusterKey)
 }
 
 func createUpgradePolicyHypershift(r *rosa.Runtime, cmd *cobra.Command, clusterKey string,
@@ -0,0 +1,362 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	semver "github.com/hashicorp/go-version"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper/roles"
+	"github.com/openshift/rosa/pkg/helper/verify"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// Number of minor versions that node pools can be behind the hosted control plane
+const maxNodePoolVersionSkew = 2
+
+// runPreflight checks everything that would block or complicate the upgrade of the cluster to the
+// version, without changing anything, and exits with an error when there are blockers
+func runPreflight(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string) {
+	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+	if err != nil {
+		r.Reporter.Errorf("Failed to find available upgrades: %v", err)
+		os.Exit(1)
+	}
+	if len(availableUpgrades) == 0 {
+		r.Reporter.Infof("There are no available upgrades for cluster '%s'", clusterKey)
+		os.Exit(0)
+	}
+	version := args.version
+	if version == "" {
+		version = availableUpgrades[0]
+	}
+
+	if !output.HasFlag() && r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Running pre-flight checks for the upgrade of cluster '%s' to version '%s'...",
+			clusterKey, version)
+	}
+
+	version, checks := preflightChecks(r, cluster, availableUpgrades, version)
+	if output.HasFlag() {
+		err = output.Print(preflightReport(cluster, version, checks))
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	} else {
+		verify.PrintReport(checks)
+	}
+	if verify.HasFailures(checks) {
+		os.Exit(1)
+	}
+}
+
+// preflightChecks runs the checks for the upgrade to the version, and returns them together with
+// the version in the format used by the service
+func preflightChecks(r *rosa.Runtime, cluster *cmv1.Cluster, availableUpgrades []string,
+	version string) (string, []verify.Check) {
+	checks := []verify.Check{}
+	err := r.OCMClient.CheckUpgradeClusterVersion(availableUpgrades, version, cluster)
+	if err != nil {
+		checks = append(checks, verify.Fail("Version", "%v", strings.ReplaceAll(err.Error(), "\n", " ")))
+	} else {
+		version, err = ocm.CheckAndParseVersion(availableUpgrades, version)
+		if err != nil {
+			checks = append(checks, verify.Fail("Version", "%v", err))
+		} else {
+			checks = append(checks, verify.Pass("Version", "'%s' is an available upgrade", version))
+			checks = append(checks, preflightScheduledUpgrade(r, cluster))
+			checks = append(checks, preflightGates(r, cluster, version))
+			if _, isSTS := cluster.AWS().STS().GetRoleARN(); isSTS {
+				checks = append(checks, PreflightRoles(r, cluster, version)...)
+			}
+			checks = append(checks, preflightMachinePools(r, cluster, version))
+			checks = append(checks, preflightAddOns(r, cluster, version)...)
+		}
+	}
+	return version, append(checks, preflightLimitedSupport(r, cluster)...)
+}
+
+// preflightReport returns the report printed with the output flag
+func preflightReport(cluster *cmv1.Cluster, version string, checks []verify.Check) map[string]interface{} {
+	return map[string]interface{}{
+		"cluster": cluster.ID(),
+		"version": version,
+		"checks":  checks,
+	}
+}
+
+func preflightScheduledUpgrade(r *rosa.Runtime, cluster *cmv1.Cluster) verify.Check {
+	name := "Scheduled upgrade"
+	if cluster.Hypershift().Enabled() {
+		scheduledUpgrade, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return verify.Fail(name, "Failed to get scheduled upgrades: %v", err)
+		}
+		if scheduledUpgrade != nil {
+			return verify.Fail(name, "There is already a %s upgrade to version %s on %s",
+				scheduledUpgrade.State().Value(), scheduledUpgrade.Version(),
+				scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST"))
+		}
+		return verify.Pass(name, "No upgrade is scheduled")
+	}
+	scheduledUpgrade, upgradeState, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
+	if err != nil {
+		return verify.Fail(name, "Failed to get scheduled upgrades: %v", err)
+	}
+	if scheduledUpgrade != nil {
+		return verify.Fail(name, "There is already a %s upgrade to version %s on %s",
+			upgradeState.Value(), scheduledUpgrade.Version(),
+			scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST"))
+	}
+	return verify.Pass(name, "No upgrade is scheduled")
+}
+
+func preflightGates(r *rosa.Runtime, cluster *cmv1.Cluster, version string) verify.Check {
+	name := "Version gates"
+	var gates []*cmv1.VersionGate
+	if cluster.Hypershift().Enabled() {
+		upgradePolicy, err := cmv1.NewControlPlaneUpgradePolicy().ScheduleType("manual").
+			UpgradeType("ControlPlane").Version(version).NextRun(time.Now().UTC().Add(10 * time.Minute)).Build()
+		if err != nil {
+			return verify.Fail(name, "%v", err)
+		}
+		gates, err = r.OCMClient.GetMissingGateAgreementsHypershift(cluster.ID(), upgradePolicy)
+		if err != nil {
+			return verify.Fail(name, "Failed to check for missing gate agreements: %v", err)
+		}
+	} else {
+		upgradePolicy, err := cmv1.NewUpgradePolicy().ScheduleType("manual").Version(version).Build()
+		if err != nil {
+			return verify.Fail(name, "%v", err)
+		}
+		gates, err = r.OCMClient.GetMissingGateAgreementsClassic(cluster.ID(), upgradePolicy)
+		if err != nil {
+			return verify.Fail(name, "Failed to check for missing gate agreements: %v", err)
+		}
+	}
+
+	// Gates that only apply to STS are acknowledged automatically when the upgrade is scheduled
+	missing := []string{}
+	for _, gate := range gates {
+		if !gate.STSOnly() {
+			missing = append(missing, fmt.Sprintf("%s (%s)", gate.Description(), gate.DocumentationURL()))
+		}
+	}
+	if len(missing) > 0 {
+		return verify.Fail(name, "Missing acknowledgements, run 'rosa upgrade cluster' to review them: %s",
+			strings.Join(missing, "; "))
+	}
+	return verify.Pass(name, "No acknowledgements are missing")
+}
+
+// PreflightRoles checks that the operator roles and role policies of an STS cluster are ready for
+// the given version, without changing them
+func PreflightRoles(r *rosa.Runtime, cluster *cmv1.Cluster, version string) []verify.Check {
+	checks := []verify.Check{}
+	upgradeRoles := fmt.Sprintf("Run 'rosa upgrade roles -c %s --cluster-version=%s'", cluster.ID(), version)
+
+	missingRoles, err := r.OCMClient.FindMissingOperatorRolesForUpgrade(cluster, version)
+	if err != nil {
+		checks = append(checks, verify.Fail("Operator roles", "Failed to find missing operator roles: %v", err))
+	} else if len(missingRoles) > 0 {
+		names := []string{}
+		for _, operator := range missingRoles {
+			names = append(names, roles.GetOperatorRoleName(cluster, operator))
+		}
+		sort.Strings(names)
+		checks = append(checks, verify.Fail("Operator roles", "Missing operator roles %s. %s",
+			strings.Join(names, ", "), upgradeRoles))
+	} else {
+		checks = append(checks, verify.Pass("Operator roles", "All operator roles needed by '%s' exist", version))
+	}
+
+	name := "Role policies"
+	if cluster.AWS().STS().ManagedPolicies() {
+		return append(checks, verify.Pass(name, "The roles use managed policies"))
+	}
+	policyVersion, err := r.OCMClient.GetPolicyVersion("", cluster.Version().ChannelGroup())
+	if err != nil {
+		return append(checks, verify.Fail(name, "Failed to get the policy version: %v", err))
+	}
+	accountUpgradeNeeded, err := r.AWSClient.IsUpgradedNeededForAccountRolePoliciesUsingCluster(cluster,
+		policyVersion)
+	if err != nil {
+		return append(checks, verify.Fail(name, "Failed to check account role policies: %v", err))
+	}
+	credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
+	if err != nil {
+		return append(checks, verify.Fail(name, "Failed to get operator credential requests: %v", err))
+	}
+	prefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, r.AWSClient)
+	if err != nil {
+		return append(checks, verify.Fail(name, "Failed to get operator role policy prefix: %v", err))
+	}
+	operatorUpgradeNeeded, err := r.AWSClient.IsUpgradedNeededForOperatorRolePoliciesUsingCluster(cluster,
+		r.Creator.AccountID, policyVersion, credRequests, prefix)
+	if err != nil {
+		return append(checks, verify.Fail(name, "Failed to check operator role policies: %v", err))
+	}
+	outdated := []string{}
+	if accountUpgradeNeeded {
+		outdated = append(outdated, "account role")
+	}
+	if operatorUpgradeNeeded {
+		outdated = append(outdated, "operator role")
+	}
+	if len(outdated) > 0 {
+		return append(checks, verify.Fail(name, "The %s policies need to be upgraded to version '%s'. %s",
+			strings.Join(outdated, " and "), policyVersion, upgradeRoles))
+	}
+	return append(checks, verify.Pass(name, "Role policies are compatible with version '%s'", policyVersion))
+}
+
+func preflightMachinePools(r *rosa.Runtime, cluster *cmv1.Cluster, version string) verify.Check {
+	name := "Machine pools"
+	// Machine pools of classic clusters are upgraded together with the cluster
+	if !cluster.Hypershift().Enabled() {
+		return verify.Pass(name, "Machine pools are upgraded together with the cluster")
+	}
+	targetMinor, err := semver.NewVersion(ocm.GetVersionMinor(version))
+	if err != nil {
+		return verify.Fail(name, "Invalid version '%s': %v", version, err)
+	}
+	nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+	if err != nil {
+		return verify.Fail(name, "Failed to get machine pools: %v", err)
+	}
+	skewed := []string{}
+	behind := []string{}
+	for _, nodePool := range nodePools {
+		nodePoolVersion := nodePool.Version().RawID()
+		if nodePoolVersion == "" {
+			nodePoolVersion = nodePool.Version().ID()
+		}
+		minor, err := semver.NewVersion(ocm.GetVersionMinor(nodePoolVersion))
+		if err != nil {
+			return verify.Fail(name, "Invalid version '%s' of machine pool '%s': %v",
+				nodePoolVersion, nodePool.ID(), err)
+		}
+		skew := targetMinor.Segments()[1] - minor.Segments()[1]
+		if targetMinor.Segments()[0] != minor.Segments()[0] || skew > maxNodePoolVersionSkew {
+			skewed = append(skewed, fmt.Sprintf("%s (%s)", nodePool.ID(), nodePoolVersion))
+		} else if skew > 0 {
+			behind = append(behind, fmt.Sprintf("%s (%s)", nodePool.ID(), nodePoolVersion))
+		}
+	}
+	if len(skewed) > 0 {
+		return verify.Fail(name, "Machine pools would be more than %d minor versions behind the control "+
+			"plane, upgrade them first: %s", maxNodePoolVersionSkew, strings.Join(skewed, ", "))
+	}
+	if len(behind) > 0 {
+		return verify.Warn(name, "Machine pools will be behind the control plane until they are upgraded: %s",
+			strings.Join(behind, ", "))
+	}
+	return verify.Pass(name, "Machine pool versions are within the supported skew")
+}
+
+func preflightAddOns(r *rosa.Runtime, cluster *cmv1.Cluster, version string) []verify.Check {
+	name := "Add-ons"
+	clusterAddOns, err := r.OCMClient.GetClusterAddOns(cluster)
+	if err != nil {
+		return []verify.Check{verify.Fail(name, "Failed to get add-ons: %v", err)}
+	}
+
+	// Requirements on the cluster are checked against the attributes it will have after the upgrade
+	upgradedCluster, err := cmv1.NewCluster().Copy(cluster).
+		OpenshiftVersion(version).
+		Version(cmv1.NewVersion().Copy(cluster.Version()).RawID(version)).
+		Build()
+	if err != nil {
+		return []verify.Check{verify.Fail(name, "%v", err)}
+	}
+
+	checks := []verify.Check{}
+	for _, clusterAddOn := range clusterAddOns {
+		if clusterAddOn.State == "not installed" || clusterAddOn.State == "unavailable" {
+			continue
+		}
+		addOnName := fmt.Sprintf("Add-on %s", clusterAddOn.ID)
+		addOn, err := r.OCMClient.GetAddOn(clusterAddOn.ID)
+		if err != nil {
+			checks = append(checks, verify.Fail(addOnName, "Failed to get add-on: %v", err))
+			continue
+		}
+		// The status evaluated by the service is for the current version of the cluster
+		requirements := []*cmv1.AddOnRequirementBuilder{}
+		for _, requirement := range addOn.Requirements() {
+			if requirement.Resource() == ocm.AddOnRequirementCluster {
+				requirements = append(requirements, cmv1.NewAddOnRequirement().ID(requirement.ID()).
+					Resource(requirement.Resource()).Enabled(requirement.Enabled()).Data(requirement.Data()))
+			}
+		}
+		if len(requirements) == 0 {
+			continue
+		}
+		addOn, err = cmv1.NewAddOn().ID(addOn.ID()).Requirements(requirements...).Build()
+		if err != nil {
+			checks = append(checks, verify.Fail(addOnName, "%v", err))
+			continue
+		}
+		results, err := ocm.CheckAddOnRequirements(addOn, upgradedCluster, nil, nil)
+		if err != nil {
+			checks = append(checks, verify.Fail(addOnName, "Failed to check requirements: %v", err))
+			continue
+		}
+		reasons := []string{}
+		for _, result := range results {
+			if !result.Fulfilled {
+				reasons = append(reasons, result.Reasons...)
+			}
+		}
+		if len(reasons) > 0 {
+			checks = append(checks, verify.Fail(addOnName, "Not compatible with version '%s': %s",
+				version, strings.Join(reasons, "; ")))
+		} else {
+			checks = append(checks, verify.Pass(addOnName, "Compatible with version '%s'", version))
+		}
+	}
+	if len(checks) == 0 {
+		checks = append(checks, verify.Pass(name, "No installed add-on has requirements on the cluster version"))
+	}
+	return checks
+}
+
+func preflightLimitedSupport(r *rosa.Runtime, cluster *cmv1.Cluster) []verify.Check {
+	name := "Limited support"
+	reasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		return []verify.Check{verify.Fail(name, "Failed to get limited support reasons: %v", err)}
+	}
+	if len(reasons) == 0 {
+		return []verify.Check{verify.Pass(name, "The cluster is fully supported")}
+	}
+	checks := []verify.Check{}
+	for _, reason := range reasons {
+		checks = append(checks, verify.Warn(name, "%s: %s", reason.Summary(), reason.Details()))
+	}
+	return checks
+}
@@ -0,0 +1,195 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/helper/verify"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const clustersPath = "/api/clusters_mgmt/v1/clusters/cluster1"
+
+var _ = Describe("Pre-flight", func() {
+	var apiServer *ghttp.Server
+	var r *rosa.Runtime
+	var cluster *cmv1.Cluster
+
+	// The add-ons and limited support reasons are the same in every case
+	respondWithItems := func(items ...string) http.HandlerFunc {
+		return RespondWithJSON(http.StatusOK, fmt.Sprintf(`{"page": 1, "size": %d, "total": %d, "items": [%s]}`,
+			len(items), len(items), strings.Join(items, ",")))
+	}
+
+	BeforeEach(func() {
+		apiServer = MakeTCPServer()
+		apiServer.SetAllowUnhandledRequests(true)
+		apiServer.SetUnhandledRequestStatusCode(http.StatusInternalServerError)
+		apiServer.RouteToHandler(http.MethodGet, "/api/accounts_mgmt/v1/current_account",
+			RespondWithJSON(http.StatusOK, `{"id": "account1", "organization": {"id": "org1"}}`))
+		apiServer.RouteToHandler(http.MethodGet, "/api/accounts_mgmt/v1/organizations/org1/quota_cost",
+			respondWithItems())
+		apiServer.RouteToHandler(http.MethodGet, "/api/clusters_mgmt/v1/addons", respondWithItems())
+		apiServer.RouteToHandler(http.MethodGet, clustersPath+"/addons", respondWithItems())
+		apiServer.RouteToHandler(http.MethodGet, clustersPath+"/limited_support_reasons", respondWithItems())
+
+		client, err := ocm.NewClient().
+			Logger(logging.NewLogger()).
+			Config(&config.Config{Session: config.Session{
+				URL:         apiServer.URL(),
+				AccessToken: MakeTokenString("Bearer", 15*time.Minute),
+			}}).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		r = &rosa.Runtime{OCMClient: client}
+
+		cluster, err = cmv1.NewCluster().
+			ID("cluster1").
+			OpenshiftVersion("4.12.10").
+			Version(cmv1.NewVersion().ID("openshift-v4.12.10").RawID("4.12.10")).
+			Hypershift(cmv1.NewHypershift().Enabled(true)).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		Expect(r.OCMClient.Close()).To(Succeed())
+	})
+
+	nodePool := func(id string, version string) string {
+		return fmt.Sprintf(`{"id": "%s", "version": {"id": "openshift-v%s", "raw_id": "%s"}}`, id, version, version)
+	}
+
+	DescribeTable("Checks",
+		func(nodePools []string, upgradePolicies []string, gates []string, version string,
+			expected map[string]string, blocked bool) {
+			apiServer.RouteToHandler(http.MethodGet, clustersPath+"/node_pools", respondWithItems(nodePools...))
+			apiServer.RouteToHandler(http.MethodGet, clustersPath+"/control_plane/upgrade_policies",
+				respondWithItems(upgradePolicies...))
+			if len(gates) == 0 {
+				apiServer.RouteToHandler(http.MethodPost, clustersPath+"/control_plane/upgrade_policies",
+					RespondWithJSON(http.StatusCreated, `{}`))
+			} else {
+				apiServer.RouteToHandler(http.MethodPost, clustersPath+"/control_plane/upgrade_policies",
+					RespondWithJSON(http.StatusBadRequest, fmt.Sprintf(`{"kind": "Error", "id": "400", `+
+						`"reason": "Missing required gate agreements", "details": [%s]}`, strings.Join(gates, ","))))
+			}
+
+			parsedVersion, checks := preflightChecks(r, cluster, []string{"4.12.20", "4.13.1"}, version)
+			results := map[string]string{}
+			for _, check := range checks {
+				results[check.Name] = check.Result
+			}
+			Expect(results).To(Equal(expected))
+			Expect(verify.HasFailures(checks)).To(Equal(blocked))
+			if !blocked {
+				Expect(parsedVersion).To(Equal(version))
+			}
+		},
+		Entry("no blockers",
+			[]string{nodePool("workers", "4.12.10")}, nil, nil, "4.13.1",
+			map[string]string{
+				"Version":           verify.Passed,
+				"Scheduled upgrade": verify.Passed,
+				"Version gates":     verify.Passed,
+				"Machine pools":     verify.Warning,
+				"Add-ons":           verify.Passed,
+				"Limited support":   verify.Passed,
+			}, false),
+		Entry("machine pools too far behind",
+			[]string{nodePool("workers", "4.12.10"), nodePool("old", "4.10.3")}, nil, nil, "4.13.1",
+			map[string]string{
+				"Version":           verify.Passed,
+				"Scheduled upgrade": verify.Passed,
+				"Version gates":     verify.Passed,
+				"Machine pools":     verify.Failed,
+				"Add-ons":           verify.Passed,
+				"Limited support":   verify.Passed,
+			}, true),
+		Entry("machine pools at the target minor version",
+			[]string{nodePool("workers", "4.12.10")}, nil, nil, "4.12.20",
+			map[string]string{
+				"Version":           verify.Passed,
+				"Scheduled upgrade": verify.Passed,
+				"Version gates":     verify.Passed,
+				"Machine pools":     verify.Passed,
+				"Add-ons":           verify.Passed,
+				"Limited support":   verify.Passed,
+			}, false),
+		Entry("missing gate acknowledgement",
+			[]string{nodePool("workers", "4.12.10")}, nil,
+			[]string{`{"kind": "VersionGate", "id": "gate1", "description": "API removals", ` +
+				`"documentation_url": "https://docs.example.com", "sts_only": false}`},
+			"4.13.1",
+			map[string]string{
+				"Version":           verify.Passed,
+				"Scheduled upgrade": verify.Passed,
+				"Version gates":     verify.Failed,
+				"Machine pools":     verify.Warning,
+				"Add-ons":           verify.Passed,
+				"Limited support":   verify.Passed,
+			}, true),
+		Entry("only STS gates missing",
+			[]string{nodePool("workers", "4.12.10")}, nil,
+			[]string{`{"kind": "VersionGate", "id": "gate1", "description": "STS policies", "sts_only": true}`},
+			"4.13.1",
+			map[string]string{
+				"Version":           verify.Passed,
+				"Scheduled upgrade": verify.Passed,
+				"Version gates":     verify.Passed,
+				"Machine pools":     verify.Warning,
+				"Add-ons":           verify.Passed,
+				"Limited support":   verify.Passed,
+			}, false),
+		Entry("upgrade already scheduled",
+			[]string{nodePool("workers", "4.12.10")},
+			[]string{`{"id": "policy1", "upgrade_type": "ControlPlane", "version": "4.12.20", ` +
+				`"next_run": "2023-06-01T10:00:00Z", "state": {"value": "scheduled"}}`},
+			nil, "4.13.1",
+			map[string]string{
+				"Version":           verify.Passed,
+				"Scheduled upgrade": verify.Failed,
+				"Version gates":     verify.Passed,
+				"Machine pools":     verify.Warning,
+				"Add-ons":           verify.Passed,
+				"Limited support":   verify.Passed,
+			}, true),
+		Entry("version that isn't an available upgrade",
+			nil, nil, nil, "4.14.0",
+			map[string]string{
+				"Version":         verify.Failed,
+				"Limited support": verify.Passed,
+			}, true),
+	)
+
+	It("Reports the checks with the output flag", func() {
+		checks := []verify.Check{
+			verify.Pass("Version", "'4.13.1' is an available upgrade"),
+			verify.Fail("Version gates", "Missing acknowledgements"),
+		}
+		data, err := json.Marshal(preflightReport(cluster, "4.13.1", checks))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"cluster": "cluster1",
+			"version": "4.13.1",
+			"checks": [
+				{"name": "Version", "result": "passed", "message": "'4.13.1' is an available upgrade"},
+				{"name": "Version gates", "result": "failed", "message": "Missing acknowledgements"}
+			]
+		}`))
+	})
+})
@@ -0,0 +1,401 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	plan     string
+	state    string
+	interval time.Duration
+	timeout  time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:   "clusters",
+	Short: "Upgrade clusters in waves",
+	Long: "Upgrade clusters in the waves of a plan. Each wave is upgraded and checked for health before " +
+		"the next one starts, and the progress is stored in a state file so that the command can be run " +
+		"again to resume the plan where it stopped.",
+	Example: `  # Upgrade the clusters selected by the waves of a plan
+  rosa upgrade clusters --plan waves.yaml
+
+  # Example of plan that upgrades a canary cluster before the production clusters:
+  #
+  # version: 4.12.10
+  # waves:
+  # - name: canary
+  #   clusters:
+  #   - name: canary
+  # - name: production
+  #   clusters:
+  #   - name: prod-*
+  #     region: us-east-1`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(
+		&args.plan,
+		"plan",
+		"",
+		"Path to the YAML file with the waves of clusters to upgrade.",
+	)
+	Cmd.MarkFlagRequired("plan")
+
+	flags.StringVar(
+		&args.state,
+		"state",
+		"",
+		"Path to the file where the progress of the plan is stored. Defaults to the path of the plan "+
+			"followed by '.state.json'.",
+	)
+
+	flags.DurationVar(
+		&args.interval,
+		"interval",
+		time.Minute,
+		"Time to wait between checks of the upgrades of a wave.",
+	)
+
+	flags.DurationVar(
+		&args.timeout,
+		"timeout",
+		4*time.Hour,
+		"Maximum time to wait for the upgrades of each wave to complete.",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	plan, err := ReadPlan(args.plan)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	stateFile := args.state
+	if stateFile == "" {
+		stateFile = args.plan + ".state.json"
+	}
+	state, err := ReadState(stateFile)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	clusters, err := r.OCMClient.GetClusters(r.Creator, 100)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get clusters: %v", err)
+		os.Exit(1)
+	}
+	waves := plan.AssignClusters(clusters)
+
+	total := 0
+	for i, wave := range plan.Waves {
+		names := []string{}
+		for _, cluster := range waves[i] {
+			names = append(names, cluster.Name())
+		}
+		total += len(names)
+		r.Reporter.Infof("Wave '%s': %s", wave.Name, strings.Join(names, ", "))
+	}
+	if total == 0 {
+		r.Reporter.Errorf("The waves of plan '%s' don't select any cluster", args.plan)
+		os.Exit(1)
+	}
+	if !confirm.Confirm("upgrade %d clusters in %d waves", total, len(plan.Waves)) {
+		os.Exit(0)
+	}
+
+	saveState := func() {
+		err := state.Save(stateFile)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+	}
+
+	for i, wave := range plan.Waves {
+		if len(waves[i]) == 0 {
+			r.Reporter.Warnf("Wave '%s' doesn't select any cluster", wave.Name)
+			continue
+		}
+		r.Reporter.Infof("Starting wave '%s'", wave.Name)
+
+		pending := []*cmv1.Cluster{}
+		for _, cluster := range waves[i] {
+			if state.Done(cluster.ID()) {
+				r.Reporter.Infof("Cluster '%s' was already upgraded", cluster.Name())
+				continue
+			}
+			if clusterState, ok := state.Clusters[cluster.ID()]; ok && clusterState.State == StateScheduled {
+				pending = append(pending, cluster)
+				continue
+			}
+			version, err := scheduleUpgrade(r, cluster, plan.Version)
+			switch {
+			case err != nil:
+				r.Reporter.Warnf("Failed to schedule upgrade of cluster '%s': %v", cluster.Name(), err)
+				state.Set(cluster, wave.Name, version, StateFailed, err.Error())
+			case version == "":
+				err = checkHealth(r, cluster)
+				if err != nil {
+					r.Reporter.Warnf("Cluster '%s' is not healthy: %v", cluster.Name(), err)
+					state.Set(cluster, wave.Name, cluster.Version().RawID(), StateFailed, err.Error())
+				} else {
+					r.Reporter.Infof("Cluster '%s' doesn't need to be upgraded", cluster.Name())
+					state.Set(cluster, wave.Name, cluster.Version().RawID(), StateSkipped,
+						"No upgrade needed")
+				}
+			default:
+				r.Reporter.Infof("Scheduled upgrade of cluster '%s' to version '%s'", cluster.Name(), version)
+				state.Set(cluster, wave.Name, version, StateScheduled, "")
+				pending = append(pending, cluster)
+			}
+			saveState()
+		}
+
+		waitForUpgrades(r, state, wave.Name, pending, saveState)
+
+		failed := []string{}
+		for _, cluster := range waves[i] {
+			if clusterState, ok := state.Clusters[cluster.ID()]; ok && clusterState.State == StateFailed {
+				failed = append(failed, fmt.Sprintf("%s (%s)", cluster.Name(), clusterState.Message))
+			}
+		}
+		if len(failed) > 0 {
+			r.Reporter.Errorf("Stopping the plan because the upgrade of clusters of wave '%s' failed: %s. "+
+				"Fix them and run the command again to resume the plan", wave.Name, strings.Join(failed, ", "))
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Completed wave '%s'", wave.Name)
+	}
+	r.Reporter.Infof("Completed all the waves of plan '%s'", args.plan)
+}
+
+// scheduleUpgrade schedules the upgrade of the cluster and returns the version it will be upgraded
+// to, which is empty when the cluster doesn't need to be upgraded
+func scheduleUpgrade(r *rosa.Runtime, cluster *cmv1.Cluster, version string) (string, error) {
+	if cluster.State() != cmv1.ClusterStateReady {
+		return "", fmt.Errorf("Cluster is not ready")
+	}
+	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+	if err != nil {
+		return "", fmt.Errorf("Failed to find available upgrades: %v", err)
+	}
+	if version == "" {
+		if len(availableUpgrades) == 0 {
+			return "", nil
+		}
+		version = availableUpgrades[0]
+	} else {
+		if cluster.Version().RawID() == version {
+			return "", nil
+		}
+		if !helper.Contains(availableUpgrades, version) {
+			return "", fmt.Errorf("Version '%s' is not an available upgrade", version)
+		}
+	}
+
+	nextRun := time.Now().UTC().Add(10 * time.Minute)
+	if cluster.Hypershift().Enabled() {
+		scheduledUpgrade, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return version, fmt.Errorf("Failed to get scheduled upgrades: %v", err)
+		}
+		if scheduledUpgrade != nil {
+			return version, fmt.Errorf("There is already a %s upgrade policy",
+				scheduledUpgrade.ScheduleType())
+		}
+		upgradePolicy, err := cmv1.NewControlPlaneUpgradePolicy().ScheduleType("manual").
+			UpgradeType("ControlPlane").Version(version).NextRun(nextRun).Build()
+		if err != nil {
+			return version, err
+		}
+		gates, err := r.OCMClient.GetMissingGateAgreementsHypershift(cluster.ID(), upgradePolicy)
+		if err != nil {
+			return version, fmt.Errorf("Failed to check for missing gate agreements: %v", err)
+		}
+		err = ackGates(r, cluster, gates)
+		if err != nil {
+			return version, err
+		}
+		return version, r.OCMClient.ScheduleHypershiftControlPlaneUpgrade(cluster.ID(), upgradePolicy)
+	}
+
+	scheduledUpgrade, _, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
+	if err != nil {
+		return version, fmt.Errorf("Failed to get scheduled upgrades: %v", err)
+	}
+	if scheduledUpgrade != nil {
+		return version, fmt.Errorf("There is already a %s upgrade policy", scheduledUpgrade.ScheduleType())
+	}
+	upgradePolicy, err := cmv1.NewUpgradePolicy().ScheduleType("manual").Version(version).Build()
+	if err != nil {
+		return version, err
+	}
+	gates, err := r.OCMClient.GetMissingGateAgreementsClassic(cluster.ID(), upgradePolicy)
+	if err != nil {
+		return version, fmt.Errorf("Failed to check for missing gate agreements: %v", err)
+	}
+	err = ackGates(r, cluster, gates)
+	if err != nil {
+		return version, err
+	}
+	upgradePolicy, err = cmv1.NewUpgradePolicy().ScheduleType("manual").Version(version).NextRun(nextRun).Build()
+	if err != nil {
+		return version, err
+	}
+	return version, r.OCMClient.ScheduleUpgrade(cluster.ID(), upgradePolicy)
+}
+
+// ackGates acknowledges the gates that only apply to STS, the rest need to be acknowledged by a
+// person before the plan can upgrade the cluster
+func ackGates(r *rosa.Runtime, cluster *cmv1.Cluster, gates []*cmv1.VersionGate) error {
+	for _, gate := range gates {
+		if !gate.STSOnly() {
+			return fmt.Errorf("Missing acknowledgement of '%s' (%s). Run 'rosa upgrade cluster -c %s' "+
+				"to acknowledge it", gate.Description(), gate.DocumentationURL(), cluster.Name())
+		}
+	}
+	for _, gate := range gates {
+		err := r.OCMClient.AckVersionGate(cluster.ID(), gate.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to acknowledge version gate '%s': %v", gate.ID(), err)
+		}
+	}
+	return nil
+}
+
+// waitForUpgrades polls the upgrade policies of the clusters until they are upgraded and healthy,
+// or until the timeout expires
+func waitForUpgrades(r *rosa.Runtime, state *State, wave string, clusters []*cmv1.Cluster, saveState func()) {
+	deadline := time.Now().Add(args.timeout)
+	for len(clusters) > 0 {
+		pending := []*cmv1.Cluster{}
+		for _, cluster := range clusters {
+			version := state.Clusters[cluster.ID()].Version
+			upgraded, done, err := checkUpgrade(r, cluster, version)
+			if err == nil && done {
+				err = checkHealth(r, upgraded)
+			}
+			switch {
+			case err != nil:
+				r.Reporter.Warnf("Upgrade of cluster '%s' failed: %v", cluster.Name(), err)
+				state.Set(cluster, wave, version, StateFailed, err.Error())
+				saveState()
+			case done:
+				r.Reporter.Infof("Cluster '%s' was upgraded to version '%s'", cluster.Name(), version)
+				state.Set(cluster, wave, version, StateUpgraded, "")
+				saveState()
+			default:
+				pending = append(pending, cluster)
+			}
+		}
+		clusters = pending
+		if len(clusters) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			for _, cluster := range clusters {
+				state.Set(cluster, wave, state.Clusters[cluster.ID()].Version, StateFailed,
+					"Timed out waiting for the upgrade")
+			}
+			saveState()
+			break
+		}
+		r.Reporter.Infof("Waiting for %d clusters of wave '%s' to be upgraded", len(clusters), wave)
+		time.Sleep(args.interval)
+	}
+}
+
+// checkUpgrade checks if the upgrade policy of the cluster completed and returns the upgraded cluster
+func checkUpgrade(r *rosa.Runtime, cluster *cmv1.Cluster, version string) (*cmv1.Cluster, bool, error) {
+	var upgradeState cmv1.UpgradePolicyStateValue
+	if cluster.Hypershift().Enabled() {
+		scheduledUpgrade, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return nil, false, fmt.Errorf("Failed to get scheduled upgrades: %v", err)
+		}
+		if scheduledUpgrade != nil {
+			upgradeState = scheduledUpgrade.State().Value()
+		}
+	} else {
+		scheduledUpgrade, state, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return nil, false, fmt.Errorf("Failed to get scheduled upgrades: %v", err)
+		}
+		if scheduledUpgrade != nil {
+			upgradeState = state.Value()
+		}
+	}
+	switch upgradeState {
+	case cmv1.UpgradePolicyStateValueFailed, cmv1.UpgradePolicyStateValueCancelled:
+		return nil, false, fmt.Errorf("Upgrade is %s", upgradeState)
+	case "", cmv1.UpgradePolicyStateValueCompleted:
+		// Upgrade policies are removed once they complete
+	default:
+		return nil, false, nil
+	}
+
+	upgraded, err := r.OCMClient.GetClusterByID(cluster.ID(), r.Creator)
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed to get cluster: %v", err)
+	}
+	if upgraded.Version().RawID() != version {
+		return nil, false, nil
+	}
+	return upgraded, true, nil
+}
+
+func checkHealth(r *rosa.Runtime, cluster *cmv1.Cluster) error {
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf("Cluster is %s", cluster.State())
+	}
+	reasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get limited support reasons: %v", err)
+	}
+	if len(reasons) > 0 {
+		summaries := []string{}
+		for _, reason := range reasons {
+			summaries = append(summaries, reason.Summary())
+		}
+		return fmt.Errorf("Cluster is in limited support: %s", strings.Join(summaries, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/ghodss/yaml"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// States of the clusters of a plan
+const (
+	StateScheduled = "scheduled"
+	StateUpgraded  = "upgraded"
+	StateSkipped   = "skipped"
+	StateFailed    = "failed"
+)
+
+// Plan is a list of waves of clusters that are upgraded one after the other, so that problems
+// found in the first waves stop the upgrade of the rest
+type Plan struct {
+	// Version that the clusters are upgraded to, the latest available one of each cluster when empty
+	Version string `json:"version,omitempty"`
+	Waves   []Wave `json:"waves"`
+}
+
+type Wave struct {
+	Name     string            `json:"name"`
+	Clusters []ClusterSelector `json:"clusters"`
+}
+
+// ClusterSelector selects the clusters matching all of its fields. The name can be a shell
+// pattern like 'prod-*'.
+type ClusterSelector struct {
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Region string `json:"region,omitempty"`
+}
+
+// ReadPlan reads a plan from a YAML file
+func ReadPlan(file string) (*Plan, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read plan file '%s': %v", file, err)
+	}
+	return ParsePlan(data)
+}
+
+func ParsePlan(data []byte) (*Plan, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse plan: %v", err)
+	}
+	// Reject unknown fields, as a typo in a selector would otherwise select every cluster
+	plan := &Plan{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(plan)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse plan: %v", err)
+	}
+	if len(plan.Waves) == 0 {
+		return nil, fmt.Errorf("Expected plan to have at least one wave")
+	}
+	names := map[string]bool{}
+	for i := range plan.Waves {
+		wave := &plan.Waves[i]
+		if wave.Name == "" {
+			wave.Name = fmt.Sprintf("wave-%d", i+1)
+		}
+		if names[wave.Name] {
+			return nil, fmt.Errorf("Wave '%s' is defined more than once", wave.Name)
+		}
+		names[wave.Name] = true
+		if len(wave.Clusters) == 0 {
+			return nil, fmt.Errorf("Expected wave '%s' to select at least one cluster", wave.Name)
+		}
+		for _, selector := range wave.Clusters {
+			if selector.ID == "" && selector.Name == "" && selector.Region == "" {
+				return nil, fmt.Errorf("Expected selectors of wave '%s' to have an id, a name or a region",
+					wave.Name)
+			}
+			if _, err = path.Match(selector.Name, ""); err != nil {
+				return nil, fmt.Errorf("Invalid name '%s' in wave '%s': %v", selector.Name, wave.Name, err)
+			}
+		}
+	}
+	return plan, nil
+}
+
+// Matches checks if the cluster is selected by the selector
+func (s ClusterSelector) Matches(cluster *cmv1.Cluster) bool {
+	if s.ID != "" && s.ID != cluster.ID() && s.ID != cluster.ExternalID() {
+		return false
+	}
+	if s.Name != "" {
+		matched, err := path.Match(s.Name, cluster.Name())
+		if err != nil || !matched {
+			return false
+		}
+	}
+	if s.Region != "" && s.Region != cluster.Region().ID() {
+		return false
+	}
+	return true
+}
+
+// AssignClusters returns the clusters of each wave of the plan. Clusters selected by more than one
+// wave only belong to the first one.
+func (p *Plan) AssignClusters(clusters []*cmv1.Cluster) [][]*cmv1.Cluster {
+	assigned := map[string]bool{}
+	waves := make([][]*cmv1.Cluster, len(p.Waves))
+	for i, wave := range p.Waves {
+		waves[i] = []*cmv1.Cluster{}
+		for _, cluster := range clusters {
+			if assigned[cluster.ID()] {
+				continue
+			}
+			for _, selector := range wave.Clusters {
+				if selector.Matches(cluster) {
+					assigned[cluster.ID()] = true
+					waves[i] = append(waves[i], cluster)
+					break
+				}
+			}
+		}
+	}
+	return waves
+}
+
+// State records the progress of a plan, so that it can be resumed where it stopped
+type State struct {
+	Clusters map[string]*ClusterState `json:"clusters"`
+}
+
+type ClusterState struct {
+	Name      string    `json:"name"`
+	Wave      string    `json:"wave"`
+	Version   string    `json:"version,omitempty"`
+	State     string    `json:"state"`
+	Message   string    `json:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ReadState reads the state of a plan, which is empty if the plan hasn't run yet
+func ReadState(file string) (*State, error) {
+	state := &State{
+		Clusters: map[string]*ClusterState{},
+	}
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read state file '%s': %v", file, err)
+	}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse state file '%s': %v", file, err)
+	}
+	if state.Clusters == nil {
+		state.Clusters = map[string]*ClusterState{}
+	}
+	return state, nil
+}
+
+// Save writes the state to the file, replacing the previous one only once it's complete
+func (s *State) Save(file string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to marshal state: %v", err)
+	}
+	tmpFile := file + ".tmp"
+	err = os.WriteFile(tmpFile, data, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to write state file '%s': %v", tmpFile, err)
+	}
+	err = os.Rename(tmpFile, file)
+	if err != nil {
+		return fmt.Errorf("Failed to write state file '%s': %v", file, err)
+	}
+	return nil
+}
+
+// Set records the state of a cluster
+func (s *State) Set(cluster *cmv1.Cluster, wave string, version string, state string, message string) {
+	s.Clusters[cluster.ID()] = &ClusterState{
+		Name:      cluster.Name(),
+		Wave:      wave,
+		Version:   version,
+		State:     state,
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// Done checks if the upgrade of the cluster doesn't need anything else
+func (s *State) Done(clusterID string) bool {
+	clusterState, ok := s.Clusters[clusterID]
+	return ok && (clusterState.State == StateUpgraded || clusterState.State == StateSkipped)
+}
@@ -0,0 +1,130 @@
+package clusters_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/cmd/upgrade/clusters"
+)
+
+func buildCluster(id string, name string, region string) *cmv1.Cluster {
+	cluster, err := cmv1.NewCluster().ID(id).Name(name).Region(cmv1.NewCloudRegion().ID(region)).Build()
+	Expect(err).NotTo(HaveOccurred())
+	return cluster
+}
+
+var _ = Describe("Upgrade plan", func() {
+	Context("ParsePlan", func() {
+		It("Parses the waves and names the unnamed ones", func() {
+			plan, err := clusters.ParsePlan([]byte(`
+version: 4.12.10
+waves:
+- name: canary
+  clusters:
+  - name: canary
+- clusters:
+  - name: prod-*
+    region: us-east-1
+  - id: 123abc
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Version).To(Equal("4.12.10"))
+			Expect(plan.Waves).To(HaveLen(2))
+			Expect(plan.Waves[0].Name).To(Equal("canary"))
+			Expect(plan.Waves[1].Name).To(Equal("wave-2"))
+			Expect(plan.Waves[1].Clusters).To(ConsistOf(
+				clusters.ClusterSelector{Name: "prod-*", Region: "us-east-1"},
+				clusters.ClusterSelector{ID: "123abc"},
+			))
+		})
+
+		It("Rejects unknown fields", func() {
+			_, err := clusters.ParsePlan([]byte(`
+waves:
+- clusters:
+  - nmae: prod-*
+`))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Rejects plans without waves", func() {
+			_, err := clusters.ParsePlan([]byte(`version: 4.12.10`))
+			Expect(err).To(MatchError("Expected plan to have at least one wave"))
+		})
+
+		It("Rejects empty selectors", func() {
+			_, err := clusters.ParsePlan([]byte(`
+waves:
+- name: canary
+  clusters:
+  - {}
+`))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Rejects duplicated wave names", func() {
+			_, err := clusters.ParsePlan([]byte(`
+waves:
+- name: canary
+  clusters:
+  - name: a
+- name: canary
+  clusters:
+  - name: b
+`))
+			Expect(err).To(MatchError("Wave 'canary' is defined more than once"))
+		})
+	})
+
+	Context("AssignClusters", func() {
+		It("Assigns each cluster to the first wave that selects it", func() {
+			plan, err := clusters.ParsePlan([]byte(`
+waves:
+- name: canary
+  clusters:
+  - name: prod-canary
+- name: east
+  clusters:
+  - name: prod-*
+    region: us-east-1
+- name: rest
+  clusters:
+  - name: prod-*
+`))
+			Expect(err).NotTo(HaveOccurred())
+			canary := buildCluster("1", "prod-canary", "us-east-1")
+			east := buildCluster("2", "prod-east", "us-east-1")
+			west := buildCluster("3", "prod-west", "us-west-2")
+			staging := buildCluster("4", "staging", "us-east-1")
+			waves := plan.AssignClusters([]*cmv1.Cluster{canary, east, west, staging})
+			Expect(waves).To(HaveLen(3))
+			Expect(waves[0]).To(ConsistOf(canary))
+			Expect(waves[1]).To(ConsistOf(east))
+			Expect(waves[2]).To(ConsistOf(west))
+		})
+	})
+
+	Context("State", func() {
+		It("Saves and reads the state of the clusters", func() {
+			file := filepath.Join(GinkgoT().TempDir(), "plan.yaml.state.json")
+			state, err := clusters.ReadState(file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.Clusters).To(BeEmpty())
+
+			state.Set(buildCluster("1", "prod-canary", "us-east-1"), "canary", "4.12.10", clusters.StateUpgraded, "")
+			state.Set(buildCluster("2", "prod-east", "us-east-1"), "east", "4.12.10", clusters.StateScheduled, "")
+			Expect(state.Save(file)).To(Succeed())
+
+			state, err = clusters.ReadState(file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.Clusters).To(HaveLen(2))
+			Expect(state.Clusters["2"].Wave).To(Equal("east"))
+			Expect(state.Done("1")).To(BeTrue())
+			Expect(state.Done("2")).To(BeFalse())
+			Expect(state.Done("3")).To(BeFalse())
+		})
+	})
+})
@@ -20,9 +20,13 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/cmd/upgrade/accountroles"
+	"github.com/openshift/rosa/cmd/upgrade/addon"
 	"github.com/openshift/rosa/cmd/upgrade/cluster"
+	"github.com/openshift/rosa/cmd/upgrade/clusters"
+	"github.com/openshift/rosa/cmd/upgrade/machinepool"
 	"github.com/openshift/rosa/cmd/upgrade/operatorroles"
 	"github.com/openshift/rosa/cmd/upgrade/roles"
+	"github.com/openshift/rosa/cmd/upgrade/service"
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/interactive"
 )
@@ -35,9 +39,13 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(clusters.Cmd)
+	Cmd.AddCommand(machinepool.Cmd)
 	Cmd.AddCommand(accountroles.Cmd)
 	Cmd.AddCommand(operatorroles.Cmd)
 	Cmd.AddCommand(roles.Cmd)
+	Cmd.AddCommand(service.Cmd)
+	Cmd.AddCommand(addon.Cmd)
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
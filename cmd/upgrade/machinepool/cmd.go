@@ -0,0 +1,281 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper/versions"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	version      string
+	all          bool
+	stageTimeout time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:     "machinepool ID [ID...]",
+	Aliases: []string{"machinepools", "machine-pool", "machine-pools"},
+	Short:   "Upgrade machine pool",
+	Long: "Upgrade machine pools of a hosted cluster to a new available version. When several machine " +
+		"pools are selected they are upgraded one at a time, waiting for each machine pool to run the new " +
+		"version with all its replicas available before upgrading the next one.",
+	Example: `  # Upgrade machine pool 'mp1' on hosted cluster 'mycluster' to version 4.12.20
+  rosa upgrade machinepool -c mycluster --version 4.12.20 mp1
+
+  # Upgrade machine pools 'mp1' and then 'mp2' on hosted cluster 'mycluster'
+  rosa upgrade machinepool -c mycluster --version 4.12.20 mp1 mp2
+
+  # Upgrade all machine pools on hosted cluster 'mycluster', one at a time
+  rosa upgrade machinepools -c mycluster --version 4.12.20 --all`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if args.all && len(argv) != 0 {
+			return fmt.Errorf("The id of the machine pool can't be set when using '--all'")
+		}
+		if !args.all && len(argv) == 0 {
+			return fmt.Errorf(
+				"Expected at least one command line parameter containing the id of the machine pool",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Version of OpenShift that the machine pools will be upgraded to",
+	)
+
+	flags.BoolVar(
+		&args.all,
+		"all",
+		false,
+		"Upgrade all machine pools of the cluster, one at a time",
+	)
+
+	flags.DurationVar(
+		&args.stageTimeout,
+		"stage-timeout",
+		time.Hour,
+		"Maximum time to wait for a machine pool to have all its replicas available before "+
+			"upgrading the next one",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	if !cluster.Hypershift().Enabled() {
+		r.Reporter.Errorf("Upgrading machine pools is only supported for hosted clusters. " +
+			"Machine pools of classic clusters are upgraded together with the cluster")
+		os.Exit(1)
+	}
+
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading machine pools for hosted cluster '%s'", clusterKey)
+	allNodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pools for hosted cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	nodePools, err := selectNodePools(allNodePools, argv)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	channelGroup := cluster.Version().ChannelGroup()
+	versionList, err := versions.GetVersionList(r, channelGroup, true, true)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	// Machine pools can't run a newer version than the control plane
+	version := args.version
+	if version == "" || interactive.Enabled() {
+		version, err = interactive.GetOption(interactive.Input{
+			Question: "OpenShift version",
+			Help:     cmd.Flags().Lookup("version").Usage,
+			Options: versions.GetFilteredVersionList(versionList,
+				ocm.GetRawVersionId(nodePools[0].Version().ID()), cluster.Version().RawID()),
+			Default:  version,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid OpenShift version: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	// Validate the version against every machine pool before upgrading any of them
+	pending := []*cmv1.NodePool{}
+	for _, nodePool := range nodePools {
+		nodePoolVersion := ocm.GetRawVersionId(nodePool.Version().ID())
+		if nodePoolVersion == version {
+			r.Reporter.Infof("Machine pool '%s' is already running version '%s'", nodePool.ID(), version)
+			continue
+		}
+		filteredVersionList := versions.GetFilteredVersionList(versionList, nodePoolVersion,
+			cluster.Version().RawID())
+		_, err = r.OCMClient.ValidateVersion(version, filteredVersionList, channelGroup, true, true)
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid OpenShift version for machine pool '%s': %s", nodePool.ID(), err)
+			os.Exit(1)
+		}
+		pending = append(pending, nodePool)
+	}
+	if len(pending) == 0 {
+		return
+	}
+	versionID, err := r.OCMClient.ValidateVersion(version, versionList, channelGroup, true, true)
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid OpenShift version: %s", err)
+		os.Exit(1)
+	}
+
+	ids := []string{}
+	for _, nodePool := range pending {
+		ids = append(ids, nodePool.ID())
+	}
+	if !confirm.Confirm("upgrade machine pools '%s' on hosted cluster '%s' to version '%s'",
+		strings.Join(ids, "', '"), clusterKey, version) {
+		os.Exit(0)
+	}
+
+	for i, nodePool := range pending {
+		npBuilder := cmv1.NewNodePool().
+			ID(nodePool.ID()).
+			Version(cmv1.NewVersion().ID(versionID))
+		update, err := npBuilder.Build()
+		if err != nil {
+			r.Reporter.Errorf("Failed to upgrade machine pool '%s' on hosted cluster '%s': %v",
+				nodePool.ID(), clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Debugf("Upgrading machine pool '%s' on hosted cluster '%s'", nodePool.ID(), clusterKey)
+		_, err = r.OCMClient.UpdateNodePool(cluster.ID(), update)
+		if err != nil {
+			r.Reporter.Errorf("Failed to upgrade machine pool '%s' on hosted cluster '%s': %v",
+				nodePool.ID(), clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Upgrade of machine pool '%s' on hosted cluster '%s' to version '%s' started",
+			nodePool.ID(), clusterKey, version)
+
+		// Don't move to the next stage of the rollout until the machine pool is available again
+		if i < len(pending)-1 {
+			waitForNodePool(r, cluster.ID(), clusterKey, nodePool.ID(), version)
+		}
+	}
+}
+
+// selectNodePools returns the node pools with the given IDs in the same order, or all of them
+// when no IDs are given
+func selectNodePools(nodePools []*cmv1.NodePool, ids []string) ([]*cmv1.NodePool, error) {
+	if len(ids) == 0 {
+		if len(nodePools) == 0 {
+			return nil, fmt.Errorf("There are no machine pools to upgrade")
+		}
+		return nodePools, nil
+	}
+	selected := []*cmv1.NodePool{}
+	for _, id := range ids {
+		var found *cmv1.NodePool
+		for _, nodePool := range nodePools {
+			if nodePool.ID() == id {
+				found = nodePool
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("Machine pool '%s' does not exist", id)
+		}
+		selected = append(selected, found)
+	}
+	return selected, nil
+}
+
+func waitForNodePool(r *rosa.Runtime, clusterID string, clusterKey string, nodePoolID string,
+	version string) {
+	deadline := time.Now().Add(args.stageTimeout)
+	for {
+		// Give the service some time to start replacing the nodes
+		time.Sleep(30 * time.Second)
+		nodePool, err := r.OCMClient.GetNodePool(clusterID, nodePoolID)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get machine pool '%s' for hosted cluster '%s': %v",
+				nodePoolID, clusterKey, err)
+			os.Exit(1)
+		}
+		if isNodePoolUpgraded(nodePool, version) {
+			return
+		}
+		if time.Now().After(deadline) {
+			r.Reporter.Errorf("Timeout waiting for machine pool '%s' to be upgraded. Run this command "+
+				"again to continue upgrading the remaining machine pools", nodePoolID)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Waiting for machine pool '%s' to be upgraded, running version '%s' with %d "+
+			"replicas available: %s", nodePoolID, ocm.GetRawVersionId(nodePool.Version().ID()),
+			nodePool.Status().CurrentReplicas(), nodePool.Status().Message())
+	}
+}
+
+// isNodePoolUpgraded checks that the node pool runs the target version, has all its desired
+// replicas and no pending status message. The replicas alone are already available before the
+// nodes start being replaced, so they don't show that the upgrade finished.
+func isNodePoolUpgraded(nodePool *cmv1.NodePool, version string) bool {
+	if ocm.GetRawVersionId(nodePool.Version().ID()) != version {
+		return false
+	}
+	desired := nodePool.Replicas()
+	if nodePool.Autoscaling() != nil {
+		desired = nodePool.Autoscaling().MinReplica()
+	}
+	return nodePool.Status().CurrentReplicas() >= desired && nodePool.Status().Message() == ""
+}
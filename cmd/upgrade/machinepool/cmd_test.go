@@ -0,0 +1,28 @@
+package machinepool
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Upgrade machine pool", func() {
+	DescribeTable("isNodePoolUpgraded",
+		func(version string, currentReplicas int, message string, expected bool) {
+			nodePool, err := cmv1.NewNodePool().
+				ID("mp1").
+				Replicas(3).
+				Version(cmv1.NewVersion().ID("openshift-v" + version)).
+				Status(cmv1.NewNodePoolStatus().CurrentReplicas(currentReplicas).Message(message)).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(isNodePoolUpgraded(nodePool, "4.12.20")).To(Equal(expected))
+		},
+		Entry("upgrade not started", "4.12.18", 3, "", false),
+		Entry("replacing nodes", "4.12.20", 2, "Replacing nodes", false),
+		Entry("replicas missing", "4.12.20", 2, "", false),
+		Entry("upgraded", "4.12.20", 3, "", true),
+	)
+})
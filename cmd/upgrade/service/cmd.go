@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	id      string
+	version string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "managed-service",
+	Aliases: []string{"appliance", "service"},
+	Short:   "Upgrade managed service",
+	Long:    "Upgrade the add-on of a Red Hat managed service to one of its available versions",
+	Example: `  # Upgrade a managed service to version 1.2.0
+  rosa upgrade managed-service --id=<service id> --version=1.2.0`,
+	Run:    run,
+	Hidden: true,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.id,
+		"id",
+		"",
+		"The id of the service to upgrade",
+	)
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Version of the add-on that the service will be upgraded to",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	if args.id == "" {
+		r.Reporter.Errorf("Service id not specified.")
+		cmd.Help()
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading service %q", args.id)
+	service, err := r.OCMClient.GetManagedService(ocm.DescribeManagedServiceArgs{ID: args.id})
+	if err != nil {
+		r.Reporter.Errorf("Failed to get service %q: %v", args.id, err)
+		os.Exit(1)
+	}
+
+	clusterID := service.Cluster().Id()
+	if clusterID == "" {
+		r.Reporter.Errorf("Service %q is not installed yet", args.id)
+		os.Exit(1)
+	}
+
+	addOnID := service.Service()
+	r.Reporter.Debugf("Loading add-on %q installation of service %q", addOnID, args.id)
+	addOnInstallation, err := r.OCMClient.GetAddOnInstallation(clusterID, addOnID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get add-on %q installation of service %q: %v", addOnID, args.id, err)
+		os.Exit(1)
+	}
+
+	currentVersion := addOnInstallation.AddonVersion().ID()
+	availableUpgrades := addOnInstallation.AddonVersion().AvailableUpgrades()
+	if len(availableUpgrades) == 0 {
+		r.Reporter.Infof("Service %q is already running the latest version '%s'", args.id, currentVersion)
+		return
+	}
+
+	version := args.version
+	if version == "" || interactive.Enabled() {
+		version, err = interactive.GetOption(interactive.Input{
+			Question: "Version",
+			Help:     cmd.Flags().Lookup("version").Usage,
+			Options:  availableUpgrades,
+			Default:  version,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid version: %s", err)
+			os.Exit(1)
+		}
+	}
+	if !helper.Contains(availableUpgrades, version) {
+		r.Reporter.Errorf("Expected a valid version for service %q. Available upgrades from version '%s' are %v",
+			args.id, currentVersion, availableUpgrades)
+		os.Exit(1)
+	}
+
+	if !confirm.Confirm("upgrade service %q from version '%s' to '%s'", args.id, currentVersion, version) {
+		os.Exit(0)
+	}
+
+	r.Reporter.Debugf("Upgrading add-on %q of service %q to version '%s'", addOnID, args.id, version)
+	err = r.OCMClient.UpgradeAddOnInstallation(clusterID, addOnID, version)
+	if err != nil {
+		r.Reporter.Errorf("Failed to upgrade service %q: %v", args.id, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Service %q is now upgrading to version '%s'. To check the status run "+
+		"'rosa logs service --id %s'", args.id, version, args.id)
+}
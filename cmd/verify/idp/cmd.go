@@ -0,0 +1,159 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	idpPack "github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/helper/verify"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	bindPassword string
+	testUser     string
+	testPassword string
+	timeout      time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:   "idp NAME",
+	Short: "Verify identity provider configuration",
+	Long: "Verify that an identity provider of a cluster can reach its external services. OpenID " +
+		"providers are checked by fetching the discovery document of the issuer, LDAP providers by " +
+		"binding and searching for a test user, and GitHub providers by looking up their organizations. " +
+		"Certificate chains are validated against the CA bundle of the identity provider.",
+	Example: `  # Verify the identity provider named 'openid-1' of cluster 'mycluster'
+  rosa verify idp openid-1 --cluster=mycluster
+
+  # Verify that user 'jdoe' can log in with the LDAP identity provider named 'ldap-1'
+  rosa verify idp ldap-1 --cluster=mycluster --bind-password=<password> --test-user=jdoe \
+    --test-password=<password>`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the name of the identity provider",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.bindPassword,
+		"bind-password",
+		"",
+		"LDAP: Password of the bind DN. It is needed as passwords can't be read back from the identity provider.",
+	)
+	flags.StringVar(
+		&args.testUser,
+		"test-user",
+		"",
+		"LDAP: Username to search for with the attribute and filter of the LDAP URL.",
+	)
+	flags.StringVar(
+		&args.testPassword,
+		"test-password",
+		"",
+		"LDAP: Password of the test user, to check that it can bind.",
+	)
+	flags.DurationVar(
+		&args.timeout,
+		"timeout",
+		10*time.Second,
+		"Maximum time to wait for each external service.",
+	)
+
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	idpName := argv[0]
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	if args.testPassword != "" && args.testUser == "" {
+		r.Reporter.Errorf("Expected the `test-user` flag to be set together with `test-password`")
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading identity providers for cluster '%s'", clusterKey)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	var idp *cmv1.IdentityProvider
+	for _, item := range idps {
+		if item.Name() == idpName {
+			idp = item
+			break
+		}
+	}
+	if idp == nil {
+		r.Reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", idpName, clusterKey)
+		os.Exit(1)
+	}
+	if idp.Type() == cmv1.IdentityProviderTypeHtpasswd {
+		r.Reporter.Infof("HTPasswd identity provider '%s' has no external services to verify", idpName)
+		return
+	}
+
+	if !output.HasFlag() && r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Verifying identity provider '%s' on cluster '%s'...", idpName, clusterKey)
+	}
+	checks := idpPack.VerifyIdentityProvider(cluster, idp, idpPack.VerifyOptions{
+		LDAPBindPassword: args.bindPassword,
+		LDAPTestUser:     args.testUser,
+		LDAPTestPassword: args.testPassword,
+		Timeout:          args.timeout,
+	})
+
+	if output.HasFlag() {
+		err = output.Print(map[string]interface{}{
+			"name":   idpName,
+			"checks": checks,
+		})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	} else {
+		verify.PrintReport(checks)
+	}
+	if verify.HasFailures(checks) {
+		os.Exit(1)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"text/tabwriter"
 
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	"github.com/spf13/cobra"
@@ -38,18 +39,36 @@ var Cmd = &cobra.Command{
 	Short: "Displays user account information",
 	Long:  "Displays information about your AWS and Red Hat accounts",
 	Example: `  # Displays user information
-  rosa whoami`,
+  rosa whoami
+
+  # Displays the account of every session logged in to OCM
+  rosa whoami --all`,
 	Run: run,
 }
 
+var args struct {
+	all bool
+}
+
 func init() {
 	flags := Cmd.PersistentFlags()
+	flags.BoolVar(
+		&args.all,
+		"all",
+		false,
+		"Display the OCM account of every session instead of the AWS and OCM accounts of the session in use.",
+	)
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
 	output.AddFlag(Cmd)
 }
 
 func run(_ *cobra.Command, _ []string) {
+	if args.all {
+		runAll()
+		return
+	}
+
 	r := rosa.NewRuntime().WithAWS()
 
 	// Get default AWS region:
@@ -121,6 +140,9 @@ func run(_ *cobra.Command, _ []string) {
 	if account.Organization().ExternalID() != "" {
 		outputObject["OCM Organization External ID"] = account.Organization().ExternalID()
 	}
+	if cfg.SessionName() != config.DefaultSession {
+		outputObject["OCM Session"] = cfg.SessionName()
+	}
 
 	if output.HasFlag() {
 		err = output.Print(outputObject)
@@ -141,6 +163,95 @@ func run(_ *cobra.Command, _ []string) {
 	fmt.Println()
 }
 
+// runAll displays the OCM account of every session that has credentials.
+func runAll() {
+	r := rosa.NewRuntime()
+
+	cfg, err := config.Load()
+	if err != nil {
+		r.Reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	if cfg == nil || len(cfg.SessionNames()) == 0 {
+		r.Reporter.Errorf("User is not logged in to OCM")
+		os.Exit(0)
+	}
+
+	sessions := []object.Object{}
+	for _, name := range cfg.SessionNames() {
+		session := object.Object{
+			"Session": name,
+			"Current": name == cfg.SessionName(),
+		}
+		sessionCfg := cfg.ForSession(name)
+		session["OCM API"] = sessionCfg.URL
+		account, err := getAccount(r, sessionCfg)
+		if err != nil {
+			session["Error"] = err.Error()
+		} else {
+			session["OCM Account Username"] = account.Username()
+			session["OCM Organization ID"] = account.Organization().ID()
+			session["OCM Organization Name"] = account.Organization().Name()
+		}
+		sessions = append(sessions, session)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(sessions)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "SESSION\tCURRENT\tOCM API\tUSERNAME\tORGANIZATION\n")
+	for _, session := range sessions {
+		current := ""
+		if session["Current"] == true {
+			current = "*"
+		}
+		username := session["OCM Account Username"]
+		if username == nil {
+			username = fmt.Sprintf("error: %s", session["Error"])
+		}
+		organization := session["OCM Organization Name"]
+		if organization == nil {
+			organization = ""
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			session["Session"], current, session["OCM API"], username, organization)
+	}
+	writer.Flush()
+}
+
+// getAccount returns the OCM account of the session of the given configuration.
+func getAccount(r *rosa.Runtime, cfg *config.Config) (*amsv1.Account, error) {
+	loggedIn, err := cfg.Armed()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to verify configuration: %v", err)
+	}
+	if !loggedIn {
+		return nil, fmt.Errorf("Credentials have expired")
+	}
+	client, err := ocm.NewClient().
+		Config(cfg).
+		Logger(r.Logger).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	defer client.Close()
+	account, err := client.GetCurrentAccount()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get current account: %v", err)
+	}
+	if account == nil {
+		return getAccountDataFromToken(cfg)
+	}
+	return account, nil
+}
+
 func getAccountDataFromToken(cfg *config.Config) (*amsv1.Account, error) {
 	firstName, err := cfg.GetData("first_name")
 	if err != nil {
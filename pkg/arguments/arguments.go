@@ -27,6 +27,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws/profile"
 	"github.com/openshift/rosa/pkg/aws/region"
+	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/debug"
 	"github.com/openshift/rosa/pkg/helper"
 )
@@ -201,13 +202,23 @@ func AddDebugFlag(fs *pflag.FlagSet) {
 	debug.AddFlag(fs)
 }
 
+// AddSessionFlag adds the '--session' flag to the given set of command line flags.
+func AddSessionFlag(fs *pflag.FlagSet) {
+	config.AddSessionFlag(fs)
+}
+
 // AddProfileFlag adds the '--profile' flag to the given set of command line flags.
 func AddProfileFlag(fs *pflag.FlagSet) {
 	profile.AddFlag(fs)
 }
 
+// GetProfile returns the AWS profile given in the command line or the environment, falling back
+// to the one of the current context.
 func GetProfile() string {
-	return profile.Profile()
+	if p := profile.Profile(); p != "" {
+		return p
+	}
+	return currentContext.Profile
 }
 
 // AddRegionFlag adds the '--region' flag to the given set of command line flags.
@@ -215,8 +226,13 @@ func AddRegionFlag(fs *pflag.FlagSet) {
 	region.AddFlag(fs)
 }
 
+// GetRegion returns the AWS region given in the command line or the environment, falling back
+// to the one of the current context.
 func GetRegion() string {
-	return region.Region()
+	if r := region.Region(); r != "" {
+		return r
+	}
+	return currentContext.Region
 }
 
 func IsValidMode(modes []string, mode string) bool {
@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions that apply the defaults of the current context of the
+// configuration to the command line.
+
+package arguments
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+// currentContext holds the defaults of the current context, empty when there is none.
+var currentContext = &config.Context{}
+
+// LoadContext loads the current context of the configuration file. Commands that require a
+// cluster use the cluster of the context when the '--cluster' flag isn't given.
+func LoadContext(cmd *cobra.Command) error {
+	cfg, err := config.LoadSettings()
+	if err != nil {
+		return err
+	}
+	context := cfg.GetCurrentContext()
+	if context == nil {
+		return nil
+	}
+	currentContext = context
+
+	flag := cmd.Flags().Lookup("cluster")
+	if flag == nil || flag.Changed || context.Cluster == "" {
+		return nil
+	}
+	// Only required cluster flags are defaulted, as leaving optional ones empty usually means
+	// 'all the clusters'
+	required, ok := flag.Annotations[cobra.BashCompOneRequiredFlag]
+	if !ok || len(required) == 0 || required[0] != "true" {
+		return nil
+	}
+	err = cmd.Flags().Set("cluster", context.Cluster)
+	if err != nil {
+		return fmt.Errorf("Failed to use cluster '%s' of context '%s': %v", context.Cluster,
+			cfg.CurrentContext, err)
+	}
+	return nil
+}
+
+// GetEnv returns the OCM environment of the current context, if any.
+func GetEnv() string {
+	return currentContext.Env
+}
@@ -0,0 +1,81 @@
+package arguments
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+var _ = Describe("Contexts", func() {
+	var cluster string
+
+	buildCmd := func(required bool) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringVarP(&cluster, "cluster", "c", "", "")
+		if required {
+			cmd.MarkFlagRequired("cluster")
+		}
+		return cmd
+	}
+
+	BeforeEach(func() {
+		cluster = ""
+		currentContext = &config.Context{}
+		os.Setenv("OCM_CONFIG", filepath.Join(GinkgoT().TempDir(), "ocm.json"))
+		os.Unsetenv("AWS_PROFILE")
+		os.Unsetenv("AWS_REGION")
+
+		cfg := &config.Config{}
+		Expect(cfg.SetContext("prod", &config.Context{
+			Env:     "production",
+			Profile: "prod-aws",
+			Region:  "us-east-1",
+			Cluster: "main",
+		})).To(Succeed())
+		Expect(cfg.UseContext("prod")).To(Succeed())
+		Expect(config.Save(cfg)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("OCM_CONFIG")
+		currentContext = &config.Context{}
+	})
+
+	It("Uses the defaults of the current context", func() {
+		Expect(LoadContext(&cobra.Command{})).To(Succeed())
+		Expect(GetProfile()).To(Equal("prod-aws"))
+		Expect(GetRegion()).To(Equal("us-east-1"))
+		Expect(GetEnv()).To(Equal("production"))
+	})
+
+	It("Prefers the environment over the context", func() {
+		os.Setenv("AWS_REGION", "eu-west-1")
+		defer os.Unsetenv("AWS_REGION")
+		Expect(LoadContext(&cobra.Command{})).To(Succeed())
+		Expect(GetRegion()).To(Equal("eu-west-1"))
+	})
+
+	It("Uses the cluster of the context for required cluster flags", func() {
+		cmd := buildCmd(true)
+		Expect(LoadContext(cmd)).To(Succeed())
+		Expect(cluster).To(Equal("main"))
+		Expect(cmd.Flags().Lookup("cluster").Changed).To(BeTrue())
+	})
+
+	It("Doesn't override the cluster given", func() {
+		cmd := buildCmd(true)
+		Expect(cmd.Flags().Set("cluster", "other")).To(Succeed())
+		Expect(LoadContext(cmd)).To(Succeed())
+		Expect(cluster).To(Equal("other"))
+	})
+
+	It("Doesn't use the cluster of the context for optional cluster flags", func() {
+		Expect(LoadContext(buildCmd(false))).To(Succeed())
+		Expect(cluster).To(BeEmpty())
+	})
+})
@@ -54,8 +54,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/zgalor/weberr"
 
-	"github.com/openshift/rosa/pkg/aws/profile"
-	regionflag "github.com/openshift/rosa/pkg/aws/region"
+	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/info"
 	"github.com/openshift/rosa/pkg/logging"
@@ -287,7 +286,7 @@ func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey) (*s
 func (b *ClientBuilder) BuildSessionWithOptions() (*session.Session, error) {
 	return session.NewSessionWithOptions(session.Options{
 		SharedConfigState: session.SharedConfigEnable,
-		Profile:           profile.Profile(),
+		Profile:           arguments.GetProfile(),
 		Config: aws.Config{
 			CredentialsChainVerboseErrors: aws.Bool(true),
 			Region:                        b.region,
@@ -313,7 +312,7 @@ func (b *ClientBuilder) Build() (Client, error) {
 	var sess *session.Session
 
 	if b.region == nil || *b.region == "" {
-		region, err := GetRegion(regionflag.Region())
+		region, err := GetRegion(arguments.GetRegion())
 		if err != nil {
 			return nil, err
 		}
@@ -339,8 +338,8 @@ func (b *ClientBuilder) Build() (Client, error) {
 	// Add ROSACLI as user-agent
 	sess.Handlers.Build.PushFrontNamed(addROSAVersionToUserAgent)
 
-	if profile.Profile() != "" {
-		b.logger.Debugf("Using AWS profile: %s", profile.Profile())
+	if arguments.GetProfile() != "" {
+		b.logger.Debugf("Using AWS profile: %s", arguments.GetProfile())
 	}
 
 	// Check that the AWS credentials are available:
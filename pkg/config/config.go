@@ -21,6 +21,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -34,8 +35,31 @@ import (
 	"github.com/openshift/rosa/pkg/debug"
 )
 
-// Config is the type used to store the configuration of the client.
+// Config is the type used to store the configuration of the client. The fields of the embedded
+// session hold the credentials of the session in use.
 type Config struct {
+	Session
+
+	// Sessions other than the default one, whose credentials are stored at the top level
+	Sessions       map[string]*Session `json:"sessions,omitempty"`
+	CurrentSession string              `json:"current_session,omitempty"`
+
+	// Named sets of defaults for the command line options, like kubeconfig contexts
+	Contexts       map[string]*Context `json:"contexts,omitempty"`
+	CurrentContext string              `json:"current_context,omitempty"`
+
+	// Where the tokens are stored, empty or 'file' for the configuration file and 'keyring' for
+	// the keyring
+	TokenStorage string `json:"token_storage,omitempty"`
+
+	// Name of the session in use, empty for the default one, and the credentials of the default
+	// session while another one is in use
+	session        string
+	defaultSession Session
+}
+
+// Session is the type used to store the credentials used to connect to an environment.
+type Session struct {
 	AccessToken  string   `json:"access_token,omitempty"`
 	ClientID     string   `json:"client_id,omitempty"`
 	ClientSecret string   `json:"client_secret,omitempty"`
@@ -47,13 +71,73 @@ type Config struct {
 	FedRAMP      bool     `json:"fedramp,omitempty"`
 }
 
-// Load loads the configuration from the configuration file. If the configuration file doesn't exist
-// it will return an empty configuration object.
+// Load loads the configuration from the configuration file, including the tokens stored in the
+// keyring. If the configuration file doesn't exist it will return a nil configuration object. If
+// the selected session doesn't exist it returns the configuration switched to that empty session
+// together with an error wrapping ErrSessionNotFound, so that logging in can create it.
 func Load() (cfg *Config, err error) {
 	file, err := Location()
 	if err != nil {
 		return
 	}
+	cfg, err = read(file)
+	if err != nil {
+		return
+	}
+	if cfg == nil {
+		err = checkSession(nil, selectedSession(new(Config)))
+		return
+	}
+	name := selectedSession(cfg)
+	sessionErr := checkSession(cfg, name)
+	if sessionErr != nil && !errors.Is(sessionErr, ErrSessionNotFound) {
+		cfg = nil
+		err = sessionErr
+		return
+	}
+	// The secrets are stored by session name, so they need to be loaded before switching to the
+	// selected session, while the sessions are still as stored in the file:
+	if cfg.UsesKeyring() {
+		err = cfg.loadSecrets()
+		if err != nil {
+			cfg = nil
+			return
+		}
+	}
+	cfg.SwitchSession(name)
+	err = sessionErr
+	return
+}
+
+// LoadSettings loads the configuration from the configuration file without retrieving the tokens
+// stored in the keyring, for callers that don't need to connect to OCM. If the configuration file
+// doesn't exist it will return a nil configuration object. Only the name of the selected session is
+// checked, as settings don't depend on the session.
+func LoadSettings() (cfg *Config, err error) {
+	file, err := Location()
+	if err != nil {
+		return
+	}
+	cfg, err = read(file)
+	if err != nil {
+		return
+	}
+	if cfg == nil {
+		err = checkSession(nil, selectedSession(new(Config)))
+		return
+	}
+	name := selectedSession(cfg)
+	err = checkSession(nil, name)
+	if err != nil {
+		cfg = nil
+		return
+	}
+	cfg.SwitchSession(name)
+	return
+}
+
+// read reads the configuration file as it is stored, returning nil if it doesn't exist.
+func read(file string) (cfg *Config, err error) {
 	_, err = os.Stat(file)
 	if os.IsNotExist(err) {
 		cfg = nil
@@ -73,24 +157,33 @@ func Load() (cfg *Config, err error) {
 	cfg = new(Config)
 	err = json.Unmarshal(data, cfg)
 	if err != nil {
+		cfg = nil
 		err = fmt.Errorf("Failed to parse config file '%s': %v", file, err)
 		return
 	}
 	return
 }
 
-// Save saves the given configuration to the configuration file.
+// Save saves the given configuration to the configuration file, moving the tokens to the keyring
+// when it is used.
 func Save(cfg *Config) error {
 	file, err := Location()
 	if err != nil {
 		return err
 	}
+	// The previous file is only needed to clean up the keyring, so one that can't be read is just
+	// overwritten:
+	previous, _ := read(file)
+	stored, err := cfg.stored().saveSecrets(previous)
+	if err != nil {
+		return err
+	}
 	dir := filepath.Dir(file)
 	err = os.MkdirAll(dir, os.FileMode(0755))
 	if err != nil {
 		return fmt.Errorf("Failed to create directory %s: %v", dir, err)
 	}
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	data, err := json.MarshalIndent(stored, "", "  ")
 	if err != nil {
 		return fmt.Errorf("Failed to marshal config: %v", err)
 	}
@@ -111,6 +204,13 @@ func Remove() error {
 	if os.IsNotExist(err) {
 		return nil
 	}
+	cfg, _ := read(file)
+	if cfg != nil && cfg.UsesKeyring() {
+		err = cfg.deleteSecrets(nil)
+		if err != nil {
+			return err
+		}
+	}
 	err = os.Remove(file)
 	if err != nil {
 		return err
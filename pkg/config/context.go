@@ -0,0 +1,108 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to manage the named contexts of the
+// configuration.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+var contextNameRE = regexp.MustCompile(`^[a-zA-Z0-9]([-_.a-zA-Z0-9]*[a-zA-Z0-9])?$`)
+
+// Context holds the defaults used by commands when the corresponding options aren't given.
+type Context struct {
+	Env     string `json:"env,omitempty"`
+	Profile string `json:"profile,omitempty"`
+	Region  string `json:"region,omitempty"`
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// ValidateContextName checks that a context name can be safely used in the command line.
+func ValidateContextName(name string) error {
+	if !contextNameRE.MatchString(name) {
+		return fmt.Errorf("Context name '%s' isn't valid: it must contain only letters, digits, "+
+			"dashes, underscores and dots, and start and end with a letter or digit", name)
+	}
+	return nil
+}
+
+// GetContext returns the context with the given name, or nil if it doesn't exist.
+func (c *Config) GetContext(name string) *Context {
+	if c == nil || name == "" {
+		return nil
+	}
+	return c.Contexts[name]
+}
+
+// GetCurrentContext returns the context in use, or nil if there is none.
+func (c *Config) GetCurrentContext() *Context {
+	if c == nil {
+		return nil
+	}
+	return c.GetContext(c.CurrentContext)
+}
+
+// SetContext creates or replaces the context with the given name.
+func (c *Config) SetContext(name string, context *Context) error {
+	err := ValidateContextName(name)
+	if err != nil {
+		return err
+	}
+	if c.Contexts == nil {
+		c.Contexts = map[string]*Context{}
+	}
+	c.Contexts[name] = context
+	return nil
+}
+
+// UseContext makes the context with the given name the one in use.
+func (c *Config) UseContext(name string) error {
+	if c.GetContext(name) == nil {
+		return fmt.Errorf("There is no context named '%s'", name)
+	}
+	c.CurrentContext = name
+	return nil
+}
+
+// DeleteContext deletes the context with the given name, which stops being in use if it was.
+func (c *Config) DeleteContext(name string) error {
+	if c.GetContext(name) == nil {
+		return fmt.Errorf("There is no context named '%s'", name)
+	}
+	delete(c.Contexts, name)
+	if c.CurrentContext == name {
+		c.CurrentContext = ""
+	}
+	return nil
+}
+
+// ContextNames returns the names of the contexts sorted alphabetically.
+func (c *Config) ContextNames() []string {
+	names := []string{}
+	if c == nil {
+		return names
+	}
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,61 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+var _ = Describe("Contexts", func() {
+	var cfg *config.Config
+
+	BeforeEach(func() {
+		cfg = &config.Config{}
+		Expect(cfg.SetContext("prod", &config.Context{Env: "production", Region: "us-east-1", Cluster: "main"})).To(Succeed())
+		Expect(cfg.SetContext("dev", &config.Context{Env: "staging"})).To(Succeed())
+	})
+
+	It("Has no current context until one is used", func() {
+		Expect(cfg.GetCurrentContext()).To(BeNil())
+		Expect(cfg.UseContext("prod")).To(Succeed())
+		Expect(cfg.GetCurrentContext().Cluster).To(Equal("main"))
+	})
+
+	It("Fails to use contexts that don't exist", func() {
+		Expect(cfg.UseContext("test")).To(MatchError("There is no context named 'test'"))
+		Expect(cfg.CurrentContext).To(BeEmpty())
+	})
+
+	It("Rejects invalid names", func() {
+		Expect(cfg.SetContext("-prod", &config.Context{})).NotTo(Succeed())
+		Expect(cfg.SetContext("prod env", &config.Context{})).NotTo(Succeed())
+		Expect(cfg.SetContext("prod.us-east_1", &config.Context{})).To(Succeed())
+	})
+
+	It("Stops using deleted contexts", func() {
+		Expect(cfg.UseContext("prod")).To(Succeed())
+		Expect(cfg.DeleteContext("prod")).To(Succeed())
+		Expect(cfg.GetCurrentContext()).To(BeNil())
+		Expect(cfg.ContextNames()).To(Equal([]string{"dev"}))
+	})
+
+	It("Lists names sorted", func() {
+		Expect(cfg.ContextNames()).To(Equal([]string{"dev", "prod"}))
+	})
+
+	It("Saves contexts to the configuration file", func() {
+		os.Setenv("OCM_CONFIG", filepath.Join(GinkgoT().TempDir(), "ocm.json"))
+		defer os.Unsetenv("OCM_CONFIG")
+
+		Expect(cfg.UseContext("prod")).To(Succeed())
+		Expect(config.Save(cfg)).To(Succeed())
+		loaded, err := config.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.CurrentContext).To(Equal("prod"))
+		Expect(loaded.GetCurrentContext()).To(Equal(&config.Context{Env: "production", Region: "us-east-1", Cluster: "main"}))
+	})
+})
@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to store the tokens of the configuration in a
+// keyring instead of in the configuration file.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// TokenStorageFile stores the tokens in the configuration file, in plain text.
+	TokenStorageFile = "file"
+
+	// TokenStorageKeyring stores the tokens in the keyring of the operating system, or in an
+	// encrypted file when there is no keyring.
+	TokenStorageKeyring = "keyring"
+)
+
+// TokenStorages contains the valid values of the token storage.
+var TokenStorages = []string{TokenStorageFile, TokenStorageKeyring}
+
+// ErrKeyNotFound is returned by keyrings when there is no value for a key.
+var ErrKeyNotFound = errors.New("Key not found in keyring")
+
+// Keyring is the interface of the backends that store the tokens.
+type Keyring interface {
+	// Get returns the value of a key, or ErrKeyNotFound when it doesn't exist.
+	Get(key string) (string, error)
+
+	// Set creates or replaces the value of a key.
+	Set(key string, value string) error
+
+	// Delete removes the value of a key, if it exists.
+	Delete(key string) error
+}
+
+// keyring is the backend used to store the tokens, selected the first time it is needed.
+var keyring Keyring
+
+// SetKeyring replaces the backend used to store the tokens.
+func SetKeyring(k Keyring) {
+	keyring = k
+}
+
+// getKeyring returns the backend used to store the tokens: the Secret Service when it is
+// available, otherwise an encrypted file next to the configuration file.
+func getKeyring() (Keyring, error) {
+	if keyring != nil {
+		return keyring, nil
+	}
+	if secretServiceAvailable() {
+		keyring = &secretServiceKeyring{}
+		return keyring, nil
+	}
+	file, err := Location()
+	if err != nil {
+		return nil, err
+	}
+	keyring = NewFileKeyring(filepath.Join(filepath.Dir(file), "ocm.keyring"), filePassphrase)
+	return keyring, nil
+}
+
+// UsesKeyring checks if the tokens are stored in the keyring.
+func (c *Config) UsesKeyring() bool {
+	return c.TokenStorage == TokenStorageKeyring
+}
+
+// secrets contains the fields of a session that are stored in the keyring.
+type secrets struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// storedSessions returns the sessions of a configuration as stored in the file, by name.
+func (c *Config) storedSessions() map[string]*Session {
+	sessions := map[string]*Session{DefaultSession: &c.Session}
+	for name, session := range c.Sessions {
+		sessions[name] = session
+	}
+	return sessions
+}
+
+// loadSecrets fills the sessions of a configuration read from the file with the secrets stored in
+// the keyring. It must be called before switching sessions, as the default session is the one
+// stored at the top level.
+func (c *Config) loadSecrets() error {
+	k, err := getKeyring()
+	if err != nil {
+		return err
+	}
+	for name, session := range c.storedSessions() {
+		value, err := k.Get(name)
+		if errors.Is(err, ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to get tokens of session '%s' from keyring: %v", name, err)
+		}
+		var s secrets
+		err = json.Unmarshal([]byte(value), &s)
+		if err != nil {
+			return fmt.Errorf("Failed to parse tokens of session '%s' from keyring: %v", name, err)
+		}
+		session.AccessToken = s.AccessToken
+		session.ClientSecret = s.ClientSecret
+		session.RefreshToken = s.RefreshToken
+	}
+	return nil
+}
+
+// saveSecrets moves the secrets of the sessions of a configuration about to be stored in the file
+// to the keyring, and removes from the keyring the sessions of the previous configuration file that
+// no longer exist. It returns the configuration to store in the file.
+func (c *Config) saveSecrets(previous *Config) (*Config, error) {
+	stored := *c
+	stored.Sessions = map[string]*Session{}
+	current := map[string]bool{}
+	if c.UsesKeyring() {
+		k, err := getKeyring()
+		if err != nil {
+			return nil, err
+		}
+		for name, session := range c.storedSessions() {
+			if !session.HasCredentials() {
+				continue
+			}
+			data, err := json.Marshal(secrets{
+				AccessToken:  session.AccessToken,
+				ClientSecret: session.ClientSecret,
+				RefreshToken: session.RefreshToken,
+			})
+			if err != nil {
+				return nil, err
+			}
+			err = k.Set(name, string(data))
+			if err != nil {
+				return nil, fmt.Errorf("Failed to save tokens of session '%s' to keyring: %v", name, err)
+			}
+			current[name] = true
+		}
+		stored.Session = withoutSecrets(c.Session)
+		for name, session := range c.Sessions {
+			s := withoutSecrets(*session)
+			stored.Sessions[name] = &s
+		}
+	} else {
+		for name, session := range c.Sessions {
+			stored.Sessions[name] = session
+		}
+	}
+	if previous != nil && previous.UsesKeyring() {
+		err := previous.deleteSecrets(current)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &stored, nil
+}
+
+// deleteSecrets removes from the keyring the secrets of the sessions of a configuration read from
+// the file, except the ones that should be kept.
+func (c *Config) deleteSecrets(keep map[string]bool) error {
+	k, err := getKeyring()
+	if err != nil {
+		return err
+	}
+	for name := range c.storedSessions() {
+		if keep[name] {
+			continue
+		}
+		err = k.Delete(name)
+		if err != nil {
+			return fmt.Errorf("Failed to remove tokens of session '%s' from keyring: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// withoutSecrets returns a copy of a session without the fields stored in the keyring.
+func withoutSecrets(session Session) Session {
+	session.AccessToken = ""
+	session.ClientSecret = ""
+	session.RefreshToken = ""
+	return session
+}
+
+// filePassphrase returns the passphrase of the encrypted file used when there is no keyring, from
+// the environment or asking the user.
+func filePassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv("ROSA_KEYRING_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+	if confirm {
+		return readPassphrase("Confirm passphrase of the token keyring: ")
+	}
+	return readPassphrase("Passphrase of the token keyring: ")
+}
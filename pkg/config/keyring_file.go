@@ -0,0 +1,234 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the keyring that stores the tokens in a file encrypted with a passphrase,
+// used when the operating system doesn't provide a keyring.
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/term"
+)
+
+// fileKeyringIterations is the number of iterations used to derive the encryption key from the
+// passphrase.
+const fileKeyringIterations = 600000
+
+// fileKeyring is a keyring that stores the values in a file encrypted with AES-GCM, using a key
+// derived from a passphrase.
+type fileKeyring struct {
+	path       string
+	passphrase func(confirm bool) (string, error)
+
+	// Salt of the file and key derived from it, kept to avoid deriving the key for every
+	// operation
+	salt []byte
+	key  []byte
+}
+
+// fileKeyringData is the content of the file of the keyring.
+type fileKeyringData struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// NewFileKeyring creates a keyring that stores the values in the given file, encrypted with the
+// passphrase returned by the given function. The function is called when the file is first read
+// or written, and again only if the passphrase turns out to be wrong. When the file is created it
+// is called a second time with confirm set, and both passphrases have to match.
+func NewFileKeyring(path string, passphrase func(confirm bool) (string, error)) Keyring {
+	return &fileKeyring{
+		path:       path,
+		passphrase: passphrase,
+	}
+}
+
+func (k *fileKeyring) Get(key string) (string, error) {
+	values, err := k.read()
+	if err != nil {
+		return "", err
+	}
+	value, ok := values[key]
+	if !ok {
+		return "", ErrKeyNotFound
+	}
+	return value, nil
+}
+
+func (k *fileKeyring) Set(key string, value string) error {
+	values, err := k.read()
+	if err != nil {
+		return err
+	}
+	values[key] = value
+	return k.write(values)
+}
+
+func (k *fileKeyring) Delete(key string) error {
+	_, err := os.Stat(k.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	values, err := k.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := values[key]; !ok {
+		return nil
+	}
+	delete(values, key)
+	if len(values) == 0 {
+		return os.Remove(k.path)
+	}
+	return k.write(values)
+}
+
+// read decrypts the values stored in the file, which are empty if the file doesn't exist.
+func (k *fileKeyring) read() (map[string]string, error) {
+	values := map[string]string{}
+	// #nosec G304
+	content, err := os.ReadFile(k.path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read keyring file '%s': %v", k.path, err)
+	}
+	var data fileKeyringData
+	err = json.Unmarshal(content, &data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse keyring file '%s': %v", k.path, err)
+	}
+	gcm, err := k.cipher(data.Salt, false)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, data.Nonce, data.Data, nil)
+	if err != nil {
+		// Forget the key so that the passphrase is asked again:
+		k.salt = nil
+		k.key = nil
+		return nil, fmt.Errorf("Failed to decrypt keyring file '%s', the passphrase may be wrong", k.path)
+	}
+	err = json.Unmarshal(plain, &values)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse keyring file '%s': %v", k.path, err)
+	}
+	return values, nil
+}
+
+// write encrypts the values and stores them in the file.
+func (k *fileKeyring) write(values map[string]string) error {
+	// There is no salt yet when the file is created
+	salt := k.salt
+	create := salt == nil
+	if create {
+		salt = make([]byte, 16)
+		_, err := io.ReadFull(rand.Reader, salt)
+		if err != nil {
+			return err
+		}
+	}
+	gcm, err := k.cipher(salt, create)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(fileKeyringData{
+		Salt:  salt,
+		Nonce: nonce,
+		Data:  gcm.Seal(nil, nonce, plain, nil),
+	})
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(k.path)
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return fmt.Errorf("Failed to create directory %s: %v", dir, err)
+	}
+	err = os.WriteFile(k.path, content, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to write keyring file '%s': %v", k.path, err)
+	}
+	return nil
+}
+
+// cipher returns the cipher that uses the key derived from the passphrase and the given salt,
+// asking to confirm the passphrase when the file is being created.
+func (k *fileKeyring) cipher(salt []byte, create bool) (cipher.AEAD, error) {
+	if k.key == nil || string(k.salt) != string(salt) {
+		passphrase, err := k.passphrase(false)
+		if err != nil {
+			return nil, err
+		}
+		if passphrase == "" {
+			return nil, fmt.Errorf("Passphrase of keyring file '%s' can't be empty", k.path)
+		}
+		if create {
+			confirmation, err := k.passphrase(true)
+			if err != nil {
+				return nil, err
+			}
+			if confirmation != passphrase {
+				return nil, fmt.Errorf("Passphrases of keyring file '%s' don't match", k.path)
+			}
+		}
+		k.salt = salt
+		k.key = pbkdf2.Key([]byte(passphrase), salt, fileKeyringIterations, 32, sha256.New)
+	}
+	block, err := aes.NewCipher(k.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readPassphrase asks the user for a passphrase without echoing it.
+func readPassphrase(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("Passphrase of the token keyring is required, " +
+			"set it in the ROSA_KEYRING_PASSPHRASE environment variable")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read passphrase: %v", err)
+	}
+	return string(passphrase), nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the keyring that stores the tokens in the Secret Service of the desktop
+// session, using the 'secret-tool' command of libsecret to talk to it over D-Bus.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService is the value of the 'service' attribute of the items stored in the keyring.
+const keyringService = "rosa"
+
+// secretServiceKeyring is a keyring that stores each value as an item of the Secret Service,
+// identified by the 'service' and 'session' attributes.
+type secretServiceKeyring struct{}
+
+// secretServiceAvailable checks if there is a D-Bus session and the 'secret-tool' command to talk
+// to the Secret Service.
+func secretServiceAvailable() bool {
+	if runtime.GOOS != "linux" || os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return false
+	}
+	_, err := exec.LookPath("secret-tool")
+	return err == nil
+}
+
+func (k *secretServiceKeyring) Get(key string) (string, error) {
+	stdout, err := k.run("", "lookup", "service", keyringService, "session", key)
+	if err != nil {
+		return "", err
+	}
+	if stdout == "" {
+		return "", ErrKeyNotFound
+	}
+	return stdout, nil
+}
+
+func (k *secretServiceKeyring) Set(key string, value string) error {
+	label := fmt.Sprintf("--label=ROSA tokens of session '%s'", key)
+	_, err := k.run(value, "store", label, "service", keyringService, "session", key)
+	return err
+}
+
+func (k *secretServiceKeyring) Delete(key string) error {
+	_, err := k.run("", "clear", "service", keyringService, "session", key)
+	return err
+}
+
+// run runs the 'secret-tool' command with the given input and arguments, and returns its output.
+// The 'lookup' command exits with status 1 without printing anything when there is no item
+// matching the attributes, which is reported as ErrKeyNotFound. Any other failure is an error, so
+// that tokens aren't lost when they can't be stored.
+func (k *secretServiceKeyring) run(stdin string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	// #nosec G204
+	cmd := exec.Command("secret-tool", args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if args[0] == "lookup" && errors.As(err, &exitErr) && exitErr.ExitCode() == 1 &&
+		stdout.Len() == 0 && stderr.Len() == 0 {
+		return "", ErrKeyNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("Failed to run 'secret-tool %s': %v: %s", args[0], err,
+			strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
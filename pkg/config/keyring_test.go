@@ -0,0 +1,252 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+// fakeKeyring is a keyring that keeps the values in memory.
+type fakeKeyring map[string]string
+
+func (k fakeKeyring) Get(key string) (string, error) {
+	value, ok := k[key]
+	if !ok {
+		return "", config.ErrKeyNotFound
+	}
+	return value, nil
+}
+
+func (k fakeKeyring) Set(key string, value string) error {
+	k[key] = value
+	return nil
+}
+
+func (k fakeKeyring) Delete(key string) error {
+	delete(k, key)
+	return nil
+}
+
+var _ = Describe("Keyring", func() {
+	var file string
+	var keyring fakeKeyring
+
+	readFile := func() string {
+		data, err := os.ReadFile(file)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	BeforeEach(func() {
+		file = filepath.Join(GinkgoT().TempDir(), "ocm.json")
+		os.Setenv("OCM_CONFIG", file)
+		os.Unsetenv("ROSA_SESSION")
+		keyring = fakeKeyring{}
+		config.SetKeyring(keyring)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("OCM_CONFIG")
+		config.SetKeyring(nil)
+	})
+
+	It("Keeps the tokens in the configuration file by default", func() {
+		cfg := config.New()
+		cfg.RefreshToken = "prod"
+		Expect(config.Save(cfg)).To(Succeed())
+		Expect(readFile()).To(ContainSubstring(`"refresh_token": "prod"`))
+		Expect(keyring).To(BeEmpty())
+	})
+
+	It("Migrates the tokens of every session to the keyring and back", func() {
+		Expect(os.WriteFile(file, []byte(`{
+			"refresh_token": "prod",
+			"url": "https://api.openshift.com",
+			"sessions": {"gov": {"refresh_token": "gov", "client_secret": "secret", "fedramp": true}}
+		}`), 0600)).To(Succeed())
+
+		cfg, err := config.Load()
+		Expect(err).NotTo(HaveOccurred())
+		cfg.TokenStorage = config.TokenStorageKeyring
+		Expect(config.Save(cfg)).To(Succeed())
+		Expect(readFile()).NotTo(ContainSubstring("prod"))
+		Expect(readFile()).NotTo(ContainSubstring("secret"))
+		Expect(keyring).To(HaveKey(config.DefaultSession))
+		Expect(keyring).To(HaveKey("gov"))
+
+		cfg, err = config.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.RefreshToken).To(Equal("prod"))
+		Expect(cfg.URL).To(Equal("https://api.openshift.com"))
+		gov := cfg.ForSession("gov")
+		Expect(gov.RefreshToken).To(Equal("gov"))
+		Expect(gov.ClientSecret).To(Equal("secret"))
+		Expect(gov.FedRAMP).To(BeTrue())
+		Expect(cfg.SessionNames()).To(Equal([]string{config.DefaultSession, "gov"}))
+
+		cfg.TokenStorage = ""
+		Expect(config.Save(cfg)).To(Succeed())
+		Expect(readFile()).To(ContainSubstring(`"refresh_token": "prod"`))
+		Expect(readFile()).To(ContainSubstring(`"refresh_token": "gov"`))
+		Expect(keyring).To(BeEmpty())
+	})
+
+	It("Removes the tokens of sessions logged out from the keyring", func() {
+		cfg := config.New()
+		cfg.TokenStorage = config.TokenStorageKeyring
+		cfg.RefreshToken = "prod"
+		cfg.SwitchSession("gov")
+		cfg.RefreshToken = "gov"
+		Expect(config.Save(cfg)).To(Succeed())
+		Expect(keyring).To(HaveLen(2))
+
+		cfg.Logout()
+		Expect(config.Save(cfg)).To(Succeed())
+		Expect(keyring).To(HaveKey(config.DefaultSession))
+		Expect(keyring).NotTo(HaveKey("gov"))
+
+		Expect(config.Remove()).To(Succeed())
+		Expect(keyring).To(BeEmpty())
+		_, err := os.Stat(file)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	Context("Another session in use", func() {
+		BeforeEach(func() {
+			cfg := config.New()
+			cfg.TokenStorage = config.TokenStorageKeyring
+			cfg.RefreshToken = "prod"
+			cfg.URL = "https://api.openshift.com"
+			cfg.SwitchSession("gov")
+			cfg.RefreshToken = "gov"
+			cfg.URL = "https://api.openshiftusgov.com"
+			Expect(config.Save(cfg)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("ROSA_SESSION")
+		})
+
+		It("Loads the tokens of the current session", func() {
+			cfg, err := config.Load()
+			Expect(err).NotTo(HaveOccurred())
+			cfg.CurrentSession = "gov"
+			Expect(config.Save(cfg)).To(Succeed())
+
+			cfg, err = config.Load()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.SessionName()).To(Equal("gov"))
+			Expect(cfg.RefreshToken).To(Equal("gov"))
+			Expect(cfg.URL).To(Equal("https://api.openshiftusgov.com"))
+			Expect(cfg.ForSession(config.DefaultSession).RefreshToken).To(Equal("prod"))
+
+			// Saving again keeps the tokens of each session
+			Expect(config.Save(cfg)).To(Succeed())
+			Expect(keyring["gov"]).To(ContainSubstring(`"refresh_token":"gov"`))
+			Expect(keyring[config.DefaultSession]).To(ContainSubstring(`"refresh_token":"prod"`))
+		})
+
+		It("Loads the tokens of the session selected in the environment", func() {
+			os.Setenv("ROSA_SESSION", "gov")
+			cfg, err := config.Load()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.SessionName()).To(Equal("gov"))
+			Expect(cfg.RefreshToken).To(Equal("gov"))
+			Expect(cfg.URL).To(Equal("https://api.openshiftusgov.com"))
+
+			os.Setenv("ROSA_SESSION", config.DefaultSession)
+			cfg, err = config.Load()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.RefreshToken).To(Equal("prod"))
+			Expect(cfg.URL).To(Equal("https://api.openshift.com"))
+		})
+	})
+
+	It("Doesn't read the keyring to load the settings", func() {
+		cfg := config.New()
+		cfg.TokenStorage = config.TokenStorageKeyring
+		cfg.RefreshToken = "prod"
+		Expect(config.Save(cfg)).To(Succeed())
+
+		cfg, err := config.LoadSettings()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.UsesKeyring()).To(BeTrue())
+		Expect(cfg.RefreshToken).To(BeEmpty())
+	})
+
+	Context("Encrypted file", func() {
+		var path string
+		var passphrase string
+		var confirmation string
+		var asked int
+		var confirmed int
+
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "ocm.keyring")
+			passphrase = "passphrase"
+			confirmation = "passphrase"
+			asked = 0
+			confirmed = 0
+		})
+
+		newKeyring := func() config.Keyring {
+			return config.NewFileKeyring(path, func(confirm bool) (string, error) {
+				if confirm {
+					confirmed++
+					return confirmation, nil
+				}
+				asked++
+				return passphrase, nil
+			})
+		}
+
+		It("Stores the values encrypted", func() {
+			k := newKeyring()
+			_, err := k.Get("default")
+			Expect(err).To(MatchError(config.ErrKeyNotFound))
+			Expect(k.Set("default", `{"refresh_token":"prod"}`)).To(Succeed())
+			Expect(k.Set("gov", `{"refresh_token":"gov"}`)).To(Succeed())
+			Expect(asked).To(Equal(1))
+			Expect(confirmed).To(Equal(1))
+
+			data, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).NotTo(ContainSubstring("prod"))
+
+			k = newKeyring()
+			value, err := k.Get("default")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal(`{"refresh_token":"prod"}`))
+			Expect(confirmed).To(Equal(1))
+			Expect(k.Delete("default")).To(Succeed())
+			Expect(k.Delete("default")).To(Succeed())
+			_, err = k.Get("default")
+			Expect(err).To(MatchError(config.ErrKeyNotFound))
+
+			Expect(k.Delete("gov")).To(Succeed())
+			_, err = os.Stat(path)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("Fails to create the file when the confirmation doesn't match", func() {
+			confirmation = "other"
+			err := newKeyring().Set("default", "prod")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("don't match"))
+			_, err = os.Stat(path)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("Fails with the wrong passphrase", func() {
+			Expect(newKeyring().Set("default", "prod")).To(Succeed())
+			passphrase = "wrong"
+			_, err := newKeyring().Get("default")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("passphrase may be wrong"))
+		})
+	})
+})
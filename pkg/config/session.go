@@ -0,0 +1,203 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to manage the sessions of the configuration,
+// which allow staying logged in to several accounts or environments at once.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultSession is the name of the session stored at the top level of the configuration file,
+// which is the only one of configuration files written by previous versions.
+const DefaultSession = "default"
+
+var sessionNameRE = regexp.MustCompile(`^[a-zA-Z0-9]([-_.a-zA-Z0-9]*[a-zA-Z0-9])?$`)
+
+// ErrSessionNotFound is returned when the selected session doesn't exist in the configuration file.
+var ErrSessionNotFound = errors.New("Session not found")
+
+// AddSessionFlag adds the session flag to the given set of command line flags.
+func AddSessionFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&sessionFlag,
+		"session",
+		"",
+		"Use the credentials of a specific session, overriding the ROSA_SESSION environment variable "+
+			"and the current session.",
+	)
+}
+
+// sessionFlag is a string flag that indicates which session is being used.
+var sessionFlag string
+
+// selectedSession returns the name of the session to use, from the command line, the environment
+// or the configuration file.
+func selectedSession(cfg *Config) string {
+	if sessionFlag != "" {
+		return sessionFlag
+	}
+	if session := os.Getenv("ROSA_SESSION"); session != "" {
+		return session
+	}
+	return cfg.CurrentSession
+}
+
+// checkSession checks that the name of the selected session is valid and, when the configuration
+// is given, that the session exists in it.
+func checkSession(cfg *Config, name string) error {
+	if name == "" || name == DefaultSession {
+		return nil
+	}
+	err := ValidateSessionName(name)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return nil
+	}
+	if _, ok := cfg.Sessions[name]; !ok {
+		return fmt.Errorf("%w: '%s', run 'rosa login --name %s' to create it", ErrSessionNotFound, name, name)
+	}
+	return nil
+}
+
+// New returns an empty configuration that uses the selected session.
+func New() *Config {
+	cfg := new(Config)
+	cfg.SwitchSession(selectedSession(cfg))
+	return cfg
+}
+
+// ValidateSessionName checks that a session name can be safely used in the command line.
+func ValidateSessionName(name string) error {
+	if !sessionNameRE.MatchString(name) {
+		return fmt.Errorf("Session name '%s' isn't valid: it must contain only letters, digits, "+
+			"dashes, underscores and dots, and start and end with a letter or digit", name)
+	}
+	return nil
+}
+
+// HasCredentials checks if the session contains tokens or client credentials.
+func (s *Session) HasCredentials() bool {
+	return s.AccessToken != "" || s.RefreshToken != "" || s.ClientSecret != ""
+}
+
+// SessionName returns the name of the session in use.
+func (c *Config) SessionName() string {
+	if c.session == "" {
+		return DefaultSession
+	}
+	return c.session
+}
+
+// SwitchSession makes the configuration use the credentials of the session with the given name,
+// which are empty if the session doesn't exist yet. Saving the configuration saves them to that
+// session.
+func (c *Config) SwitchSession(name string) {
+	if name == DefaultSession {
+		name = ""
+	}
+	if name == c.session {
+		return
+	}
+	if c.session == "" {
+		c.defaultSession = c.Session
+	} else {
+		c.storeSession(c.session, c.Session)
+	}
+	c.session = name
+	c.Session = Session{}
+	if name == "" {
+		c.Session = c.defaultSession
+	} else if session, ok := c.Sessions[name]; ok {
+		c.Session = *session
+	}
+}
+
+// ForSession returns a copy of the configuration that uses the session with the given name.
+func (c *Config) ForSession(name string) *Config {
+	cfg := c.stored()
+	cfg.SwitchSession(name)
+	return cfg
+}
+
+// SessionNames returns the names of the sessions that have credentials, the default one first and
+// the rest sorted alphabetically.
+func (c *Config) SessionNames() []string {
+	stored := c.stored()
+	names := []string{}
+	for name, session := range stored.Sessions {
+		if session.HasCredentials() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if stored.Session.HasCredentials() {
+		names = append([]string{DefaultSession}, names...)
+	}
+	return names
+}
+
+// Logout removes the credentials of the session in use, which stops being the current session.
+func (c *Config) Logout() {
+	c.Session = Session{}
+	if c.CurrentSession == c.SessionName() {
+		c.CurrentSession = ""
+	}
+}
+
+// IsEmpty checks if there is nothing worth keeping in the configuration.
+func (c *Config) IsEmpty() bool {
+	return len(c.SessionNames()) == 0 && len(c.Contexts) == 0
+}
+
+// storeSession stores the credentials of a session other than the default one, removing the
+// session when they are empty
+func (c *Config) storeSession(name string, session Session) {
+	sessions := map[string]*Session{}
+	for key, value := range c.Sessions {
+		if key != name {
+			sessions[key] = value
+		}
+	}
+	if session.HasCredentials() {
+		sessions[name] = &session
+	}
+	c.Sessions = sessions
+}
+
+// stored returns the configuration as it is stored in the file, with the credentials of the
+// default session at the top level and the ones of the rest in the sessions.
+func (c *Config) stored() *Config {
+	stored := *c
+	if c.session == "" {
+		return &stored
+	}
+	stored.Session = c.defaultSession
+	stored.session = ""
+	stored.defaultSession = Session{}
+	stored.storeSession(c.session, c.Session)
+	return &stored
+}
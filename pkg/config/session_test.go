@@ -0,0 +1,133 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+var _ = Describe("Sessions", func() {
+	var file string
+
+	BeforeEach(func() {
+		file = filepath.Join(GinkgoT().TempDir(), "ocm.json")
+		os.Setenv("OCM_CONFIG", file)
+		os.Unsetenv("ROSA_SESSION")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("OCM_CONFIG")
+		os.Unsetenv("ROSA_SESSION")
+	})
+
+	It("Loads configuration files without sessions into the default session", func() {
+		Expect(os.WriteFile(file, []byte(`{"refresh_token":"prod","url":"https://api.openshift.com"}`),
+			0600)).To(Succeed())
+		cfg, err := config.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.SessionName()).To(Equal(config.DefaultSession))
+		Expect(cfg.RefreshToken).To(Equal("prod"))
+		Expect(cfg.SessionNames()).To(Equal([]string{config.DefaultSession}))
+	})
+
+	It("Stores the refresh tokens of each session separately", func() {
+		cfg := config.New()
+		cfg.RefreshToken = "prod"
+		cfg.SwitchSession("gov")
+		Expect(cfg.RefreshToken).To(BeEmpty())
+		cfg.RefreshToken = "gov"
+		cfg.FedRAMP = true
+		cfg.CurrentSession = "gov"
+		Expect(config.Save(cfg)).To(Succeed())
+
+		cfg, err := config.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.SessionName()).To(Equal("gov"))
+		Expect(cfg.RefreshToken).To(Equal("gov"))
+		Expect(cfg.FedRAMP).To(BeTrue())
+		Expect(cfg.SessionNames()).To(Equal([]string{config.DefaultSession, "gov"}))
+		Expect(cfg.ForSession(config.DefaultSession).RefreshToken).To(Equal("prod"))
+		Expect(cfg.RefreshToken).To(Equal("gov"))
+	})
+
+	It("Uses the session selected by the environment", func() {
+		cfg := config.New()
+		cfg.RefreshToken = "prod"
+		cfg.SwitchSession("gov")
+		cfg.RefreshToken = "gov"
+		cfg.CurrentSession = "gov"
+		Expect(config.Save(cfg)).To(Succeed())
+
+		os.Setenv("ROSA_SESSION", config.DefaultSession)
+		cfg, err := config.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.SessionName()).To(Equal(config.DefaultSession))
+		Expect(cfg.RefreshToken).To(Equal("prod"))
+	})
+
+	It("Rejects invalid session names from the environment", func() {
+		os.Setenv("ROSA_SESSION", "gov cloud")
+		_, err := config.Load()
+		Expect(err).To(MatchError(ContainSubstring("Session name 'gov cloud' isn't valid")))
+
+		cfg := config.New()
+		cfg.RefreshToken = "prod"
+		os.Unsetenv("ROSA_SESSION")
+		Expect(config.Save(cfg)).To(Succeed())
+		os.Setenv("ROSA_SESSION", "gov cloud")
+		cfg, err = config.Load()
+		Expect(err).To(MatchError(ContainSubstring("Session name 'gov cloud' isn't valid")))
+		Expect(cfg).To(BeNil())
+		_, err = config.LoadSettings()
+		Expect(err).To(MatchError(ContainSubstring("Session name 'gov cloud' isn't valid")))
+	})
+
+	It("Reports sessions that don't exist while keeping the rest", func() {
+		cfg := config.New()
+		cfg.RefreshToken = "prod"
+		Expect(config.Save(cfg)).To(Succeed())
+
+		os.Setenv("ROSA_SESSION", "gov")
+		cfg, err := config.Load()
+		Expect(err).To(MatchError(config.ErrSessionNotFound))
+		Expect(err).To(MatchError(ContainSubstring("'gov'")))
+		Expect(cfg.SessionName()).To(Equal("gov"))
+		Expect(cfg.HasCredentials()).To(BeFalse())
+
+		// Logging in creates the session:
+		cfg.RefreshToken = "gov"
+		Expect(config.Save(cfg)).To(Succeed())
+		cfg, err = config.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.RefreshToken).To(Equal("gov"))
+		Expect(cfg.ForSession(config.DefaultSession).RefreshToken).To(Equal("prod"))
+	})
+
+	It("Logs out of the session in use only", func() {
+		cfg := config.New()
+		cfg.RefreshToken = "prod"
+		cfg.SwitchSession("gov")
+		cfg.RefreshToken = "gov"
+		cfg.CurrentSession = "gov"
+		cfg.Logout()
+		Expect(cfg.CurrentSession).To(BeEmpty())
+		Expect(cfg.SessionNames()).To(Equal([]string{config.DefaultSession}))
+		Expect(cfg.IsEmpty()).To(BeFalse())
+
+		cfg.SwitchSession(config.DefaultSession)
+		Expect(cfg.RefreshToken).To(Equal("prod"))
+		cfg.Logout()
+		Expect(cfg.IsEmpty()).To(BeTrue())
+	})
+
+	It("Rejects invalid names", func() {
+		Expect(config.ValidateSessionName("gov")).To(Succeed())
+		Expect(config.ValidateSessionName("prod.us-east_1")).To(Succeed())
+		Expect(config.ValidateSessionName("-gov")).NotTo(Succeed())
+		Expect(config.ValidateSessionName("gov cloud")).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,163 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Maximum time searched for the next or previous run of a schedule
+const searchLimit = 366 * 24 * time.Hour
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12,
+		names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7,
+		names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// Schedule is a standard cron expression with the minute, hour, day of month, month and day of
+// week fields, evaluated in UTC
+type Schedule struct {
+	expression string
+	values     [][]bool
+	// Whether the day of month and day of week fields are restricted, when both are a day matches
+	// if either of them matches
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// Parse parses a cron expression
+func Parse(expression string) (*Schedule, error) {
+	items := strings.Fields(expression)
+	if len(items) != len(fields) {
+		return nil, fmt.Errorf("Expected schedule '%s' to be a cron expression with %d fields, found %d",
+			expression, len(fields), len(items))
+	}
+	schedule := &Schedule{
+		expression:    expression,
+		values:        make([][]bool, len(fields)),
+		anyDayOfMonth: items[2] == "*",
+		anyDayOfWeek:  items[4] == "*",
+	}
+	for i, item := range items {
+		schedule.values[i] = make([]bool, fields[i].max+1)
+		for _, part := range strings.Split(item, ",") {
+			err := parsePart(part, fields[i], schedule.values[i])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid %s '%s' in schedule '%s': %v", fields[i].name, item, expression, err)
+			}
+		}
+	}
+	// Sunday can be written as 0 or 7
+	schedule.values[4][0] = schedule.values[4][0] || schedule.values[4][7]
+	return schedule, nil
+}
+
+func (s *Schedule) String() string {
+	return s.expression
+}
+
+// Matches checks if the schedule runs at the minute of the time
+func (s *Schedule) Matches(t time.Time) bool {
+	t = t.UTC()
+	if !s.values[0][t.Minute()] || !s.values[1][t.Hour()] || !s.values[3][int(t.Month())] {
+		return false
+	}
+	dayOfMonth := s.values[2][t.Day()]
+	dayOfWeek := s.values[4][int(t.Weekday())]
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Next returns the first time after the given one when the schedule runs, or the zero time if it
+// doesn't run within a year
+func (s *Schedule) Next(t time.Time) time.Time {
+	start := t.UTC().Truncate(time.Minute).Add(time.Minute)
+	for next := start; next.Sub(start) <= searchLimit; next = next.Add(time.Minute) {
+		if s.Matches(next) {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// Previous returns the last time up to the given one when the schedule ran, or the zero time if it
+// didn't run within a year
+func (s *Schedule) Previous(t time.Time) time.Time {
+	start := t.UTC().Truncate(time.Minute)
+	for previous := start; start.Sub(previous) <= searchLimit; previous = previous.Add(-time.Minute) {
+		if s.Matches(previous) {
+			return previous
+		}
+	}
+	return time.Time{}
+}
+
+func parsePart(part string, f field, values []bool) error {
+	rangePart := part
+	step := 1
+	if index := strings.Index(part, "/"); index >= 0 {
+		rangePart = part[:index]
+		var err error
+		step, err = strconv.Atoi(part[index+1:])
+		if err != nil || step < 1 {
+			return fmt.Errorf("expected step '%s' to be a positive number", part[index+1:])
+		}
+	}
+	first, last := f.min, f.max
+	if rangePart != "*" {
+		bounds := strings.Split(rangePart, "-")
+		if len(bounds) > 2 {
+			return fmt.Errorf("expected '%s' to be a value or a range", rangePart)
+		}
+		var err error
+		first, err = parseValue(bounds[0], f)
+		if err != nil {
+			return err
+		}
+		last = first
+		if len(bounds) == 2 {
+			last, err = parseValue(bounds[1], f)
+			if err != nil {
+				return err
+			}
+		} else if step > 1 {
+			// A single value with a step runs from that value to the end of the range
+			last = f.max
+		}
+		if first > last {
+			return fmt.Errorf("expected range '%s' to be increasing", rangePart)
+		}
+	}
+	for value := first; value <= last; value += step {
+		values[value] = true
+	}
+	return nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(value, name) {
+			return i + f.min, nil
+		}
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < f.min || number > f.max {
+		return 0, fmt.Errorf("expected '%s' to be a number between %d and %d", value, f.min, f.max)
+	}
+	return number, nil
+}
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	// Monday
+	now := time.Date(2023, time.May, 15, 10, 30, 20, 0, time.UTC)
+
+	DescribeTable("Next",
+		func(expression string, expected time.Time) {
+			schedule, err := Parse(expression)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Next(now)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2023, time.May, 15, 10, 31, 0, 0, time.UTC)),
+		Entry("weekly", "0 2 * * SUN", time.Date(2023, time.May, 21, 2, 0, 0, 0, time.UTC)),
+		Entry("sunday as seven", "0 2 * * 7", time.Date(2023, time.May, 21, 2, 0, 0, 0, time.UTC)),
+		Entry("week days", "0 20 * * 1-5", time.Date(2023, time.May, 15, 20, 0, 0, 0, time.UTC)),
+		Entry("steps", "*/20 * * * *", time.Date(2023, time.May, 15, 10, 40, 0, 0, time.UTC)),
+		Entry("day of month or day of week", "0 0 1 * FRI", time.Date(2023, time.May, 19, 0, 0, 0, 0, time.UTC)),
+		Entry("month", "0 0 1 jun *", time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("never", "0 0 31 2 *", time.Time{}),
+	)
+
+	DescribeTable("Previous",
+		func(expression string, expected time.Time) {
+			schedule, err := Parse(expression)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Previous(now)).To(Equal(expected))
+		},
+		Entry("current minute", "30 10 * * *", time.Date(2023, time.May, 15, 10, 30, 0, 0, time.UTC)),
+		Entry("weekly", "0 2 * * SUN", time.Date(2023, time.May, 14, 2, 0, 0, 0, time.UTC)),
+		Entry("week days", "0 20 * * 1-5", time.Date(2023, time.May, 12, 20, 0, 0, 0, time.UTC)),
+	)
+
+	DescribeTable("Invalid expressions",
+		func(expression string) {
+			_, err := Parse(expression)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "0 2 * *"),
+		Entry("minute out of range", "60 2 * * *"),
+		Entry("unknown day", "0 2 * * SOMEDAY"),
+		Entry("decreasing range", "0 5-1 * * *"),
+		Entry("invalid step", "*/0 2 * * *"),
+	)
+})
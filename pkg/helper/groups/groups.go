@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package groups
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper/ldap"
+	"github.com/openshift/rosa/pkg/helper/verify"
+)
+
+// Attributes of LDAP group entries that hold the members, either as DNs or as usernames
+var ldapMemberAttributes = []string{"member", "uniqueMember", "memberUid"}
+
+const githubPageSize = 100
+
+type Options struct {
+	// Secrets can't be read back from the service, so the bind password needs to be given again
+	LDAPBindPassword string
+	GithubToken      string
+	Timeout          time.Duration
+}
+
+// ResolveMembers returns the usernames that the members of a group of the identity provider
+// log in with. Groups are LDAP group DNs and GitHub teams in '<org>/<team>' format.
+func ResolveMembers(idp *cmv1.IdentityProvider, group string, options Options) ([]string, error) {
+	if options.Timeout == 0 {
+		options.Timeout = 10 * time.Second
+	}
+	switch idp.Type() {
+	case cmv1.IdentityProviderTypeLDAP:
+		return ldapGroupMembers(idp.LDAP(), group, options)
+	case cmv1.IdentityProviderTypeGithub:
+		return githubTeamMembers(idp.Github(), group, options)
+	case cmv1.IdentityProviderTypeHtpasswd:
+		return nil, fmt.Errorf("HTPasswd identity providers don't have groups")
+	default:
+		// OpenID groups claims are only sent in the tokens of the users when they log in
+		return nil, fmt.Errorf("Members of the groups of %s identity providers are only known when "+
+			"users log in, grant the users instead", idp.Type())
+	}
+}
+
+func ldapGroupMembers(provider *cmv1.LDAPIdentityProvider, group string, options Options) ([]string, error) {
+	searchURL, err := ldap.ParseSearchURL(provider.URL())
+	if err != nil {
+		return nil, err
+	}
+	config, err := verify.TLSConfig(provider.CA())
+	if err != nil {
+		return nil, err
+	}
+	config.ServerName, _, _ = net.SplitHostPort(searchURL.Host)
+	conn, err := ldap.Dial(searchURL, provider.Insecure(), config, options.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect to '%s': %v", searchURL.Host, err)
+	}
+	defer conn.Close()
+
+	bindPassword := provider.BindPassword()
+	if options.LDAPBindPassword != "" {
+		bindPassword = options.LDAPBindPassword
+	}
+	if provider.BindDN() != "" && bindPassword == "" {
+		return nil, fmt.Errorf("The bind password is needed to bind as '%s'", provider.BindDN())
+	}
+	err = conn.Bind(provider.BindDN(), bindPassword)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to bind as '%s': %v", ldap.BindDNString(provider.BindDN()), err)
+	}
+
+	entries, err := conn.Search(group, ldap.ScopeBase, "(objectClass=*)", ldapMemberAttributes, 1)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find group '%s': %v", group, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("Group '%s' does not exist", group)
+	}
+
+	// Members given as usernames don't need to be looked up
+	usernames := entries[0].Attributes["memberuid"]
+	usernameAttributes := provider.Attributes().PreferredUsername()
+	if len(usernameAttributes) == 0 {
+		usernameAttributes = []string{"uid"}
+	}
+	memberDNs := append(entries[0].Attributes["member"], entries[0].Attributes["uniquemember"]...)
+	for _, memberDN := range memberDNs {
+		members, err := conn.Search(memberDN, ldap.ScopeBase, "(objectClass=*)", usernameAttributes, 1)
+		if err != nil || len(members) == 0 {
+			return nil, fmt.Errorf("Failed to find member '%s' of group '%s': %v", memberDN, group, err)
+		}
+		username := ""
+		for _, attribute := range usernameAttributes {
+			values := members[0].Attributes[strings.ToLower(attribute)]
+			if len(values) > 0 {
+				username = values[0]
+				break
+			}
+		}
+		if username == "" {
+			return nil, fmt.Errorf("Member '%s' of group '%s' has none of the attributes %s",
+				memberDN, group, usernameAttributes)
+		}
+		usernames = append(usernames, username)
+	}
+	return usernames, nil
+}
+
+func githubTeamMembers(github *cmv1.GithubIdentityProvider, team string, options Options) ([]string,
+	error) {
+	tokens := strings.Split(team, "/")
+	if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+		return nil, fmt.Errorf("Expected GitHub team '%s' to have the '<org>/<team>' format", team)
+	}
+	if options.GithubToken == "" {
+		return nil, fmt.Errorf("A GitHub token is needed to list the members of team '%s'", team)
+	}
+	apiURL := "https://api.github.com"
+	if github.Hostname() != "" {
+		apiURL = fmt.Sprintf("https://%s/api/v3", github.Hostname())
+	}
+	config, err := verify.TLSConfig(github.CA())
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{
+		Timeout: options.Timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: config,
+		},
+	}
+
+	usernames := []string{}
+	for page := 1; ; page++ {
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/orgs/%s/teams/%s/members?per_page=%d&page=%d",
+			apiURL, url.PathEscape(tokens[0]), url.PathEscape(tokens[1]), githubPageSize, page), nil)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Accept", "application/vnd.github+json")
+		request.Header.Set("Authorization", "Bearer "+options.GithubToken)
+		response, err := client.Do(request)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list members of team '%s': %v", team, err)
+		}
+		members := []struct {
+			Login string `json:"login"`
+		}{}
+		if response.StatusCode == http.StatusOK {
+			err = json.NewDecoder(response.Body).Decode(&members)
+		} else {
+			err = fmt.Errorf("%s", response.Status)
+		}
+		response.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list members of team '%s': %v", team, err)
+		}
+		for _, member := range members {
+			usernames = append(usernames, member.Login)
+		}
+		if len(members) < githubPageSize {
+			return usernames, nil
+		}
+	}
+}
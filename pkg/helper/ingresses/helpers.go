@@ -0,0 +1,297 @@
+package ingresses
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Regular expression to used to make sure that the identifier given by the
+// user is safe and that it there is no risk of SQL injection:
+var IngressKeyRE = regexp.MustCompile(`^[a-z0-9]{3,5}$`)
+
+const (
+	ExcludedNamespacesFlag        = "excluded-namespaces"
+	WildcardPolicyFlag            = "wildcard-policy"
+	NamespaceOwnershipPolicyFlag  = "namespace-ownership-policy"
+	LoadBalancerTypeFlag          = "lb-type"
+	ClusterRoutesHostnameFlag     = "cluster-routes-hostname"
+	ClusterRoutesTLSSecretRefFlag = "cluster-routes-tls-secret-ref"
+)
+
+// RouterFlags holds the names of the flags of the router options
+var RouterFlags = []string{
+	ExcludedNamespacesFlag,
+	WildcardPolicyFlag,
+	NamespaceOwnershipPolicyFlag,
+	LoadBalancerTypeFlag,
+	ClusterRoutesHostnameFlag,
+	ClusterRoutesTLSSecretRefFlag,
+}
+
+type RouterArgs struct {
+	ExcludedNamespaces        string
+	WildcardPolicy            string
+	NamespaceOwnershipPolicy  string
+	LoadBalancerType          string
+	ClusterRoutesHostname     string
+	ClusterRoutesTLSSecretRef string
+}
+
+func AddRouterFlags(flags *pflag.FlagSet, args *RouterArgs) {
+	flags.StringVar(
+		&args.ExcludedNamespaces,
+		ExcludedNamespacesFlag,
+		"",
+		"Comma-separated list of namespaces whose routes are not exposed by the ingress. "+
+			"An empty value removes all the excluded namespaces.",
+	)
+
+	flags.StringVar(
+		&args.WildcardPolicy,
+		WildcardPolicyFlag,
+		"",
+		fmt.Sprintf("Policy for routes with wildcard hosts. Options are %s.",
+			strings.Join(ocm.WildcardPolicies, ", ")),
+	)
+
+	flags.StringVar(
+		&args.NamespaceOwnershipPolicy,
+		NamespaceOwnershipPolicyFlag,
+		"",
+		fmt.Sprintf("Policy for claiming the same hostname in different namespaces. Options are %s.",
+			strings.Join(ocm.NamespaceOwnershipPolicies, ", ")),
+	)
+
+	flags.StringVar(
+		&args.LoadBalancerType,
+		LoadBalancerTypeFlag,
+		"",
+		fmt.Sprintf("Type of AWS load balancer of the ingress. Options are %s.",
+			strings.Join(ocm.LoadBalancerTypes, ", ")),
+	)
+
+	flags.StringVar(
+		&args.ClusterRoutesHostname,
+		ClusterRoutesHostnameFlag,
+		"",
+		"Custom hostname for the console and OAuth routes of the cluster. "+
+			"Requires --"+ClusterRoutesTLSSecretRefFlag+".",
+	)
+
+	flags.StringVar(
+		&args.ClusterRoutesTLSSecretRef,
+		ClusterRoutesTLSSecretRefFlag,
+		"",
+		"Name of the TLS secret in the 'openshift-config' namespace with the default certificate "+
+			"served for the cluster routes hostname.",
+	)
+}
+
+// GetRouterOptions returns the router options set with flags, prompting for the rest in interactive
+// mode with the current options of the ingress as defaults
+func GetRouterOptions(flags *pflag.FlagSet, args *RouterArgs,
+	current *ocm.IngressRouterOptions) (*ocm.IngressRouterOptions, error) {
+	if current == nil {
+		current = &ocm.IngressRouterOptions{}
+	}
+	options := &ocm.IngressRouterOptions{}
+
+	if flags.Changed(ExcludedNamespacesFlag) || interactive.Enabled() {
+		value := args.ExcludedNamespaces
+		if !flags.Changed(ExcludedNamespacesFlag) {
+			dflt := ""
+			if current.ExcludedNamespaces != nil {
+				dflt = strings.Join(*current.ExcludedNamespaces, ",")
+			}
+			var err error
+			value, err = interactive.GetString(interactive.Input{
+				Question: "Excluded namespaces",
+				Help:     flags.Lookup(ExcludedNamespacesFlag).Usage,
+				Default:  dflt,
+				Validators: []interactive.Validator{
+					func(val interface{}) error {
+						_, err := ParseExcludedNamespaces(fmt.Sprint(val))
+						return err
+					},
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("Expected a valid comma-separated list of namespaces: %s", err)
+			}
+		}
+		namespaces, err := ParseExcludedNamespaces(value)
+		if err != nil {
+			return nil, err
+		}
+		options.ExcludedNamespaces = &namespaces
+	}
+
+	var err error
+	options.RouteWildcardPolicy, err = getRouterOption(flags, WildcardPolicyFlag, "Wildcard policy",
+		args.WildcardPolicy, current.RouteWildcardPolicy, ocm.WildcardPolicies)
+	if err != nil {
+		return nil, err
+	}
+	options.RouteNamespaceOwnershipPolicy, err = getRouterOption(flags, NamespaceOwnershipPolicyFlag,
+		"Namespace ownership policy", args.NamespaceOwnershipPolicy, current.RouteNamespaceOwnershipPolicy,
+		ocm.NamespaceOwnershipPolicies)
+	if err != nil {
+		return nil, err
+	}
+	options.LoadBalancerType, err = getRouterOption(flags, LoadBalancerTypeFlag, "Load balancer type",
+		args.LoadBalancerType, current.LoadBalancerType, ocm.LoadBalancerTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	// The hostname and the certificate of the cluster routes are only changed together
+	if flags.Changed(ClusterRoutesHostnameFlag) != flags.Changed(ClusterRoutesTLSSecretRefFlag) {
+		return nil, fmt.Errorf("Expected both --%s and --%s to be set",
+			ClusterRoutesHostnameFlag, ClusterRoutesTLSSecretRefFlag)
+	}
+	if flags.Changed(ClusterRoutesHostnameFlag) {
+		if args.ClusterRoutesHostname != "" {
+			if errs := validation.IsDNS1123Subdomain(args.ClusterRoutesHostname); len(errs) > 0 {
+				return nil, fmt.Errorf("Expected a valid cluster routes hostname: %s", strings.Join(errs, ", "))
+			}
+		}
+		if args.ClusterRoutesTLSSecretRef != "" {
+			if errs := validation.IsDNS1123Subdomain(args.ClusterRoutesTLSSecretRef); len(errs) > 0 {
+				return nil, fmt.Errorf("Expected a valid TLS secret name: %s", strings.Join(errs, ", "))
+			}
+		}
+		if (args.ClusterRoutesHostname == "") != (args.ClusterRoutesTLSSecretRef == "") {
+			return nil, fmt.Errorf("Expected --%s and --%s to be both set or both empty",
+				ClusterRoutesHostnameFlag, ClusterRoutesTLSSecretRefFlag)
+		}
+		options.ClusterRoutesHostname = &args.ClusterRoutesHostname
+		options.ClusterRoutesTLSSecretRef = &args.ClusterRoutesTLSSecretRef
+	}
+
+	return options, nil
+}
+
+// unsetRouterOption is the choice offered when an option has no current value, which leaves it
+// to the default of the service instead of sending one of the values
+const unsetRouterOption = "(default)"
+
+func getRouterOption(flags *pflag.FlagSet, flag string, question string, value string, current *string,
+	options []string) (*string, error) {
+	if !flags.Changed(flag) {
+		if !interactive.Enabled() {
+			return nil, nil
+		}
+		// The current value is the default, so that accepting every default changes nothing
+		choices := options
+		dflt := unsetRouterOption
+		if current != nil && *current != "" {
+			// The prompt falls back to the first option when the default isn't one of them
+			dflt = *current
+			for _, option := range options {
+				if strings.EqualFold(option, *current) {
+					dflt = option
+				}
+			}
+		} else {
+			choices = append([]string{unsetRouterOption}, options...)
+		}
+		var err error
+		value, err = interactive.GetOption(interactive.Input{
+			Question: question,
+			Help:     flags.Lookup(flag).Usage,
+			Options:  choices,
+			Default:  dflt,
+			Required: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Expected a valid %s: %s", strings.ToLower(question), err)
+		}
+		if value == unsetRouterOption {
+			return nil, nil
+		}
+	}
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return &option, nil
+		}
+	}
+	return nil, fmt.Errorf("Expected a valid value for --%s, one of: %s", flag, strings.Join(options, ", "))
+}
+
+// ParseExcludedNamespaces parses a comma-separated list of namespaces
+func ParseExcludedNamespaces(value string) ([]string, error) {
+	namespaces := []string{}
+	for _, namespace := range strings.Split(value, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return nil, fmt.Errorf("Expected a valid namespace '%s': %s", namespace, strings.Join(errs, ", "))
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces, nil
+}
+
+// ChangedRouterOptions returns the options that are different from the current ones
+func ChangedRouterOptions(options *ocm.IngressRouterOptions,
+	current *ocm.IngressRouterOptions) *ocm.IngressRouterOptions {
+	changed := &ocm.IngressRouterOptions{}
+	if options.ExcludedNamespaces != nil &&
+		strings.Join(*options.ExcludedNamespaces, ",") != strings.Join(valueOf(current.ExcludedNamespaces), ",") {
+		changed.ExcludedNamespaces = options.ExcludedNamespaces
+	}
+	if isChanged(options.RouteWildcardPolicy, current.RouteWildcardPolicy) {
+		changed.RouteWildcardPolicy = options.RouteWildcardPolicy
+	}
+	if isChanged(options.RouteNamespaceOwnershipPolicy, current.RouteNamespaceOwnershipPolicy) {
+		changed.RouteNamespaceOwnershipPolicy = options.RouteNamespaceOwnershipPolicy
+	}
+	if isChanged(options.LoadBalancerType, current.LoadBalancerType) {
+		changed.LoadBalancerType = options.LoadBalancerType
+	}
+	if isChanged(options.ClusterRoutesHostname, current.ClusterRoutesHostname) ||
+		isChanged(options.ClusterRoutesTLSSecretRef, current.ClusterRoutesTLSSecretRef) {
+		changed.ClusterRoutesHostname = options.ClusterRoutesHostname
+		changed.ClusterRoutesTLSSecretRef = options.ClusterRoutesTLSSecretRef
+	}
+	return changed
+}
+
+func isChanged(value *string, current *string) bool {
+	return value != nil && (current == nil || !strings.EqualFold(*value, *current))
+}
+
+func valueOf(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
+// GetIngress finds an ingress by its ID, where 'apps' is the default ingress and 'apps2' the
+// additional one
+func GetIngress(ingresses []*cmv1.Ingress, ingressKey string) *cmv1.Ingress {
+	var ingress *cmv1.Ingress
+	for _, item := range ingresses {
+		if ingressKey == "apps" && item.Default() {
+			ingress = item
+		}
+		if ingressKey == "apps2" && !item.Default() {
+			ingress = item
+		}
+		if item.ID() == ingressKey {
+			ingress = item
+		}
+	}
+	return ingress
+}
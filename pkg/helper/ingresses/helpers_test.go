@@ -0,0 +1,96 @@
+package ingresses
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Router options", func() {
+	var flags *pflag.FlagSet
+	var args RouterArgs
+
+	BeforeEach(func() {
+		args = RouterArgs{}
+		flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddRouterFlags(flags, &args)
+	})
+
+	It("Only returns the options set with flags", func() {
+		Expect(flags.Parse([]string{"--lb-type=NLB", "--excluded-namespaces=stage, dev"})).To(Succeed())
+		options, err := GetRouterOptions(flags, &args, nil)
+		Expect(err).To(BeNil())
+		Expect(*options.LoadBalancerType).To(Equal(ocm.LoadBalancerTypeNLB))
+		Expect(*options.ExcludedNamespaces).To(Equal([]string{"stage", "dev"}))
+		Expect(options.RouteWildcardPolicy).To(BeNil())
+		Expect(options.RouteNamespaceOwnershipPolicy).To(BeNil())
+		Expect(options.ClusterRoutesHostname).To(BeNil())
+	})
+
+	It("Rejects invalid values", func() {
+		Expect(flags.Parse([]string{"--wildcard-policy=Sometimes"})).To(Succeed())
+		_, err := GetRouterOptions(flags, &args, nil)
+		Expect(err).To(MatchError("Expected a valid value for --wildcard-policy, one of: " +
+			"WildcardsDisallowed, WildcardsAllowed"))
+	})
+
+	It("Requires the cluster routes hostname and TLS secret together", func() {
+		Expect(flags.Parse([]string{"--cluster-routes-hostname=console.example.com"})).To(Succeed())
+		_, err := GetRouterOptions(flags, &args, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Rejects invalid namespaces", func() {
+		_, err := ParseExcludedNamespaces("stage,Not_Valid")
+		Expect(err).To(HaveOccurred())
+		namespaces, err := ParseExcludedNamespaces("")
+		Expect(err).To(BeNil())
+		Expect(namespaces).To(BeEmpty())
+	})
+
+	It("Keeps only the changed options", func() {
+		nlb := ocm.LoadBalancerTypeNLB
+		classic := ocm.LoadBalancerTypeClassic
+		strict := ocm.NamespaceOwnershipPolicyStrict
+		namespaces := []string{"stage"}
+		current := &ocm.IngressRouterOptions{
+			LoadBalancerType:              &classic,
+			RouteNamespaceOwnershipPolicy: &strict,
+			ExcludedNamespaces:            &namespaces,
+		}
+		changed := ChangedRouterOptions(&ocm.IngressRouterOptions{
+			LoadBalancerType:              &nlb,
+			RouteNamespaceOwnershipPolicy: &strict,
+			ExcludedNamespaces:            &[]string{"stage"},
+		}, current)
+		Expect(*changed.LoadBalancerType).To(Equal(nlb))
+		Expect(changed.RouteNamespaceOwnershipPolicy).To(BeNil())
+		Expect(changed.ExcludedNamespaces).To(BeNil())
+
+		changed = ChangedRouterOptions(&ocm.IngressRouterOptions{ExcludedNamespaces: &[]string{}}, current)
+		Expect(*changed.ExcludedNamespaces).To(BeEmpty())
+		Expect(ChangedRouterOptions(&ocm.IngressRouterOptions{}, current).IsEmpty()).To(BeTrue())
+
+		// Values reported with a different case are the same
+		upperNLB := "NLB"
+		current.LoadBalancerType = &upperNLB
+		Expect(ChangedRouterOptions(&ocm.IngressRouterOptions{LoadBalancerType: &nlb}, current).IsEmpty()).To(BeTrue())
+	})
+})
+
+var _ = Describe("GetIngress", func() {
+	It("Finds ingresses by ID and by sub-domain", func() {
+		apps, err := cmv1.NewIngress().ID("a1b2").Default(true).Build()
+		Expect(err).To(BeNil())
+		apps2, err := cmv1.NewIngress().ID("c3d4").Default(false).Build()
+		Expect(err).To(BeNil())
+		ingresses := []*cmv1.Ingress{apps, apps2}
+		Expect(GetIngress(ingresses, "apps")).To(Equal(apps))
+		Expect(GetIngress(ingresses, "apps2")).To(Equal(apps2))
+		Expect(GetIngress(ingresses, "c3d4")).To(Equal(apps2))
+		Expect(GetIngress(ingresses, "e5f6")).To(BeNil())
+	})
+})
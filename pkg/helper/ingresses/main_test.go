@@ -0,0 +1,13 @@
+package ingresses
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIngressHelpers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ingress Helpers")
+}
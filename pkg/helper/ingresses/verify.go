@@ -0,0 +1,106 @@
+package ingresses
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper/verify"
+)
+
+type VerifyOptions struct {
+	Timeout time.Duration
+	// Port that the load balancer of the ingress listens on, 443 when empty
+	Port string
+	// Certificates trusted for the default certificate of the ingress, the ones of the system when nil
+	RootCAs *x509.CertPool
+	// Function used to resolve names, the default resolver when nil
+	LookupHost func(ctx context.Context, host string) ([]string, error)
+}
+
+// VerifyIngress checks that the wildcard DNS records of the ingress resolve and that each of the
+// addresses of its load balancer completes a TLS handshake with a certificate valid for its routes
+func VerifyIngress(ingress *cmv1.Ingress, options VerifyOptions) []verify.Check {
+	if options.Timeout == 0 {
+		options.Timeout = 10 * time.Second
+	}
+	if options.Port == "" {
+		options.Port = "443"
+	}
+	if options.LookupHost == nil {
+		options.LookupHost = net.DefaultResolver.LookupHost
+	}
+
+	// Any name under the domain of the ingress has to resolve to its load balancer
+	// nolint:gosec
+	host := fmt.Sprintf("rosa-verify-%d.%s", rand.Intn(100000), ingress.DNSName())
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	defer cancel()
+	addresses, err := options.LookupHost(ctx, host)
+	if err != nil || len(addresses) == 0 {
+		return []verify.Check{
+			verify.Fail("Wildcard DNS", "Failed to resolve '*.%s': %v", ingress.DNSName(), err),
+		}
+	}
+	checks := []verify.Check{
+		verify.Pass("Wildcard DNS", "'*.%s' resolves to %v", ingress.DNSName(), addresses),
+	}
+
+	for _, address := range addresses {
+		checks = append(checks, checkEndpoint(ingress, host, address, options))
+	}
+	return checks
+}
+
+func checkEndpoint(ingress *cmv1.Ingress, host string, address string, options VerifyOptions) verify.Check {
+	name := fmt.Sprintf("Load balancer %s", address)
+	endpoint := net.JoinHostPort(address, options.Port)
+
+	// The chain is verified after the handshake, so that certificates that aren't trusted can
+	// still be reported with the names they are valid for
+	dialer := &net.Dialer{Timeout: options.Timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", endpoint, &tls.Config{
+		ServerName: host,
+		// nolint:gosec
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		if ingress.Listening() == cmv1.ListeningMethodInternal {
+			return verify.Fail(name, "TLS handshake with '%s' failed: %v. Private ingresses are only "+
+				"reachable from networks connected to the VPC of the cluster", endpoint, err)
+		}
+		return verify.Fail(name, "TLS handshake with '%s' failed: %v", endpoint, err)
+	}
+	defer conn.Close()
+
+	certificates := conn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return verify.Fail(name, "'%s' didn't send a certificate", endpoint)
+	}
+	certificate := certificates[0]
+	err = certificate.VerifyHostname(host)
+	if err != nil {
+		return verify.Fail(name, "Certificate of '%s' is valid for %v, not for '*.%s'",
+			endpoint, certificate.DNSNames, ingress.DNSName())
+	}
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range certificates[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	_, err = certificate.Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Roots:         options.RootCAs,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		return verify.Fail(name, "Certificate of '%s' isn't trusted: %v", endpoint, err)
+	}
+	return verify.Pass(name, "TLS handshake succeeded with a trusted certificate valid for '*.%s'",
+		ingress.DNSName())
+}
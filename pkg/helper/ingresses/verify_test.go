@@ -0,0 +1,115 @@
+package ingresses
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper/verify"
+)
+
+var _ = Describe("VerifyIngress", func() {
+	var listener net.Listener
+	var options VerifyOptions
+
+	BeforeEach(func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).To(BeNil())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "*.apps.cluster.example.com"},
+			DNSNames:     []string{"*.apps.cluster.example.com"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			IsCA:         true,
+
+			BasicConstraintsValid: true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).To(BeNil())
+		certificate, err := x509.ParseCertificate(der)
+		Expect(err).To(BeNil())
+
+		listener, err = tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		})
+		Expect(err).To(BeNil())
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				_ = conn.(*tls.Conn).Handshake()
+				conn.Close()
+			}
+		}()
+
+		_, port, err := net.SplitHostPort(listener.Addr().String())
+		Expect(err).To(BeNil())
+		roots := x509.NewCertPool()
+		roots.AddCert(certificate)
+		options = VerifyOptions{
+			Port:    port,
+			RootCAs: roots,
+			LookupHost: func(_ context.Context, host string) ([]string, error) {
+				return []string{"127.0.0.1"}, nil
+			},
+		}
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	buildIngress := func(dnsName string) *cmv1.Ingress {
+		ingress, err := cmv1.NewIngress().ID("a1b2").DNSName(dnsName).Build()
+		Expect(err).To(BeNil())
+		return ingress
+	}
+
+	It("Passes when the certificate covers the routes", func() {
+		checks := VerifyIngress(buildIngress("apps.cluster.example.com"), options)
+		Expect(checks).To(HaveLen(2))
+		for _, check := range checks {
+			Expect(check.Result).To(Equal(verify.Passed), check.Message)
+		}
+	})
+
+	It("Fails when the certificate is for other names", func() {
+		checks := VerifyIngress(buildIngress("apps.other.example.com"), options)
+		Expect(checks).To(HaveLen(2))
+		Expect(checks[1].Result).To(Equal(verify.Failed))
+		Expect(checks[1].Message).To(ContainSubstring("*.apps.cluster.example.com"))
+	})
+
+	It("Fails when the certificate isn't trusted", func() {
+		options.RootCAs = x509.NewCertPool()
+		checks := VerifyIngress(buildIngress("apps.cluster.example.com"), options)
+		Expect(checks[1].Result).To(Equal(verify.Failed))
+		Expect(checks[1].Message).To(ContainSubstring("isn't trusted"))
+	})
+
+	It("Fails when the DNS records don't resolve", func() {
+		options.LookupHost = func(_ context.Context, host string) ([]string, error) {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		checks := VerifyIngress(buildIngress("apps.cluster.example.com"), options)
+		Expect(checks).To(HaveLen(1))
+		Expect(checks[0].Name).To(Equal("Wildcard DNS"))
+		Expect(checks[0].Result).To(Equal(verify.Failed))
+	})
+})
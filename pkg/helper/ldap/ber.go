@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// berMaxLength limits the size of the elements read from the server, so that a broken server
+// can't make the client allocate gigabytes of memory for a single message
+const berMaxLength = 16 * 1024 * 1024
+
+type berElement struct {
+	tag     byte
+	content []byte
+}
+
+func berEncode(tag byte, contents ...[]byte) []byte {
+	length := 0
+	for _, content := range contents {
+		length += len(content)
+	}
+	encoded := []byte{tag}
+	if length < 0x80 {
+		encoded = append(encoded, byte(length))
+	} else {
+		lengthBytes := []byte{}
+		for l := length; l > 0; l >>= 8 {
+			lengthBytes = append([]byte{byte(l)}, lengthBytes...)
+		}
+		encoded = append(encoded, 0x80|byte(len(lengthBytes)))
+		encoded = append(encoded, lengthBytes...)
+	}
+	for _, content := range contents {
+		encoded = append(encoded, content...)
+	}
+	return encoded
+}
+
+func berEncodeInt(tag byte, value int) []byte {
+	content := []byte{byte(value)}
+	for v := value >> 8; v > 0; v >>= 8 {
+		content = append([]byte{byte(v)}, content...)
+	}
+	// Positive numbers can't have the sign bit set
+	if content[0]&0x80 != 0 {
+		content = append([]byte{0}, content...)
+	}
+	return berEncode(tag, content)
+}
+
+func berDecodeInt(content []byte) int {
+	value := 0
+	for _, b := range content {
+		value = value<<8 | int(b)
+	}
+	return value
+}
+
+// berRead reads the next element, with definite lengths of up to four bytes
+func berRead(reader io.Reader) (*berElement, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, err
+	}
+	length := int(header[1])
+	if length&0x80 != 0 {
+		lengthBytes := make([]byte, length&0x7f)
+		if len(lengthBytes) == 0 || len(lengthBytes) > 4 {
+			return nil, fmt.Errorf("Unsupported BER length")
+		}
+		if _, err := io.ReadFull(reader, lengthBytes); err != nil {
+			return nil, err
+		}
+		length = berDecodeInt(lengthBytes)
+	}
+	if length > berMaxLength {
+		return nil, fmt.Errorf("BER element length %d exceeds the limit of %d bytes", length, berMaxLength)
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(reader, content); err != nil {
+		return nil, err
+	}
+	return &berElement{tag: header[0], content: content}, nil
+}
+
+func berDecodeAll(content []byte) ([]*berElement, error) {
+	elements := []*berElement{}
+	reader := bytes.NewReader(content)
+	for reader.Len() > 0 {
+		element, err := berRead(reader)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid LDAP message: %v", err)
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
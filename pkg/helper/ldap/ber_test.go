@@ -0,0 +1,70 @@
+package ldap
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BER", func() {
+	DescribeTable("Encodes integers",
+		func(value int, expected []byte) {
+			Expect(berEncodeInt(berInteger, value)).To(Equal(expected))
+		},
+		Entry("zero", 0, []byte{0x02, 0x01, 0x00}),
+		Entry("one byte", 3, []byte{0x02, 0x01, 0x03}),
+		Entry("sign bit", 128, []byte{0x02, 0x02, 0x00, 0x80}),
+		Entry("two bytes", 256, []byte{0x02, 0x02, 0x01, 0x00}),
+	)
+
+	It("Encodes short lengths in one byte", func() {
+		Expect(berEncode(berOctetString, []byte("ab"), []byte("c"))).To(
+			Equal([]byte{0x04, 0x03, 'a', 'b', 'c'}))
+	})
+
+	It("Encodes long lengths with the number of length bytes", func() {
+		encoded := berEncode(berOctetString, bytes.Repeat([]byte{'a'}, 300))
+		Expect(encoded[:4]).To(Equal([]byte{0x04, 0x82, 0x01, 0x2c}))
+		Expect(encoded).To(HaveLen(304))
+	})
+
+	It("Reads back encoded elements", func() {
+		content := bytes.Repeat([]byte{'a'}, 200)
+		element, err := berRead(bytes.NewReader(berEncode(berOctetString, content)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(element.tag).To(Equal(byte(berOctetString)))
+		Expect(element.content).To(Equal(content))
+		Expect(berDecodeInt([]byte{0x01, 0x2c})).To(Equal(300))
+	})
+
+	It("Decodes consecutive elements", func() {
+		elements, err := berDecodeAll(append(berEncodeInt(berEnumerated, 49),
+			berEncode(berOctetString, []byte("dn"))...))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(elements).To(HaveLen(2))
+		Expect(elements[0].tag).To(Equal(byte(berEnumerated)))
+		Expect(berDecodeInt(elements[0].content)).To(Equal(49))
+		Expect(string(elements[1].content)).To(Equal("dn"))
+	})
+
+	DescribeTable("Rejects invalid elements",
+		func(encoded []byte, message string) {
+			_, err := berRead(bytes.NewReader(encoded))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("indefinite length", []byte{0x30, 0x80}, "Unsupported BER length"),
+		Entry("length longer than four bytes", []byte{0x30, 0x85, 1, 0, 0, 0, 0}, "Unsupported BER length"),
+		Entry("length over the limit", []byte{0x04, 0x84, 0xff, 0xff, 0xff, 0xff}, "exceeds the limit"),
+		Entry("truncated content", []byte{0x04, 0x05, 'a'}, "EOF"),
+	)
+
+	It("Rejects elements longer than their parent", func() {
+		_, err := berDecodeAll([]byte{0x04, 0x05, 'a', 'b'})
+		Expect(err).To(HaveOccurred())
+		Expect(strings.HasPrefix(err.Error(), "Invalid LDAP message")).To(BeTrue())
+	})
+})
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ldap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// encodeFilter encodes RFC 4515 filters with '&', '|', '!', equality, presence and
+// substring items
+func encodeFilter(filter string) ([]byte, error) {
+	encoded, rest, err := encodeFilterItem(strings.TrimSpace(filter))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid LDAP filter '%s': %v", filter, err)
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("Invalid LDAP filter '%s': unexpected '%s'", filter, rest)
+	}
+	return encoded, nil
+}
+
+func encodeFilterItem(filter string) ([]byte, string, error) {
+	if !strings.HasPrefix(filter, "(") {
+		return nil, "", fmt.Errorf("expected '('")
+	}
+	filter = filter[1:]
+	if filter == "" {
+		return nil, "", fmt.Errorf("unexpected end of filter")
+	}
+	switch filter[0] {
+	case '&', '|', '!':
+		tag := map[byte]byte{'&': ldapFilterAnd, '|': ldapFilterOr, '!': ldapFilterNot}[filter[0]]
+		rest := filter[1:]
+		items := [][]byte{}
+		for strings.HasPrefix(rest, "(") {
+			item, next, err := encodeFilterItem(rest)
+			if err != nil {
+				return nil, "", err
+			}
+			items = append(items, item)
+			rest = next
+		}
+		if !strings.HasPrefix(rest, ")") {
+			return nil, "", fmt.Errorf("expected ')'")
+		}
+		if len(items) == 0 || (tag == ldapFilterNot && len(items) != 1) {
+			return nil, "", fmt.Errorf("unexpected number of filters for '%c'", filter[0])
+		}
+		return berEncode(tag, items...), rest[1:], nil
+	}
+
+	end := strings.Index(filter, ")")
+	if end < 0 {
+		return nil, "", fmt.Errorf("expected ')'")
+	}
+	item := filter[:end]
+	rest := filter[end+1:]
+	tokens := strings.SplitN(item, "=", 2)
+	if len(tokens) != 2 || tokens[0] == "" {
+		return nil, "", fmt.Errorf("expected 'attribute=value' in '%s'", item)
+	}
+	attribute := tokens[0]
+	value := tokens[1]
+	if strings.HasSuffix(attribute, "~") || strings.HasSuffix(attribute, ">") ||
+		strings.HasSuffix(attribute, "<") || strings.HasSuffix(attribute, ":") {
+		return nil, "", fmt.Errorf("unsupported match in '%s'", item)
+	}
+	if value == "*" {
+		return berEncode(ldapFilterPresent, []byte(attribute)), rest, nil
+	}
+	if !strings.Contains(value, "*") {
+		unescaped, err := unescapeFilterValue(value)
+		if err != nil {
+			return nil, "", err
+		}
+		return berEncode(ldapFilterEquality,
+			berEncode(berOctetString, []byte(attribute)),
+			berEncode(berOctetString, unescaped),
+		), rest, nil
+	}
+
+	// Substrings: initial*any*...*final
+	parts := strings.Split(value, "*")
+	substrings := [][]byte{}
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		unescaped, err := unescapeFilterValue(part)
+		if err != nil {
+			return nil, "", err
+		}
+		tag := byte(0x81)
+		if i == 0 {
+			tag = 0x80
+		} else if i == len(parts)-1 {
+			tag = 0x82
+		}
+		substrings = append(substrings, berEncode(tag, unescaped))
+	}
+	return berEncode(ldapFilterSubstring,
+		berEncode(berOctetString, []byte(attribute)),
+		berEncode(berSequence, substrings...),
+	), rest, nil
+}
+
+// unescapeFilterValue decodes the '\XX' hexadecimal escapes of filter values
+func unescapeFilterValue(value string) ([]byte, error) {
+	result := []byte{}
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			result = append(result, value[i])
+			continue
+		}
+		var b byte
+		if i+2 >= len(value) {
+			return nil, fmt.Errorf("invalid escape in '%s'", value)
+		}
+		_, err := fmt.Sscanf(value[i+1:i+3], "%02x", &b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid escape in '%s'", value)
+		}
+		result = append(result, b)
+		i += 2
+	}
+	return result, nil
+}
+
+// EscapeFilterValue escapes the characters with special meaning in filter values
+func EscapeFilterValue(value string) string {
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '*', '(', ')', '\\', 0:
+			fmt.Fprintf(&builder, "\\%02x", value[i])
+		default:
+			builder.WriteByte(value[i])
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,58 @@
+package ldap
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter", func() {
+	DescribeTable("Escapes values",
+		func(value string, expected string) {
+			Expect(EscapeFilterValue(value)).To(Equal(expected))
+			unescaped, err := unescapeFilterValue(expected)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(unescaped)).To(Equal(value))
+		},
+		Entry("plain", "jdoe", "jdoe"),
+		Entry("wildcard", "j*", "j\\2a"),
+		Entry("parentheses", "a(b)", "a\\28b\\29"),
+		Entry("backslash", "a\\b", "a\\5cb"),
+		Entry("null", "a\x00", "a\\00"),
+	)
+
+	DescribeTable("Encodes filters",
+		func(filter string, expected []byte) {
+			encoded, err := encodeFilter(filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(encoded).To(Equal(expected))
+		},
+		Entry("presence", "(uid=*)", []byte{0x87, 0x03, 'u', 'i', 'd'}),
+		Entry("equality with escapes", "(cn=a\\2ab)", []byte{
+			0xa3, 0x09,
+			0x04, 0x02, 'c', 'n',
+			0x04, 0x03, 'a', '*', 'b',
+		}),
+		Entry("substrings", "(cn=ab*c*d)", []byte{
+			0xa4, 0x10,
+			0x04, 0x02, 'c', 'n',
+			0x30, 0x0a, 0x80, 0x02, 'a', 'b', 0x81, 0x01, 'c', 0x82, 0x01, 'd',
+		}),
+		Entry("and", "(&(a=*)(b=*))", []byte{0xa0, 0x06, 0x87, 0x01, 'a', 0x87, 0x01, 'b'}),
+		Entry("not", "(!(a=*))", []byte{0xa2, 0x03, 0x87, 0x01, 'a'}),
+	)
+
+	DescribeTable("Rejects invalid filters",
+		func(filter string) {
+			_, err := encodeFilter(filter)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("missing parentheses", "uid=*"),
+		Entry("unbalanced", "(&(a=*)"),
+		Entry("trailing characters", "(a=*)x"),
+		Entry("not with two filters", "(!(a=*)(b=*))"),
+		Entry("approximate match", "(cn~=a)"),
+		Entry("invalid escape", "(cn=a\\zz)"),
+		Entry("missing value", "(cn)"),
+	)
+})
@@ -0,0 +1,407 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ldap contains the small subset of the LDAPv3 protocol (RFC 4511) needed to check the
+// configuration of an LDAP identity provider and to look up the members of its groups: StartTLS,
+// simple bind and search.
+package ldap
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BER tags of the LDAP messages and filters
+const (
+	berInteger     = 0x02
+	berOctetString = 0x04
+	berBoolean     = 0x01
+	berEnumerated  = 0x0a
+	berSequence    = 0x30
+
+	ldapBindRequest          = 0x60
+	ldapBindResponse         = 0x61
+	ldapUnbindRequest        = 0x42
+	ldapSearchRequest        = 0x63
+	ldapSearchResultEntry    = 0x64
+	ldapSearchResultDone     = 0x65
+	ldapSearchResultRef      = 0x73
+	ldapExtendedRequest      = 0x77
+	ldapExtendedResponse     = 0x78
+	ldapSimpleAuthentication = 0x80
+	ldapExtendedRequestName  = 0x80
+
+	ldapFilterAnd       = 0xa0
+	ldapFilterOr        = 0xa1
+	ldapFilterNot       = 0xa2
+	ldapFilterEquality  = 0xa3
+	ldapFilterSubstring = 0xa4
+	ldapFilterPresent   = 0x87
+)
+
+const ldapStartTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// Search scopes
+const (
+	ScopeBase = 0
+	ScopeOne  = 1
+	ScopeSub  = 2
+)
+
+var scopes = map[string]int{
+	"base": ScopeBase,
+	"one":  ScopeOne,
+	"sub":  ScopeSub,
+}
+
+// SearchURL holds the parts of an RFC 2255 URL used by the LDAP identity provider
+type SearchURL struct {
+	Secure    bool
+	Host      string
+	BaseDN    string
+	Attribute string
+	Scope     int
+	Filter    string
+}
+
+// ParseSearchURL parses an URL with the 'ldap[s]://host:port/basedn?attribute?scope?filter'
+// format, filling in the same defaults used by the cluster
+func ParseSearchURL(ldapURL string) (*SearchURL, error) {
+	parsedURL, err := url.Parse(ldapURL)
+	if err != nil {
+		return nil, err
+	}
+	searchURL := &SearchURL{
+		Secure:    parsedURL.Scheme == "ldaps",
+		Host:      parsedURL.Host,
+		BaseDN:    strings.TrimPrefix(parsedURL.Path, "/"),
+		Attribute: "uid",
+		Scope:     scopes["sub"],
+		Filter:    "(objectClass=*)",
+	}
+	if parsedURL.Scheme != "ldap" && parsedURL.Scheme != "ldaps" {
+		return nil, fmt.Errorf("Expected LDAP URL to have an ldap:// or ldaps:// scheme")
+	}
+	if parsedURL.Port() == "" {
+		port := "389"
+		if searchURL.Secure {
+			port = "636"
+		}
+		searchURL.Host = net.JoinHostPort(parsedURL.Hostname(), port)
+	}
+
+	// The query holds the attribute, scope and filter separated by '?'
+	query, err := url.PathUnescape(parsedURL.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(query, "?")
+	if len(parts) > 0 && parts[0] != "" {
+		searchURL.Attribute = strings.Split(parts[0], ",")[0]
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		scope, ok := scopes[parts[1]]
+		if !ok {
+			return nil, fmt.Errorf("Expected LDAP URL scope to be one of 'base', 'one' or 'sub'")
+		}
+		searchURL.Scope = scope
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		searchURL.Filter = parts[2]
+		if !strings.HasPrefix(searchURL.Filter, "(") {
+			searchURL.Filter = "(" + searchURL.Filter + ")"
+		}
+	}
+	return searchURL, nil
+}
+
+// Conn is a connection to an LDAP server
+type Conn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	messageID int
+	timeout   time.Duration
+}
+
+// Dial connects to the server of the URL, upgrading the connection with StartTLS unless the URL
+// is secure or the connection is insecure
+func Dial(searchURL *SearchURL, insecure bool, tlsConfig *tls.Config,
+	timeout time.Duration) (*Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	var err error
+	if searchURL.Secure {
+		conn, err = tls.DialWithDialer(dialer, "tcp", searchURL.Host, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", searchURL.Host)
+	}
+	if err != nil {
+		return nil, err
+	}
+	client := &Conn{
+		conn:    conn,
+		reader:  bufio.NewReader(conn),
+		timeout: timeout,
+	}
+
+	// Connections that aren't insecure are upgraded with StartTLS, the same as the cluster does
+	if !searchURL.Secure && !insecure {
+		err = client.startTLS(tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+func (c *Conn) Close() {
+	// Unbind has no response, errors don't matter as the connection is closed right after
+	c.send(c.message(berEncode(ldapUnbindRequest)))
+	c.conn.Close()
+}
+
+func (c *Conn) startTLS(tlsConfig *tls.Config) error {
+	response, err := c.request(berEncode(ldapExtendedRequest,
+		berEncode(ldapExtendedRequestName, []byte(ldapStartTLSOID))), ldapExtendedResponse)
+	if err != nil {
+		return fmt.Errorf("StartTLS failed: %v", err)
+	}
+	if err = resultError(response); err != nil {
+		return fmt.Errorf("StartTLS failed: %v", err)
+	}
+	tlsConn := tls.Client(c.conn, tlsConfig)
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	if err = tlsConn.Handshake(); err != nil {
+		return err
+	}
+	c.conn = tlsConn
+	c.reader = bufio.NewReader(tlsConn)
+	return nil
+}
+
+// BindDNString returns the bind DN to show in messages, which is empty for anonymous binds
+func BindDNString(bindDN string) string {
+	if bindDN == "" {
+		return "anonymous"
+	}
+	return bindDN
+}
+
+// Bind does a simple bind, or an anonymous bind when the DN is empty
+func (c *Conn) Bind(dn string, password string) error {
+	response, err := c.request(berEncode(ldapBindRequest,
+		berEncodeInt(berInteger, 3),
+		berEncode(berOctetString, []byte(dn)),
+		berEncode(ldapSimpleAuthentication, []byte(password)),
+	), ldapBindResponse)
+	if err != nil {
+		return err
+	}
+	return resultError(response)
+}
+
+// SearchEntry is an entry returned by a search, attribute names are lower case
+type SearchEntry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Search returns the entries that match the filter, up to the size limit, with the values of the
+// requested attributes
+func (c *Conn) Search(baseDN string, scope int, filter string, attributes []string,
+	sizeLimit int) ([]*SearchEntry, error) {
+	encodedFilter, err := encodeFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	// The special '1.1' attribute asks for no attributes
+	encodedAttributes := [][]byte{berEncode(berOctetString, []byte("1.1"))}
+	if len(attributes) > 0 {
+		encodedAttributes = [][]byte{}
+		for _, attribute := range attributes {
+			encodedAttributes = append(encodedAttributes, berEncode(berOctetString, []byte(attribute)))
+		}
+	}
+	err = c.send(c.message(berEncode(ldapSearchRequest,
+		berEncode(berOctetString, []byte(baseDN)),
+		berEncodeInt(berEnumerated, scope),
+		berEncodeInt(berEnumerated, 0),
+		berEncodeInt(berInteger, sizeLimit),
+		berEncodeInt(berInteger, int(c.timeout.Seconds())),
+		berEncode(berBoolean, []byte{0}),
+		encodedFilter,
+		berEncode(berSequence, encodedAttributes...),
+	)))
+	if err != nil {
+		return nil, err
+	}
+	entries := []*SearchEntry{}
+	for {
+		tag, op, err := c.receive()
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case ldapSearchResultEntry:
+			entry, err := decodeEntry(op)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case ldapSearchResultRef:
+			continue
+		case ldapSearchResultDone:
+			err = resultError(op)
+			// Reaching the size limit still means that the search works
+			if err != nil && !strings.HasPrefix(err.Error(), "sizeLimitExceeded") {
+				return nil, err
+			}
+			return entries, nil
+		default:
+			return nil, fmt.Errorf("Unexpected LDAP response with tag 0x%x", tag)
+		}
+	}
+}
+
+func decodeEntry(content []byte) (*SearchEntry, error) {
+	fields, err := berDecodeAll(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < 1 {
+		return nil, fmt.Errorf("Unexpected LDAP entry without DN")
+	}
+	entry := &SearchEntry{
+		DN:         string(fields[0].content),
+		Attributes: map[string][]string{},
+	}
+	if len(fields) < 2 {
+		return entry, nil
+	}
+	attributes, err := berDecodeAll(fields[1].content)
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range attributes {
+		parts, err := berDecodeAll(attribute.content)
+		if err != nil {
+			return nil, err
+		}
+		if len(parts) < 2 {
+			continue
+		}
+		values, err := berDecodeAll(parts[1].content)
+		if err != nil {
+			return nil, err
+		}
+		// Attribute names are case insensitive
+		name := strings.ToLower(string(parts[0].content))
+		for _, value := range values {
+			entry.Attributes[name] = append(entry.Attributes[name], string(value.content))
+		}
+	}
+	return entry, nil
+}
+
+func (c *Conn) message(op []byte) []byte {
+	c.messageID++
+	return berEncode(berSequence, berEncodeInt(berInteger, c.messageID), op)
+}
+
+func (c *Conn) request(op []byte, expectedTag byte) ([]byte, error) {
+	err := c.send(c.message(op))
+	if err != nil {
+		return nil, err
+	}
+	tag, response, err := c.receive()
+	if err != nil {
+		return nil, err
+	}
+	if tag != expectedTag {
+		return nil, fmt.Errorf("Unexpected LDAP response with tag 0x%x", tag)
+	}
+	return response, nil
+}
+
+func (c *Conn) send(message []byte) error {
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	_, err := c.conn.Write(message)
+	return err
+}
+
+// receive reads the next message and returns the tag and the content of its protocol operation
+func (c *Conn) receive() (byte, []byte, error) {
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	message, err := berRead(c.reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if message.tag != berSequence {
+		return 0, nil, fmt.Errorf("Unexpected LDAP message with tag 0x%x", message.tag)
+	}
+	fields, err := berDecodeAll(message.content)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(fields) < 2 {
+		return 0, nil, fmt.Errorf("Unexpected LDAP message without protocol operation")
+	}
+	return fields[1].tag, fields[1].content, nil
+}
+
+var resultCodes = map[int]string{
+	1:  "operationsError",
+	2:  "protocolError",
+	4:  "sizeLimitExceeded",
+	7:  "authMethodNotSupported",
+	8:  "strongerAuthRequired",
+	32: "noSuchObject",
+	34: "invalidDNSyntax",
+	48: "inappropriateAuthentication",
+	49: "invalidCredentials",
+	50: "insufficientAccessRights",
+	52: "unavailable",
+	53: "unwillingToPerform",
+}
+
+// resultError returns an error for LDAP results with a result code other than success
+func resultError(result []byte) error {
+	fields, err := berDecodeAll(result)
+	if err != nil {
+		return err
+	}
+	if len(fields) < 3 || fields[0].tag != berEnumerated {
+		return fmt.Errorf("Unexpected LDAP result")
+	}
+	code := berDecodeInt(fields[0].content)
+	if code == 0 {
+		return nil
+	}
+	name, ok := resultCodes[code]
+	if !ok {
+		name = fmt.Sprintf("result code %d", code)
+	}
+	if len(fields[2].content) > 0 {
+		return fmt.Errorf("%s: %s", name, fields[2].content)
+	}
+	return fmt.Errorf("%s", name)
+}
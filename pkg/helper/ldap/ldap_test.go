@@ -0,0 +1,43 @@
+package ldap
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LDAP", func() {
+	It("Parses LDAP URLs with defaults", func() {
+		searchURL, err := ParseSearchURL("ldaps://ldap.example.com/ou=users,dc=example,dc=com")
+		Expect(err).To(BeNil())
+		Expect(searchURL.Secure).To(BeTrue())
+		Expect(searchURL.Host).To(Equal("ldap.example.com:636"))
+		Expect(searchURL.BaseDN).To(Equal("ou=users,dc=example,dc=com"))
+		Expect(searchURL.Attribute).To(Equal("uid"))
+		Expect(searchURL.Scope).To(Equal(ScopeSub))
+		Expect(searchURL.Filter).To(Equal("(objectClass=*)"))
+
+		searchURL, err = ParseSearchURL(
+			"ldap://ldap.example.com:1389/dc=example,dc=com?mail?one?(objectClass=person)")
+		Expect(err).To(BeNil())
+		Expect(searchURL.Secure).To(BeFalse())
+		Expect(searchURL.Host).To(Equal("ldap.example.com:1389"))
+		Expect(searchURL.Attribute).To(Equal("mail"))
+		Expect(searchURL.Scope).To(Equal(ScopeOne))
+		Expect(searchURL.Filter).To(Equal("(objectClass=person)"))
+	})
+
+	It("Rejects URLs with other schemes", func() {
+		_, err := ParseSearchURL("https://ldap.example.com/dc=example,dc=com")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Returns errors for result codes other than success", func() {
+		success := append(berEncodeInt(berEnumerated, 0),
+			append(berEncode(berOctetString), berEncode(berOctetString)...)...)
+		Expect(resultError(success)).To(Succeed())
+
+		failure := append(berEncodeInt(berEnumerated, 49),
+			append(berEncode(berOctetString), berEncode(berOctetString, []byte("bad password"))...)...)
+		Expect(resultError(failure)).To(MatchError("invalidCredentials: bad password"))
+	})
+})
@@ -0,0 +1,13 @@
+package ldap
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLDAP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "LDAP")
+}
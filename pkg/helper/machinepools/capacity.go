@@ -0,0 +1,81 @@
+package machinepools
+
+import (
+	"fmt"
+)
+
+// CapacityStrategy splits the replicas of a machine pool between a base of on-demand instances
+// and a percentage of spot instances spread across several instance types. Since a single
+// machine pool has a single instance type and market, the strategy is implemented by creating
+// one machine pool per instance type and market.
+type CapacityStrategy struct {
+	OnDemandBaseCapacity int
+	SpotPercentage       int
+	SpotInstanceTypes    []string
+}
+
+// CapacityAllocation is the number of replicas of an instance type in one market
+type CapacityAllocation struct {
+	InstanceType string
+	Spot         bool
+	Replicas     int
+}
+
+func (s CapacityStrategy) Validate() error {
+	if s.OnDemandBaseCapacity < 0 {
+		return fmt.Errorf("On-demand base capacity must be a non-negative integer")
+	}
+	if s.SpotPercentage < 0 || s.SpotPercentage > 100 {
+		return fmt.Errorf("Spot percentage must be between 0 and 100")
+	}
+	seen := map[string]bool{}
+	for _, instanceType := range s.SpotInstanceTypes {
+		if seen[instanceType] {
+			return fmt.Errorf("Duplicated spot instance type '%s'", instanceType)
+		}
+		seen[instanceType] = true
+	}
+	return nil
+}
+
+// Allocate splits the replicas between the on-demand instance type and the spot instance types.
+// The on-demand allocation always comes first followed by one allocation per spot instance type,
+// in order, even when they get no replicas, so that the allocations of the minimum and maximum
+// replicas of an autoscaling pool can be paired. Replicas are allocated in multiples of the step,
+// which is 3 for multi-AZ machine pools.
+func (s CapacityStrategy) Allocate(onDemandInstanceType string, replicas int, step int) []CapacityAllocation {
+	if step < 1 {
+		step = 1
+	}
+	spotInstanceTypes := s.SpotInstanceTypes
+	if len(spotInstanceTypes) == 0 {
+		spotInstanceTypes = []string{onDemandInstanceType}
+	}
+
+	units := replicas / step
+	baseUnits := (s.OnDemandBaseCapacity + step - 1) / step
+	spotUnits := 0
+	if units > baseUnits {
+		spotUnits = (units - baseUnits) * s.SpotPercentage / 100
+	}
+
+	allocations := []CapacityAllocation{
+		{
+			InstanceType: onDemandInstanceType,
+			Replicas:     (units - spotUnits) * step,
+		},
+	}
+	for i, instanceType := range spotInstanceTypes {
+		// Spread the spot units evenly, giving the remainder to the first instance types
+		share := spotUnits / len(spotInstanceTypes)
+		if i < spotUnits%len(spotInstanceTypes) {
+			share++
+		}
+		allocations = append(allocations, CapacityAllocation{
+			InstanceType: instanceType,
+			Spot:         true,
+			Replicas:     share * step,
+		})
+	}
+	return allocations
+}
@@ -0,0 +1,63 @@
+package machinepools
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Capacity strategy", func() {
+	DescribeTable("Allocate",
+		func(strategy CapacityStrategy, replicas int, step int, expected []CapacityAllocation) {
+			Expect(strategy.Validate()).To(Succeed())
+			Expect(strategy.Allocate("m5.xlarge", replicas, step)).To(Equal(expected))
+		},
+		Entry("on-demand only",
+			CapacityStrategy{OnDemandBaseCapacity: 2},
+			4, 1,
+			[]CapacityAllocation{
+				{InstanceType: "m5.xlarge", Replicas: 4},
+				{InstanceType: "m5.xlarge", Spot: true, Replicas: 0},
+			},
+		),
+		Entry("half spot above the base capacity across instance types",
+			CapacityStrategy{OnDemandBaseCapacity: 2, SpotPercentage: 50,
+				SpotInstanceTypes: []string{"m5.xlarge", "m5a.xlarge", "m6i.xlarge"}},
+			10, 1,
+			[]CapacityAllocation{
+				{InstanceType: "m5.xlarge", Replicas: 6},
+				{InstanceType: "m5.xlarge", Spot: true, Replicas: 2},
+				{InstanceType: "m5a.xlarge", Spot: true, Replicas: 1},
+				{InstanceType: "m6i.xlarge", Spot: true, Replicas: 1},
+			},
+		),
+		Entry("base capacity above the replicas",
+			CapacityStrategy{OnDemandBaseCapacity: 5, SpotPercentage: 100},
+			3, 1,
+			[]CapacityAllocation{
+				{InstanceType: "m5.xlarge", Replicas: 3},
+				{InstanceType: "m5.xlarge", Spot: true, Replicas: 0},
+			},
+		),
+		Entry("multi-AZ allocates multiples of 3",
+			CapacityStrategy{OnDemandBaseCapacity: 1, SpotPercentage: 100,
+				SpotInstanceTypes: []string{"m5.xlarge", "m5a.xlarge"}},
+			12, 3,
+			[]CapacityAllocation{
+				{InstanceType: "m5.xlarge", Replicas: 3},
+				{InstanceType: "m5.xlarge", Spot: true, Replicas: 6},
+				{InstanceType: "m5a.xlarge", Spot: true, Replicas: 3},
+			},
+		),
+	)
+
+	DescribeTable("Validate",
+		func(strategy CapacityStrategy, expectedErr string) {
+			Expect(strategy.Validate()).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("negative base", CapacityStrategy{OnDemandBaseCapacity: -1}, "non-negative"),
+		Entry("percentage too high", CapacityStrategy{SpotPercentage: 101}, "between 0 and 100"),
+		Entry("duplicated instance types",
+			CapacityStrategy{SpotInstanceTypes: []string{"m5.xlarge", "m5.xlarge"}}, "Duplicated"),
+	)
+})
@@ -32,3 +32,41 @@ func MaxNodePoolReplicaValidator(minReplicas int) interactive.Validator {
 		return nil
 	}
 }
+
+// ValidateDefaultMachinePoolReplicas checks the replicas of the default machine pool of a classic
+// cluster. The minimum number of nodes only applies to the minimum of autoscaling when it is being set.
+func ValidateDefaultMachinePoolReplicas(multiAZ bool, autoscaling bool, replicas int, minReplicas int,
+	maxReplicas int, isMinReplicasSet bool) error {
+	if multiAZ {
+		if !autoscaling && replicas < 3 ||
+			(autoscaling && isMinReplicasSet && minReplicas < 3) {
+			return fmt.Errorf("Default machine pool for AZ cluster requires at least 3 compute nodes")
+		}
+
+		if !autoscaling && replicas%3 != 0 ||
+			(autoscaling && (minReplicas%3 != 0 || maxReplicas%3 != 0)) {
+			return fmt.Errorf("Multi AZ clusters require that the number of compute nodes be a multiple of 3")
+		}
+	} else if !autoscaling && replicas < 2 ||
+		(autoscaling && isMinReplicasSet && minReplicas < 2) {
+		return fmt.Errorf("Default machine pool requires at least 2 compute nodes")
+	}
+	return nil
+}
+
+// ValidateMachinePoolReplicas checks the replicas of a machine pool of a classic cluster other than
+// the default one. The multiAZ flag tells if the pool is spread over the zones of a multi AZ cluster.
+func ValidateMachinePoolReplicas(multiAZ bool, autoscaling bool, replicas int, minReplicas int,
+	maxReplicas int, isMinReplicasSet bool) error {
+	if !autoscaling && replicas < 0 ||
+		(autoscaling && isMinReplicasSet && minReplicas < 0) {
+		return fmt.Errorf("The number of machine pool replicas needs to be a non-negative integer")
+	}
+
+	if multiAZ &&
+		(!autoscaling && replicas%3 != 0 ||
+			(autoscaling && (minReplicas%3 != 0 || maxReplicas%3 != 0))) {
+		return fmt.Errorf("Multi AZ clusters require that the number of MachinePool replicas be a multiple of 3")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package machinepools
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Machine pool replicas", func() {
+	expectError := func(err error, expectedErr string) {
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	}
+
+	DescribeTable("Validate default machine pool replicas",
+		func(multiAZ bool, autoscaling bool, replicas int, minReplicas int, maxReplicas int,
+			isMinReplicasSet bool, expectedErr string) {
+			expectError(ValidateDefaultMachinePoolReplicas(multiAZ, autoscaling, replicas, minReplicas, maxReplicas,
+				isMinReplicasSet), expectedErr)
+		},
+		Entry("Single AZ below minimum", false, false, 1, 0, 0, false,
+			"Default machine pool requires at least 2 compute nodes"),
+		Entry("Single AZ", false, false, 2, 0, 0, false, ""),
+		Entry("Single AZ autoscaling below minimum", false, true, 0, 1, 4, true,
+			"Default machine pool requires at least 2 compute nodes"),
+		Entry("Single AZ autoscaling without changing the minimum", false, true, 0, 0, 4, false, ""),
+		Entry("Multi AZ below minimum", true, false, 0, 0, 0, false,
+			"Default machine pool for AZ cluster requires at least 3 compute nodes"),
+		Entry("Multi AZ not a multiple of 3", true, false, 4, 0, 0, false,
+			"Multi AZ clusters require that the number of compute nodes be a multiple of 3"),
+		Entry("Multi AZ autoscaling not a multiple of 3", true, true, 0, 3, 7, true,
+			"Multi AZ clusters require that the number of compute nodes be a multiple of 3"),
+		Entry("Multi AZ autoscaling", true, true, 0, 3, 9, true, ""),
+	)
+
+	DescribeTable("Validate machine pool replicas",
+		func(multiAZ bool, autoscaling bool, replicas int, minReplicas int, maxReplicas int,
+			isMinReplicasSet bool, expectedErr string) {
+			expectError(ValidateMachinePoolReplicas(multiAZ, autoscaling, replicas, minReplicas, maxReplicas,
+				isMinReplicasSet), expectedErr)
+		},
+		Entry("Negative replicas", false, false, -1, 0, 0, false,
+			"The number of machine pool replicas needs to be a non-negative integer"),
+		Entry("No replicas", false, false, 0, 0, 0, false, ""),
+		Entry("Negative minimum", false, true, 0, -1, 3, true,
+			"The number of machine pool replicas needs to be a non-negative integer"),
+		Entry("Multi AZ not a multiple of 3", true, false, 4, 0, 0, false,
+			"Multi AZ clusters require that the number of MachinePool replicas be a multiple of 3"),
+		Entry("Multi AZ autoscaling not a multiple of 3", true, true, 0, 3, 4, true,
+			"Multi AZ clusters require that the number of MachinePool replicas be a multiple of 3"),
+		Entry("Multi AZ", true, false, 3, 0, 0, false, ""),
+	)
+})
@@ -0,0 +1,232 @@
+package machinepools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Effects supported by Kubernetes taints
+var TaintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// TaintSelector identifies the taints to remove from a machine pool. An empty effect matches
+// taints with any effect.
+type TaintSelector struct {
+	Key    string
+	Effect string
+}
+
+func ParseLabels(labels string) (map[string]string, error) {
+	labelMap := make(map[string]string)
+	if labels == "" {
+		return labelMap, nil
+	}
+	for _, label := range strings.Split(labels, ",") {
+		if !strings.Contains(label, "=") {
+			return nil, fmt.Errorf("Expected key=value format for labels")
+		}
+		tokens := strings.SplitN(label, "=", 2)
+		err := ValidateLabelKeyValuePair(tokens[0], tokens[1])
+		if err != nil {
+			return nil, err
+		}
+		key := strings.TrimSpace(tokens[0])
+		value := strings.TrimSpace(tokens[1])
+		if _, exists := labelMap[key]; exists {
+			return nil, fmt.Errorf("Duplicated label key '%s' used", key)
+		}
+		labelMap[key] = value
+	}
+	return labelMap, nil
+}
+
+// ParseLabelKeys parses a comma-separated list of label keys
+func ParseLabelKeys(keys string) ([]string, error) {
+	result := []string{}
+	if strings.TrimSpace(keys) == "" {
+		return result, nil
+	}
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.TrimSpace(key)
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return nil, fmt.Errorf("Invalid label key '%s': %s", key, strings.Join(errs, "; "))
+		}
+		result = append(result, key)
+	}
+	return result, nil
+}
+
+func ParseTaints(taints string) ([]*cmv1.TaintBuilder, error) {
+	taintBuilders := []*cmv1.TaintBuilder{}
+	if taints == "" {
+		return taintBuilders, nil
+	}
+	var errs []error
+	for _, taint := range strings.Split(taints, ",") {
+		if !strings.Contains(taint, "=") || !strings.Contains(taint, ":") {
+			return nil, fmt.Errorf("Expected key=value:scheduleType format for taints. Got '%s'", taint)
+		}
+		// First split effect
+		splitEffect := strings.SplitN(taint, ":", 2)
+		// Then split key and value
+		splitKeyValue := strings.SplitN(splitEffect[0], "=", 2)
+		newTaintBuilder := cmv1.NewTaint().Key(splitKeyValue[0]).Value(splitKeyValue[1]).Effect(splitEffect[1])
+		newTaint, _ := newTaintBuilder.Build()
+		if err := ValidateLabelKeyValuePair(newTaint.Key(), newTaint.Value()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if newTaint.Effect() == "" {
+			// Note: an empty effect means any effect. For the moment this is not supported
+			errs = append(errs, fmt.Errorf("Expected a not empty effect"))
+			continue
+		}
+		if err := ValidateTaintEffect(newTaint.Effect()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		taintBuilders = append(taintBuilders, newTaintBuilder)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.NewAggregate(errs)
+	}
+
+	return taintBuilders, nil
+}
+
+// ParseTaintSelectors parses a comma-separated list of taints to remove, in 'key' or
+// 'key:Effect' format
+func ParseTaintSelectors(taints string) ([]TaintSelector, error) {
+	selectors := []TaintSelector{}
+	if strings.TrimSpace(taints) == "" {
+		return selectors, nil
+	}
+	for _, taint := range strings.Split(taints, ",") {
+		tokens := strings.SplitN(strings.TrimSpace(taint), ":", 2)
+		// Allow the same format used to add taints, the value is ignored
+		key := strings.SplitN(tokens[0], "=", 2)[0]
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return nil, fmt.Errorf("Invalid taint key '%s': %s", key, strings.Join(errs, "; "))
+		}
+		selector := TaintSelector{Key: key}
+		if len(tokens) == 2 {
+			if err := ValidateTaintEffect(tokens[1]); err != nil {
+				return nil, err
+			}
+			selector.Effect = tokens[1]
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors, nil
+}
+
+func ValidateLabelKeyValuePair(key, value string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+		return fmt.Errorf("Invalid label key '%s': %s", key, strings.Join(errs, "; "))
+	}
+
+	if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+		return fmt.Errorf("Invalid label value '%s': at key: '%s': %s",
+			value, key, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func ValidateTaintEffect(effect string) error {
+	for _, validEffect := range TaintEffects {
+		if effect == validEffect {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid taint effect '%s', expected one of %s", effect, TaintEffects)
+}
+
+// MergeLabels returns the existing labels with the added labels set and the removed keys deleted
+func MergeLabels(existing map[string]string, add map[string]string, remove []string) map[string]string {
+	labels := make(map[string]string)
+	for key, value := range existing {
+		labels[key] = value
+	}
+	for _, key := range remove {
+		delete(labels, key)
+	}
+	for key, value := range add {
+		labels[key] = value
+	}
+	return labels
+}
+
+// MergeTaints returns the existing taints without the ones matching the selectors and with the
+// added taints. Kubernetes identifies taints by key and effect, so an added taint replaces an
+// existing one with the same key and effect.
+func MergeTaints(existing []*cmv1.Taint, add []*cmv1.TaintBuilder,
+	remove []TaintSelector) ([]*cmv1.TaintBuilder, error) {
+	added := []*cmv1.Taint{}
+	for _, builder := range add {
+		taint, err := builder.Build()
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, taint)
+	}
+
+	taintBuilders := []*cmv1.TaintBuilder{}
+	for _, taint := range existing {
+		if taint == nil || matchesAny(taint, remove) {
+			continue
+		}
+		replaced := false
+		for _, addedTaint := range added {
+			if addedTaint.Key() == taint.Key() && addedTaint.Effect() == taint.Effect() {
+				replaced = true
+				break
+			}
+		}
+		if replaced {
+			continue
+		}
+		taintBuilders = append(taintBuilders,
+			cmv1.NewTaint().Key(taint.Key()).Value(taint.Value()).Effect(taint.Effect()))
+	}
+	return append(taintBuilders, add...), nil
+}
+
+func matchesAny(taint *cmv1.Taint, selectors []TaintSelector) bool {
+	for _, selector := range selectors {
+		if selector.Key == taint.Key() && (selector.Effect == "" || selector.Effect == taint.Effect()) {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelsString formats labels as a sorted comma-separated list of 'key=value'
+func LabelsString(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, labels[key]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// TaintsString formats taints as a comma-separated list of 'key=value:Effect'
+func TaintsString(taints []*cmv1.Taint) string {
+	pairs := []string{}
+	for _, taint := range taints {
+		if taint == nil {
+			continue
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%s:%s", taint.Key(), taint.Value(), taint.Effect()))
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,75 @@
+package machinepools
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func buildTaints(builders []*cmv1.TaintBuilder) []*cmv1.Taint {
+	taints := []*cmv1.Taint{}
+	for _, builder := range builders {
+		taint, err := builder.Build()
+		Expect(err).ToNot(HaveOccurred())
+		taints = append(taints, taint)
+	}
+	return taints
+}
+
+var _ = Describe("Label and taint helpers", func() {
+	DescribeTable("ParseTaints",
+		func(taints string, expected string, expectedErr string) {
+			builders, err := ParseTaints(taints)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).ToNot(HaveOccurred())
+			Expect(TaintsString(buildTaints(builders))).To(Equal(expected))
+		},
+		Entry("empty", "", "", ""),
+		Entry("valid", "a=b:NoSchedule,c=:NoExecute", "a=b:NoSchedule,c=:NoExecute", ""),
+		Entry("invalid effect", "a=b:Never", "", "Invalid taint effect 'Never'"),
+		Entry("missing effect", "a=b", "", "Expected key=value:scheduleType format"),
+		Entry("invalid key", "a b=c:NoSchedule", "", "Invalid label key 'a b'"),
+	)
+
+	DescribeTable("ParseTaintSelectors",
+		func(taints string, expected []TaintSelector, expectedErr string) {
+			selectors, err := ParseTaintSelectors(taints)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selectors).To(Equal(expected))
+		},
+		Entry("key only", "a", []TaintSelector{{Key: "a"}}, ""),
+		Entry("key and effect", "a:NoSchedule", []TaintSelector{{Key: "a", Effect: "NoSchedule"}}, ""),
+		Entry("full taint", "a=b:NoExecute", []TaintSelector{{Key: "a", Effect: "NoExecute"}}, ""),
+		Entry("invalid effect", "a:Never", nil, "Invalid taint effect 'Never'"),
+	)
+
+	It("Merges labels", func() {
+		existing := map[string]string{"a": "1", "b": "2", "c": "3"}
+		labels := MergeLabels(existing, map[string]string{"b": "4", "d": "5"}, []string{"a"})
+		Expect(LabelsString(labels)).To(Equal("b=4,c=3,d=5"))
+		Expect(existing).To(HaveLen(3))
+	})
+
+	It("Merges taints", func() {
+		existing, err := ParseTaints("a=1:NoSchedule,a=2:NoExecute,b=3:NoSchedule,c=4:NoSchedule")
+		Expect(err).ToNot(HaveOccurred())
+		add, err := ParseTaints("a=5:NoSchedule,d=6:PreferNoSchedule")
+		Expect(err).ToNot(HaveOccurred())
+		remove, err := ParseTaintSelectors("b,c:NoExecute")
+		Expect(err).ToNot(HaveOccurred())
+
+		merged, err := MergeTaints(buildTaints(existing), add, remove)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(TaintsString(buildTaints(merged))).To(
+			Equal("a=2:NoExecute,c=4:NoSchedule,a=5:NoSchedule,d=6:PreferNoSchedule"))
+	})
+})
@@ -0,0 +1,32 @@
+package upgrades
+
+import (
+	"github.com/openshift/rosa/pkg/helper/cron"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Schedule types of upgrade policies
+const (
+	ScheduleTypeManual    = "manual"
+	ScheduleTypeAutomatic = "automatic"
+)
+
+// ValidateSchedule checks that a schedule is a standard cron expression with the minute, hour,
+// day of month, month and day of week fields
+func ValidateSchedule(schedule string) error {
+	_, err := cron.Parse(schedule)
+	return err
+}
+
+// AutomaticUpgradeVersion returns the version that an automatic upgrade would upgrade to, which is
+// the latest available upgrade within the current minor version unless minor upgrades are enabled.
+// Available upgrades are expected to be sorted with the latest version first.
+func AutomaticUpgradeVersion(currentVersion string, availableUpgrades []string, minorUpgrades bool) string {
+	minor := ocm.GetVersionMinor(currentVersion)
+	for _, version := range availableUpgrades {
+		if minorUpgrades || ocm.GetVersionMinor(version) == minor {
+			return version
+		}
+	}
+	return ""
+}
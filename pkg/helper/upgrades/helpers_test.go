@@ -0,0 +1,41 @@
+package upgrades
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upgrade Helpers", func() {
+	DescribeTable("Validate schedule",
+		func(schedule string, valid bool) {
+			err := ValidateSchedule(schedule)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("weekly", "0 2 * * SUN", true),
+		Entry("daily", "30 23 * * *", true),
+		Entry("ranges and steps", "*/15 1-5 1,15 JAN-jun mon-fri", true),
+		Entry("sunday as seven", "0 0 * * 7", true),
+		Entry("too few fields", "0 2 * *", false),
+		Entry("too many fields", "0 2 * * * 2024", false),
+		Entry("minute out of range", "60 2 * * *", false),
+		Entry("day of month out of range", "0 2 0 * *", false),
+		Entry("unknown day", "0 2 * * SOMEDAY", false),
+		Entry("decreasing range", "0 5-1 * * *", false),
+		Entry("invalid step", "*/0 2 * * *", false),
+	)
+
+	DescribeTable("Automatic upgrade version",
+		func(current string, availableUpgrades []string, minorUpgrades bool, expected string) {
+			Expect(AutomaticUpgradeVersion(current, availableUpgrades, minorUpgrades)).To(Equal(expected))
+		},
+		Entry("latest patch", "4.12.3", []string{"4.13.1", "4.12.10", "4.12.9"}, false, "4.12.10"),
+		Entry("latest minor", "4.12.3", []string{"4.13.1", "4.12.10", "4.12.9"}, true, "4.13.1"),
+		Entry("no patches", "4.12.3", []string{"4.13.1"}, false, ""),
+		Entry("no upgrades", "4.12.3", []string{}, false, ""),
+	)
+})
@@ -0,0 +1,55 @@
+package verify
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+const (
+	Passed  = "passed"
+	Failed  = "failed"
+	Skipped = "skipped"
+	Warning = "warning"
+)
+
+// Check is the result of one of the checks run to verify a resource
+type Check struct {
+	Name    string `json:"name"`
+	Result  string `json:"result"`
+	Message string `json:"message"`
+}
+
+func Pass(name string, format string, a ...interface{}) Check {
+	return Check{Name: name, Result: Passed, Message: fmt.Sprintf(format, a...)}
+}
+
+func Fail(name string, format string, a ...interface{}) Check {
+	return Check{Name: name, Result: Failed, Message: fmt.Sprintf(format, a...)}
+}
+
+func Skip(name string, format string, a ...interface{}) Check {
+	return Check{Name: name, Result: Skipped, Message: fmt.Sprintf(format, a...)}
+}
+
+func Warn(name string, format string, a ...interface{}) Check {
+	return Check{Name: name, Result: Warning, Message: fmt.Sprintf(format, a...)}
+}
+
+func HasFailures(checks []Check) bool {
+	for _, check := range checks {
+		if check.Result == Failed {
+			return true
+		}
+	}
+	return false
+}
+
+func PrintReport(checks []Check) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CHECK\tRESULT\tDETAILS\n")
+	for _, check := range checks {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", check.Name, check.Result, check.Message)
+	}
+	writer.Flush()
+}
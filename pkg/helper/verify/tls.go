@@ -0,0 +1,23 @@
+package verify
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+)
+
+// TLSConfig trusts only the given CA bundle, or the system trust store when it is empty
+func TLSConfig(ca string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if ca == "" {
+		return config, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(ca)) {
+		return nil, errors.New("CA bundle doesn't contain any PEM-encoded certificate")
+	}
+	config.RootCAs = pool
+	return config, nil
+}
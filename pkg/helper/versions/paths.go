@@ -0,0 +1,86 @@
+package versions
+
+import (
+	"sort"
+
+	ver "github.com/hashicorp/go-version"
+)
+
+// UpgradeGraph maps each version to the versions that it can be upgraded to directly
+type UpgradeGraph map[string][]string
+
+// FindUpgradePath returns the shortest list of versions, starting with the current one and ending
+// with the target, where each version can be upgraded to the next one. When there are several
+// paths of the same length the one going through the latest versions is preferred. It returns nil
+// when the target can't be reached.
+func FindUpgradePath(graph UpgradeGraph, current string, target string) []string {
+	previous := map[string]string{current: ""}
+	queue := []string{current}
+	for len(queue) > 0 {
+		version := queue[0]
+		queue = queue[1:]
+		if version == target {
+			path := []string{}
+			for ; version != ""; version = previous[version] {
+				path = append([]string{version}, path...)
+			}
+			return path
+		}
+		for _, next := range sortDescending(graph[version]) {
+			if _, ok := previous[next]; !ok {
+				previous[next] = version
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+// LatestReachableVersion returns the latest version that the current one can be upgraded to,
+// directly or through other versions, or an empty string when there are no upgrades
+func LatestReachableVersion(graph UpgradeGraph, current string) string {
+	visited := map[string]bool{current: true}
+	queue := []string{current}
+	reachable := []string{}
+	for len(queue) > 0 {
+		version := queue[0]
+		queue = queue[1:]
+		for _, next := range graph[version] {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+				reachable = append(reachable, next)
+			}
+		}
+	}
+	reachable = sortDescending(reachable)
+	if len(reachable) == 0 {
+		return ""
+	}
+	return reachable[0]
+}
+
+// IsEUS checks if the minor version of a version is an Extended Update Support release, which are
+// the even minor versions of OpenShift 4 starting with 4.6
+func IsEUS(version string) bool {
+	parsed, err := ver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	segments := parsed.Segments()
+	return segments[0] == 4 && segments[1] >= 6 && segments[1]%2 == 0
+}
+
+func sortDescending(versions []string) []string {
+	sorted := make([]string, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, erra := ver.NewVersion(sorted[i])
+		b, errb := ver.NewVersion(sorted[j])
+		if erra != nil || errb != nil {
+			return sorted[i] > sorted[j]
+		}
+		return a.GreaterThan(b)
+	})
+	return sorted
+}
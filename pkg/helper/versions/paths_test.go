@@ -0,0 +1,44 @@
+package versions
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/ginkgo/v2/dsl/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upgrade paths", func() {
+	graph := UpgradeGraph{
+		"4.12.3":  {"4.12.10", "4.12.40"},
+		"4.12.10": {"4.12.40", "4.13.1"},
+		"4.12.40": {"4.13.1", "4.13.20"},
+		"4.13.1":  {"4.13.20"},
+		"4.13.20": {"4.14.2", "4.14.5"},
+		"4.14.2":  {"4.14.5"},
+	}
+
+	DescribeTable("Find upgrade path",
+		func(current string, target string, expected []string) {
+			Expect(FindUpgradePath(graph, current, target)).To(Equal(expected))
+		},
+		Entry("direct upgrade", "4.12.3", "4.12.40", []string{"4.12.3", "4.12.40"}),
+		Entry("through the latest versions", "4.12.3", "4.13.20", []string{"4.12.3", "4.12.40", "4.13.20"}),
+		Entry("EUS to EUS", "4.12.3", "4.14.5", []string{"4.12.3", "4.12.40", "4.13.20", "4.14.5"}),
+		Entry("same version", "4.14.5", "4.14.5", []string{"4.14.5"}),
+		Entry("unreachable", "4.13.20", "4.12.40", nil),
+	)
+
+	It("Finds the latest reachable version", func() {
+		Expect(LatestReachableVersion(graph, "4.12.3")).To(Equal("4.14.5"))
+		Expect(LatestReachableVersion(graph, "4.14.5")).To(Equal(""))
+	})
+
+	DescribeTable("EUS versions",
+		func(version string, expected bool) {
+			Expect(IsEUS(version)).To(Equal(expected))
+		},
+		Entry("4.12", "4.12.3", true),
+		Entry("4.13", "4.13.20", false),
+		Entry("4.4", "4.4.1", false),
+		Entry("invalid", "latest", false),
+	)
+})
@@ -75,8 +75,8 @@ func GetAddonArgument(param cmv1.AddOnParameter, dflt string) (string, error) {
 		return "false", nil
 	case "cidr":
 		var cidrVal net.IPNet
-		if dflt != "" {
-			_, defaultIDR, _ := net.ParseCIDR(dflt)
+		// Values given in files or flags aren't validated yet, invalid ones are just not used as default
+		if _, defaultIDR, err := net.ParseCIDR(dflt); err == nil {
 			input.Default = *defaultIDR
 		}
 		cidrVal, err := GetIPNet(input)
@@ -95,7 +95,8 @@ func GetAddonArgument(param cmv1.AddOnParameter, dflt string) (string, error) {
 		}
 		return fmt.Sprintf("%d", numVal), nil
 
-	case "string":
+	default:
+		// Parameters of other types are entered as plain strings and checked by the caller
 		input.Default = dflt
 		value, err := GetString(input)
 		if err != nil {
@@ -103,10 +104,6 @@ func GetAddonArgument(param cmv1.AddOnParameter, dflt string) (string, error) {
 		}
 		return value, nil
 	}
-
-	// If the parameter type wasn't handled in the above switch statement
-	// then this parameter type is not supported by interactive mode yet.
-	return "", fmt.Errorf("The parameter '%s' does not support interactive mode", param.ID())
 }
 
 func getOptionNames(param cmv1.AddOnParameter) []string {
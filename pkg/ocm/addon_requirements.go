@@ -0,0 +1,342 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	ver "github.com/hashicorp/go-version"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Resources that the requirements of add-ons apply to
+const (
+	AddOnRequirementCluster     = "cluster"
+	AddOnRequirementAddOn       = "addon"
+	AddOnRequirementMachinePool = "machine_pool"
+)
+
+type AddOnRequirementResult struct {
+	Requirement *cmv1.AddOnRequirement
+	Fulfilled   bool
+	Reasons     []string
+}
+
+// GetAddOnInquiry returns the add-on as seen by the cluster, which includes the status of the
+// requirements of the add-on when the service has evaluated them
+func (c *Client) GetAddOnInquiry(clusterID, addOnID string) (*cmv1.AddOn, error) {
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().
+		Cluster(clusterID).AddonInquiries().AddonInquiry(addOnID).Get().Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+// CheckAddOnRequirements evaluates the requirements of an add-on. Requirements that the service
+// already evaluated for the cluster keep their status, the rest are compared with the attributes
+// of the cluster, of its machine pools and of the add-ons installed on it. Strings in the data of
+// a requirement need to be equal or to satisfy a version constraint like '>=4.12', numbers are
+// minimums and lists hold the accepted values.
+func CheckAddOnRequirements(addOn *cmv1.AddOn, cluster *cmv1.Cluster, machinePools []*cmv1.MachinePool,
+	addOns []*ClusterAddOn) ([]*AddOnRequirementResult, error) {
+	results := []*AddOnRequirementResult{}
+	for _, requirement := range addOn.Requirements() {
+		enabled, ok := requirement.GetEnabled()
+		if ok && !enabled {
+			continue
+		}
+		result := &AddOnRequirementResult{
+			Requirement: requirement,
+		}
+		results = append(results, result)
+
+		status, ok := requirement.GetStatus()
+		if ok {
+			if _, ok = status.GetFulfilled(); ok {
+				result.Fulfilled = status.Fulfilled()
+				result.Reasons = status.ErrorMsgs()
+				continue
+			}
+		}
+
+		switch requirement.Resource() {
+		case AddOnRequirementCluster:
+			object, err := toRequirementObject(cluster, func(writer *bytes.Buffer) error {
+				return cmv1.MarshalCluster(cluster, writer)
+			})
+			if err != nil {
+				return nil, err
+			}
+			result.Reasons = matchRequirementData(object, requirement.Data())
+			result.Fulfilled = len(result.Reasons) == 0
+		case AddOnRequirementMachinePool:
+			for _, machinePool := range machinePools {
+				object, err := machinePoolRequirementObject(machinePool)
+				if err != nil {
+					return nil, err
+				}
+				if len(matchRequirementData(object, requirement.Data())) == 0 {
+					result.Fulfilled = true
+					break
+				}
+			}
+			if !result.Fulfilled {
+				result.Reasons = []string{fmt.Sprintf("No machine pool has %s",
+					describeRequirementData(requirement.Data()))}
+			}
+		case AddOnRequirementAddOn:
+			for _, clusterAddOn := range addOns {
+				if clusterAddOn.State == "not installed" || clusterAddOn.State == "unavailable" {
+					continue
+				}
+				object := map[string]interface{}{
+					"id":    clusterAddOn.ID,
+					"name":  clusterAddOn.Name,
+					"state": clusterAddOn.State,
+				}
+				if len(matchRequirementData(object, requirement.Data())) == 0 {
+					result.Fulfilled = true
+					break
+				}
+			}
+			if !result.Fulfilled {
+				result.Reasons = []string{fmt.Sprintf("No installed add-on has %s",
+					describeRequirementData(requirement.Data()))}
+			}
+		default:
+			result.Reasons = []string{fmt.Sprintf("Requirements on '%s' resources can't be checked",
+				requirement.Resource())}
+		}
+	}
+	return results, nil
+}
+
+// MachinePoolForRequirement builds a machine pool that fulfills a machine pool requirement
+func MachinePoolForRequirement(id string, requirement *cmv1.AddOnRequirement) (*cmv1.MachinePool, error) {
+	if requirement.Resource() != AddOnRequirementMachinePool {
+		return nil, fmt.Errorf("Requirement '%s' is not a machine pool requirement", requirement.ID())
+	}
+	for _, key := range []string{"instance_type", "replicas"} {
+		if _, ok := requirement.Data()[key]; !ok {
+			return nil, fmt.Errorf("Machine pools without '%s' can't be created automatically", key)
+		}
+	}
+	builder := cmv1.NewMachinePool().ID(id)
+	for key, value := range requirement.Data() {
+		switch key {
+		case "instance_type":
+			// When several instance types are accepted the first one is used
+			if values, ok := value.([]interface{}); ok && len(values) > 0 {
+				value = values[0]
+			}
+			instanceType, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("Expected instance type of requirement '%s' to be a string",
+					requirement.ID())
+			}
+			builder = builder.InstanceType(instanceType)
+		case "replicas":
+			replicas, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("Expected replicas of requirement '%s' to be a number", requirement.ID())
+			}
+			builder = builder.Replicas(int(replicas))
+		case "labels":
+			values, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("Expected labels of requirement '%s' to be a map", requirement.ID())
+			}
+			labels := map[string]string{}
+			for name, label := range values {
+				labels[name] = fmt.Sprint(label)
+			}
+			builder = builder.Labels(labels)
+		default:
+			return nil, fmt.Errorf("Machine pools with '%s' can't be created automatically", key)
+		}
+	}
+	return builder.Build()
+}
+
+func toRequirementObject(object interface{}, marshal func(*bytes.Buffer) error) (map[string]interface{}, error) {
+	buffer := &bytes.Buffer{}
+	err := marshal(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal %T: %v", object, err)
+	}
+	result := map[string]interface{}{}
+	err = json.Unmarshal(buffer.Bytes(), &result)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal %T: %v", object, err)
+	}
+	return result, nil
+}
+
+func machinePoolRequirementObject(machinePool *cmv1.MachinePool) (map[string]interface{}, error) {
+	object, err := toRequirementObject(machinePool, func(writer *bytes.Buffer) error {
+		return cmv1.MarshalMachinePool(machinePool, writer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Autoscaling machine pools are guaranteed to have their minimum amount of replicas
+	if _, ok := object["replicas"]; !ok {
+		if autoscaling, ok := machinePool.GetAutoscaling(); ok {
+			object["replicas"] = float64(autoscaling.MinReplicas())
+		}
+	}
+	return object, nil
+}
+
+// matchRequirementData returns the reasons why an object doesn't match the data of a requirement,
+// where the keys of the data are dot separated paths to the attributes of the object
+func matchRequirementData(object map[string]interface{}, data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	reasons := []string{}
+	for _, key := range keys {
+		var actual interface{} = object
+		for _, step := range strings.Split(key, ".") {
+			values, ok := actual.(map[string]interface{})
+			if !ok {
+				actual = nil
+				break
+			}
+			actual = values[step]
+		}
+		if !matchRequirementValue(actual, data[key]) {
+			found := "nothing"
+			if actual != nil {
+				found = fmt.Sprintf("'%s'", formatRequirementValue(actual))
+			}
+			reasons = append(reasons, fmt.Sprintf("Expected '%s' to be %s, found %s",
+				key, describeRequirementValue(data[key]), found))
+		}
+	}
+	return reasons
+}
+
+func matchRequirementValue(actual interface{}, expected interface{}) bool {
+	if actual == nil {
+		return expected == nil
+	}
+	switch typed := expected.(type) {
+	case []interface{}:
+		// Lists of values need to hold all the expected values, other values one of them
+		if values, ok := actual.([]interface{}); ok {
+			for _, value := range typed {
+				found := false
+				for _, item := range values {
+					found = found || matchRequirementValue(item, value)
+				}
+				if !found {
+					return false
+				}
+			}
+			return true
+		}
+		for _, value := range typed {
+			if matchRequirementValue(actual, value) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		values, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range typed {
+			if !matchRequirementValue(values[key], value) {
+				return false
+			}
+		}
+		return true
+	case float64:
+		value, ok := actual.(float64)
+		return ok && value >= typed
+	case string:
+		value := formatRequirementValue(actual)
+		if value == typed {
+			return true
+		}
+		if typed != "" && strings.ContainsAny(typed[:1], "<>=!~") {
+			constraint, err := ver.NewConstraint(typed)
+			if err != nil {
+				return false
+			}
+			version, err := ver.NewVersion(value)
+			return err == nil && constraint.Check(version)
+		}
+		return false
+	default:
+		return reflect.DeepEqual(actual, expected)
+	}
+}
+
+func describeRequirementData(data map[string]interface{}) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	descriptions := make([]string, len(keys))
+	for i, key := range keys {
+		descriptions[i] = fmt.Sprintf("'%s' %s", key, describeRequirementValue(data[key]))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+func describeRequirementValue(expected interface{}) string {
+	switch typed := expected.(type) {
+	case []interface{}:
+		values := make([]string, len(typed))
+		for i, value := range typed {
+			values[i] = formatRequirementValue(value)
+		}
+		return fmt.Sprintf("one of [%s]", strings.Join(values, ", "))
+	case float64:
+		return fmt.Sprintf("at least %s", formatRequirementValue(typed))
+	case string:
+		if typed != "" && strings.ContainsAny(typed[:1], "<>=!~") {
+			return fmt.Sprintf("'%s'", typed)
+		}
+	}
+	return fmt.Sprintf("'%s'", formatRequirementValue(expected))
+}
+
+func formatRequirementValue(value interface{}) string {
+	switch value.(type) {
+	case string, float64, bool:
+		return fmt.Sprint(value)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
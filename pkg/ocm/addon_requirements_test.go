@@ -0,0 +1,125 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Addon requirements", func() {
+	var cluster *cmv1.Cluster
+	var machinePools []*cmv1.MachinePool
+	var clusterAddOns []*ClusterAddOn
+
+	BeforeEach(func() {
+		var err error
+		cluster, err = cmv1.NewCluster().ID("cluster1").
+			CloudProvider(cmv1.NewCloudProvider().ID("aws")).
+			Version(cmv1.NewVersion().RawID("4.12.3")).
+			Build()
+		Expect(err).To(BeNil())
+		workers, err := cmv1.NewMachinePool().ID("worker").InstanceType("m5.xlarge").Replicas(2).Build()
+		Expect(err).To(BeNil())
+		autoscaling, err := cmv1.NewMachinePool().ID("gpu").InstanceType("g4dn.xlarge").
+			Autoscaling(cmv1.NewMachinePoolAutoscaling().MinReplicas(3).MaxReplicas(6)).
+			Labels(map[string]string{"gpu": "true"}).
+			Build()
+		Expect(err).To(BeNil())
+		machinePools = []*cmv1.MachinePool{workers, autoscaling}
+		clusterAddOns = []*ClusterAddOn{
+			{ID: "service-mesh", State: "ready"},
+			{ID: "logging", State: "not installed"},
+		}
+	})
+
+	check := func(requirements ...*cmv1.AddOnRequirementBuilder) []*AddOnRequirementResult {
+		addOn, err := cmv1.NewAddOn().ID("addon1").Requirements(requirements...).Build()
+		Expect(err).To(BeNil())
+		results, err := CheckAddOnRequirements(addOn, cluster, machinePools, clusterAddOns)
+		Expect(err).To(BeNil())
+		return results
+	}
+
+	It("Checks cluster attributes and versions", func() {
+		results := check(
+			cmv1.NewAddOnRequirement().ID("aws").Resource("cluster").Data(map[string]interface{}{
+				"cloud_provider.id": "aws",
+				"version.raw_id":    ">=4.11",
+			}),
+			cmv1.NewAddOnRequirement().ID("version").Resource("cluster").Data(map[string]interface{}{
+				"version.raw_id": ">=4.13",
+			}),
+		)
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Fulfilled).To(BeTrue())
+		Expect(results[1].Fulfilled).To(BeFalse())
+		Expect(results[1].Reasons).To(Equal([]string{"Expected 'version.raw_id' to be '>=4.13', found '4.12.3'"}))
+	})
+
+	It("Checks machine pools", func() {
+		results := check(
+			cmv1.NewAddOnRequirement().ID("gpu").Resource("machine_pool").Data(map[string]interface{}{
+				"instance_type": []interface{}{"g4dn.xlarge", "g4dn.2xlarge"},
+				"replicas":      float64(3),
+				"labels":        map[string]interface{}{"gpu": "true"},
+			}),
+			cmv1.NewAddOnRequirement().ID("workers").Resource("machine_pool").Data(map[string]interface{}{
+				"instance_type": "m5.xlarge",
+				"replicas":      float64(3),
+			}),
+		)
+		Expect(results[0].Fulfilled).To(BeTrue())
+		Expect(results[1].Fulfilled).To(BeFalse())
+		Expect(results[1].Reasons).To(Equal([]string{
+			"No machine pool has 'instance_type' 'm5.xlarge', 'replicas' at least 3"}))
+	})
+
+	It("Checks installed add-ons", func() {
+		results := check(
+			cmv1.NewAddOnRequirement().ID("mesh").Resource("addon").Data(map[string]interface{}{
+				"id": "service-mesh", "state": "ready",
+			}),
+			cmv1.NewAddOnRequirement().ID("logging").Resource("addon").Data(map[string]interface{}{
+				"id": "logging",
+			}),
+		)
+		Expect(results[0].Fulfilled).To(BeTrue())
+		Expect(results[1].Fulfilled).To(BeFalse())
+	})
+
+	It("Uses the status evaluated by the service and skips disabled requirements", func() {
+		results := check(
+			cmv1.NewAddOnRequirement().ID("disabled").Resource("cluster").Enabled(false).
+				Data(map[string]interface{}{"cloud_provider.id": "gcp"}),
+			cmv1.NewAddOnRequirement().ID("evaluated").Resource("cluster").Enabled(true).
+				Data(map[string]interface{}{"cloud_provider.id": "aws"}).
+				Status(cmv1.NewAddOnRequirementStatus().Fulfilled(false).ErrorMsgs("Not enough quota")),
+		)
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Requirement.ID()).To(Equal("evaluated"))
+		Expect(results[0].Fulfilled).To(BeFalse())
+		Expect(results[0].Reasons).To(Equal([]string{"Not enough quota"}))
+	})
+
+	It("Builds machine pools that meet requirements", func() {
+		requirement, err := cmv1.NewAddOnRequirement().ID("gpu").Resource("machine_pool").
+			Data(map[string]interface{}{
+				"instance_type": []interface{}{"g4dn.xlarge"},
+				"replicas":      float64(2),
+				"labels":        map[string]interface{}{"gpu": "true"},
+			}).Build()
+		Expect(err).To(BeNil())
+		machinePool, err := MachinePoolForRequirement("addon1", requirement)
+		Expect(err).To(BeNil())
+		Expect(machinePool.ID()).To(Equal("addon1"))
+		Expect(machinePool.InstanceType()).To(Equal("g4dn.xlarge"))
+		Expect(machinePool.Replicas()).To(Equal(2))
+		Expect(machinePool.Labels()).To(Equal(map[string]string{"gpu": "true"}))
+
+		requirement, err = cmv1.NewAddOnRequirement().ID("zones").Resource("machine_pool").
+			Data(map[string]interface{}{"instance_type": "m5.xlarge"}).Build()
+		Expect(err).To(BeNil())
+		_, err = MachinePoolForRequirement("addon1", requirement)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -17,8 +17,14 @@ limitations under the License.
 package ocm
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"net"
+	"regexp"
+	"strconv"
 
+	"github.com/ghodss/yaml"
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
@@ -160,6 +166,104 @@ func (c *Client) UpdateAddOnInstallation(clusterID, addOnID string, params []Add
 	return nil
 }
 
+// UpgradeAddOnInstallation changes the version of an installed add-on
+func (c *Client) UpgradeAddOnInstallation(clusterID, addOnID, version string) error {
+	addOnInstallation, err := cmv1.NewAddOnInstallation().
+		Addon(cmv1.NewAddOn().ID(addOnID)).
+		AddonVersion(cmv1.NewAddOnVersion().ID(version)).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		Addons().Addoninstallation(addOnID).
+		Update().Body(addOnInstallation).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+
+	return nil
+}
+
+// ValidateAddOnParameter checks a value against the type, the validation and the options of an
+// add-on parameter
+func ValidateAddOnParameter(param *cmv1.AddOnParameter, value string) error {
+	if value == "" {
+		if param.Required() {
+			return fmt.Errorf("Parameter '%s' is required", param.ID())
+		}
+		return nil
+	}
+	var err error
+	switch param.ValueType() {
+	case "boolean":
+		_, err = strconv.ParseBool(value)
+	case "number", "resource":
+		_, err = strconv.Atoi(value)
+	case "cidr":
+		_, _, err = net.ParseCIDR(value)
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid value for parameter '%s': expected %q to be a valid %s",
+			param.ID(), value, param.ValueType())
+	}
+	if param.Validation() != "" {
+		isValid, err := regexp.MatchString(param.Validation(), value)
+		if err != nil || !isValid {
+			if param.ValidationErrMsg() != "" {
+				return fmt.Errorf("Invalid value for parameter '%s': %s", param.ID(), param.ValidationErrMsg())
+			}
+			return fmt.Errorf("Invalid value for parameter '%s': expected %q to match /%s/",
+				param.ID(), value, param.Validation())
+		}
+	}
+	if len(param.Options()) > 0 {
+		values := []string{}
+		for _, option := range param.Options() {
+			if option.Value() == value {
+				return nil
+			}
+			values = append(values, option.Value())
+		}
+		return fmt.Errorf("Invalid value for parameter '%s': expected %q to be one of %v", param.ID(), value, values)
+	}
+	return nil
+}
+
+// ParseAddOnParams parses a YAML or JSON document that maps the IDs of add-on parameters to their
+// values. Values can be strings, booleans or numbers, and are converted to the string form used
+// by the API.
+func ParseAddOnParams(data []byte) (map[string]string, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse parameters file: %v", err)
+	}
+	values := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	err = decoder.Decode(&values)
+	if err != nil {
+		return nil, fmt.Errorf("Expected parameters file to map parameter IDs to values: %v", err)
+	}
+	params := map[string]string{}
+	for id, value := range values {
+		switch typed := value.(type) {
+		case nil:
+			params[id] = ""
+		case string:
+			params[id] = typed
+		case bool:
+			params[id] = strconv.FormatBool(typed)
+		case json.Number:
+			params[id] = typed.String()
+		default:
+			return nil, fmt.Errorf("Expected a string, boolean or number value for parameter '%s'", id)
+		}
+	}
+	return params, nil
+}
+
 func (c *Client) GetAddOnParameters(clusterID, addOnID string) (*cmv1.AddOnParameterList, error) {
 	response, err := c.ocm.ClustersMgmt().V1().Clusters().
 		Cluster(clusterID).AddonInquiries().AddonInquiry(addOnID).Get().Send()
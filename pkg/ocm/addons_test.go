@@ -0,0 +1,84 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Addons", func() {
+	Context("ValidateAddOnParameter", func() {
+		It("Checks required parameters", func() {
+			param, err := cmv1.NewAddOnParameter().ID("size").Required(true).Build()
+			Expect(err).To(BeNil())
+			Expect(ValidateAddOnParameter(param, "")).To(MatchError("Parameter 'size' is required"))
+			Expect(ValidateAddOnParameter(param, "1")).To(Succeed())
+		})
+		It("Checks the validation expression", func() {
+			param, err := cmv1.NewAddOnParameter().ID("size").Validation("^[0-9]+$").Build()
+			Expect(err).To(BeNil())
+			Expect(ValidateAddOnParameter(param, "")).To(Succeed())
+			Expect(ValidateAddOnParameter(param, "10")).To(Succeed())
+			Expect(ValidateAddOnParameter(param, "ten")).To(HaveOccurred())
+
+			param, err = cmv1.NewAddOnParameter().ID("size").Validation("^[0-9]+$").
+				ValidationErrMsg("must be a number").Build()
+			Expect(err).To(BeNil())
+			Expect(ValidateAddOnParameter(param, "ten")).To(
+				MatchError("Invalid value for parameter 'size': must be a number"))
+		})
+		It("Checks the options", func() {
+			param, err := cmv1.NewAddOnParameter().ID("tier").Options(
+				cmv1.NewAddOnParameterOption().Name("Small").Value("small"),
+				cmv1.NewAddOnParameterOption().Name("Large").Value("large"),
+			).Build()
+			Expect(err).To(BeNil())
+			Expect(ValidateAddOnParameter(param, "large")).To(Succeed())
+			Expect(ValidateAddOnParameter(param, "Large")).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("Addon parameters file", func() {
+	It("Converts values to strings", func() {
+		params, err := ParseAddOnParams([]byte(`
+name: logging
+enabled: true
+replicas: 3
+ratio: 0.5
+cidr: 10.0.0.0/16
+empty:
+`))
+		Expect(err).To(BeNil())
+		Expect(params).To(Equal(map[string]string{
+			"name":     "logging",
+			"enabled":  "true",
+			"replicas": "3",
+			"ratio":    "0.5",
+			"cidr":     "10.0.0.0/16",
+			"empty":    "",
+		}))
+	})
+	It("Rejects nested values", func() {
+		_, err := ParseAddOnParams([]byte("name:\n  nested: value\n"))
+		Expect(err).To(HaveOccurred())
+		_, err = ParseAddOnParams([]byte("- name\n"))
+		Expect(err).To(HaveOccurred())
+	})
+	It("Checks the type of the values", func() {
+		param, err := cmv1.NewAddOnParameter().ID("replicas").ValueType("number").Build()
+		Expect(err).To(BeNil())
+		Expect(ValidateAddOnParameter(param, "3")).To(Succeed())
+		Expect(ValidateAddOnParameter(param, "three")).To(HaveOccurred())
+
+		param, err = cmv1.NewAddOnParameter().ID("enabled").ValueType("boolean").Build()
+		Expect(err).To(BeNil())
+		Expect(ValidateAddOnParameter(param, "false")).To(Succeed())
+		Expect(ValidateAddOnParameter(param, "no")).To(HaveOccurred())
+
+		param, err = cmv1.NewAddOnParameter().ID("cidr").ValueType("cidr").Build()
+		Expect(err).To(BeNil())
+		Expect(ValidateAddOnParameter(param, "10.0.0.0/16")).To(Succeed())
+		Expect(ValidateAddOnParameter(param, "10.0.0.0")).To(HaveOccurred())
+	})
+})
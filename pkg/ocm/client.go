@@ -82,7 +82,8 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 			err = fmt.Errorf("Failed to load config file: %v", err)
 			return nil, err
 		}
-		if b.cfg == nil {
+		// The configuration file keeps the contexts after logging out
+		if b.cfg == nil || (b.cfg.AccessToken == "" && b.cfg.RefreshToken == "" && b.cfg.ClientSecret == "") {
 			err = fmt.Errorf("Not logged in, run the 'rosa login' command")
 			return nil, err
 		}
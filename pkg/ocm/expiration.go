@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Labels of the organization used to limit how long clusters can live
+const (
+	MaxClusterLifetimeLabel     = "rosa_max_cluster_lifetime"
+	MaxExpirationExtensionLabel = "rosa_max_expiration_extension"
+)
+
+// ExpirationPolicy holds the limits of the organization on cluster expiration. A zero value means
+// that there is no limit.
+type ExpirationPolicy struct {
+	// Maximum time between the creation and the expiration of a cluster
+	MaxLifetime time.Duration
+	// Maximum extension of the expiration of a cluster at once
+	MaxExtension time.Duration
+}
+
+func (c *Client) GetOrganizationLabel(organizationID string, key string) (string, error) {
+	response, err := c.ocm.AccountsMgmt().V1().Organizations().Organization(organizationID).
+		Labels().Labels(key).Get().Send()
+	if err != nil {
+		if response.Status() == http.StatusNotFound {
+			return "", nil
+		}
+		return "", handleErr(response.Error(), err)
+	}
+	return response.Body().Value(), nil
+}
+
+// GetExpirationPolicy returns the cluster expiration policy of the current organization
+func (c *Client) GetExpirationPolicy() (*ExpirationPolicy, error) {
+	organizationID, _, err := c.GetCurrentOrganization()
+	if err != nil {
+		return nil, err
+	}
+	policy := &ExpirationPolicy{}
+	for _, item := range []struct {
+		label    string
+		duration *time.Duration
+	}{
+		{MaxClusterLifetimeLabel, &policy.MaxLifetime},
+		{MaxExpirationExtensionLabel, &policy.MaxExtension},
+	} {
+		value, err := c.GetOrganizationLabel(organizationID, item.label)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		*item.duration, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid duration in organization label '%s': %v", item.label, err)
+		}
+	}
+	return policy, nil
+}
+
+// ExtendExpiration returns the new expiration of a cluster extended by the given duration, which
+// starts from the current expiration or from now if the cluster already expired
+func (p *ExpirationPolicy) ExtendExpiration(cluster *cmv1.Cluster, by time.Duration,
+	now time.Time) (time.Time, error) {
+	expiration, ok := cluster.GetExpirationTimestamp()
+	if !ok || expiration.IsZero() {
+		return time.Time{}, fmt.Errorf("Cluster '%s' has no expiration time", cluster.ID())
+	}
+	if by <= 0 {
+		return time.Time{}, fmt.Errorf("Expected a positive duration to extend the expiration by")
+	}
+	if p.MaxExtension != 0 && by > p.MaxExtension {
+		return time.Time{}, fmt.Errorf("Expiration can't be extended by more than %s at once", p.MaxExtension)
+	}
+	if expiration.Before(now) {
+		expiration = now
+	}
+	expiration = expiration.Add(by).Round(time.Second)
+	err := p.checkLifetime(cluster, expiration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return expiration, nil
+}
+
+// CheckExpiration checks that the expiration of a cluster can be set to the given time. The
+// extension is measured from the current expiration, or from now if the cluster already expired or
+// doesn't expire. Shortening the expiration is always allowed, even for clusters that already live
+// longer than the maximum lifetime.
+func (p *ExpirationPolicy) CheckExpiration(cluster *cmv1.Cluster, expiration time.Time, now time.Time) error {
+	current, ok := cluster.GetExpirationTimestamp()
+	if ok && !current.IsZero() && expiration.Before(current) {
+		return nil
+	}
+	if p.MaxExtension != 0 {
+		if !ok || current.IsZero() || current.Before(now) {
+			current = now
+		}
+		if expiration.Sub(current) > p.MaxExtension {
+			return fmt.Errorf("Expiration can't be extended by more than %s at once, cluster '%s' can expire "+
+				"by %s at the latest", p.MaxExtension, cluster.ID(),
+				current.Add(p.MaxExtension).UTC().Format(time.RFC3339))
+		}
+	}
+	return p.checkLifetime(cluster, expiration)
+}
+
+// checkLifetime checks that the cluster doesn't live longer than allowed when expiring at the given
+// time
+func (p *ExpirationPolicy) checkLifetime(cluster *cmv1.Cluster, expiration time.Time) error {
+	if p.MaxLifetime == 0 {
+		return nil
+	}
+	maxExpiration := cluster.CreationTimestamp().Add(p.MaxLifetime)
+	if expiration.After(maxExpiration) {
+		return fmt.Errorf("Clusters can't live longer than %s, cluster '%s' must expire by %s",
+			p.MaxLifetime, cluster.ID(), maxExpiration.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// ExpiringClusters returns the clusters that have an expiration time before the given one
+func ExpiringClusters(clusters []*cmv1.Cluster, before time.Time) []*cmv1.Cluster {
+	expiring := []*cmv1.Cluster{}
+	for _, cluster := range clusters {
+		expiration, ok := cluster.GetExpirationTimestamp()
+		if ok && !expiration.IsZero() && expiration.Before(before) {
+			expiring = append(expiring, cluster)
+		}
+	}
+	return expiring
+}
@@ -0,0 +1,117 @@
+package ocm
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Expiration", func() {
+	now := time.Date(2023, time.May, 15, 10, 0, 0, 0, time.UTC)
+
+	buildCluster := func(id string, expiration time.Time) *cmv1.Cluster {
+		builder := cmv1.NewCluster().ID(id).CreationTimestamp(now.Add(-24 * time.Hour))
+		if !expiration.IsZero() {
+			builder = builder.ExpirationTimestamp(expiration)
+		}
+		cluster, err := builder.Build()
+		Expect(err).To(BeNil())
+		return cluster
+	}
+
+	Context("ExtendExpiration", func() {
+		It("Extends from the current expiration", func() {
+			cluster := buildCluster("a", now.Add(2*time.Hour))
+			expiration, err := (&ExpirationPolicy{}).ExtendExpiration(cluster, 48*time.Hour, now)
+			Expect(err).To(BeNil())
+			Expect(expiration).To(Equal(now.Add(50 * time.Hour)))
+		})
+
+		It("Extends from now when the cluster already expired", func() {
+			cluster := buildCluster("a", now.Add(-2*time.Hour))
+			expiration, err := (&ExpirationPolicy{}).ExtendExpiration(cluster, 48*time.Hour, now)
+			Expect(err).To(BeNil())
+			Expect(expiration).To(Equal(now.Add(48 * time.Hour)))
+		})
+
+		It("Fails for clusters that don't expire", func() {
+			cluster := buildCluster("a", time.Time{})
+			_, err := (&ExpirationPolicy{}).ExtendExpiration(cluster, 48*time.Hour, now)
+			Expect(err).To(MatchError("Cluster 'a' has no expiration time"))
+		})
+
+		It("Enforces the maximum extension", func() {
+			cluster := buildCluster("a", now.Add(2*time.Hour))
+			policy := &ExpirationPolicy{MaxExtension: 24 * time.Hour}
+			_, err := policy.ExtendExpiration(cluster, 48*time.Hour, now)
+			Expect(err).To(MatchError("Expiration can't be extended by more than 24h0m0s at once"))
+		})
+
+		It("Enforces the maximum lifetime", func() {
+			cluster := buildCluster("a", now.Add(2*time.Hour))
+			policy := &ExpirationPolicy{MaxLifetime: 7 * 24 * time.Hour}
+			_, err := policy.ExtendExpiration(cluster, 48*time.Hour, now)
+			Expect(err).To(BeNil())
+			_, err = policy.ExtendExpiration(cluster, 7*24*time.Hour, now)
+			Expect(err).To(MatchError(ContainSubstring("cluster 'a' must expire by 2023-05-21T10:00:00Z")))
+		})
+	})
+
+	Context("CheckExpiration", func() {
+		It("Allows any expiration without limits", func() {
+			cluster := buildCluster("a", time.Time{})
+			Expect((&ExpirationPolicy{}).CheckExpiration(cluster, now.Add(365*24*time.Hour), now)).To(Succeed())
+		})
+
+		It("Enforces the maximum extension from the current expiration", func() {
+			cluster := buildCluster("a", now.Add(2*time.Hour))
+			policy := &ExpirationPolicy{MaxExtension: 24 * time.Hour}
+			Expect(policy.CheckExpiration(cluster, now.Add(26*time.Hour), now)).To(Succeed())
+			Expect(policy.CheckExpiration(cluster, now.Add(27*time.Hour), now)).To(
+				MatchError(ContainSubstring("cluster 'a' can expire by 2023-05-16T12:00:00Z at the latest")))
+		})
+
+		It("Enforces the maximum extension from now for clusters that don't expire", func() {
+			cluster := buildCluster("a", time.Time{})
+			policy := &ExpirationPolicy{MaxExtension: 24 * time.Hour}
+			Expect(policy.CheckExpiration(cluster, now.Add(24*time.Hour), now)).To(Succeed())
+			Expect(policy.CheckExpiration(cluster, now.Add(25*time.Hour), now)).NotTo(Succeed())
+		})
+
+		It("Allows shortening the expiration", func() {
+			cluster := buildCluster("a", now.Add(48*time.Hour))
+			policy := &ExpirationPolicy{MaxExtension: 24 * time.Hour, MaxLifetime: 7 * 24 * time.Hour}
+			Expect(policy.CheckExpiration(cluster, now.Add(time.Hour), now)).To(Succeed())
+		})
+
+		It("Allows shortening the expiration of clusters past the maximum lifetime", func() {
+			cluster := buildCluster("a", now.Add(48*time.Hour))
+			policy := &ExpirationPolicy{MaxLifetime: 12 * time.Hour}
+			Expect(policy.CheckExpiration(cluster, now.Add(time.Hour), now)).To(Succeed())
+			Expect(policy.CheckExpiration(cluster, now.Add(72*time.Hour), now)).NotTo(Succeed())
+		})
+
+		It("Enforces the maximum lifetime", func() {
+			cluster := buildCluster("a", now.Add(2*time.Hour))
+			policy := &ExpirationPolicy{MaxLifetime: 7 * 24 * time.Hour}
+			Expect(policy.CheckExpiration(cluster, now.Add(8*24*time.Hour), now)).To(
+				MatchError(ContainSubstring("cluster 'a' must expire by 2023-05-21T10:00:00Z")))
+		})
+	})
+
+	It("Lists the clusters expiring before a time", func() {
+		clusters := []*cmv1.Cluster{
+			buildCluster("a", now.Add(2*time.Hour)),
+			buildCluster("b", now.Add(96*time.Hour)),
+			buildCluster("c", time.Time{}),
+			buildCluster("d", now.Add(-time.Hour)),
+		}
+		expiring := ExpiringClusters(clusters, now.Add(72*time.Hour))
+		Expect(expiring).To(HaveLen(2))
+		Expect(expiring[0].ID()).To(Equal("a"))
+		Expect(expiring[1].ID()).To(Equal("d"))
+	})
+})
@@ -82,6 +82,49 @@ func (c *Client) ListAllOcpGates(version string) (versionGates []*cmv1.VersionGa
 	return
 }
 
+// GetGateAgreements returns the version gates that were already acknowledged for the cluster
+func (c *Client) GetGateAgreements(clusterID string) (agreements []*cmv1.VersionGateAgreement, err error) {
+	collection := c.ocm.ClustersMgmt().V1().
+		Clusters().
+		Cluster(clusterID).
+		GateAgreements()
+	page := 1
+	size := 100
+	for {
+		response, err := collection.List().
+			Page(page).
+			Size(size).
+			Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		agreements = append(agreements, response.Items().Slice()...)
+		if response.Size() < size {
+			break
+		}
+		page++
+	}
+	return
+}
+
+// FilterMissingGates returns the gates that apply to a cluster and that haven't been acknowledged
+// yet. Gates that only apply to STS are ignored for clusters that don't use STS.
+func FilterMissingGates(versionGates []*cmv1.VersionGate, agreements []*cmv1.VersionGateAgreement,
+	isSTS bool) []*cmv1.VersionGate {
+	agreed := map[string]bool{}
+	for _, agreement := range agreements {
+		agreed[agreement.VersionGate().ID()] = true
+	}
+	missingGates := []*cmv1.VersionGate{}
+	for _, gate := range versionGates {
+		if agreed[gate.ID()] || (gate.STSOnly() && !isSTS) {
+			continue
+		}
+		missingGates = append(missingGates, gate)
+	}
+	return missingGates
+}
+
 func (c *Client) AcknowledgeGate(versionGates []*cmv1.VersionGate) (err error) {
 	return
 }
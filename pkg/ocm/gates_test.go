@@ -0,0 +1,47 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Gates", func() {
+	var versionGates []*cmv1.VersionGate
+	var agreements []*cmv1.VersionGateAgreement
+
+	BeforeEach(func() {
+		versionGates = []*cmv1.VersionGate{}
+		for _, builder := range []*cmv1.VersionGateBuilder{
+			cmv1.NewVersionGate().ID("ocp1").STSOnly(false),
+			cmv1.NewVersionGate().ID("ocp2").STSOnly(false),
+			cmv1.NewVersionGate().ID("sts1").STSOnly(true),
+		} {
+			gate, err := builder.Build()
+			Expect(err).To(BeNil())
+			versionGates = append(versionGates, gate)
+		}
+		agreement, err := cmv1.NewVersionGateAgreement().ID("agreement1").
+			VersionGate(cmv1.NewVersionGate().ID("ocp1")).Build()
+		Expect(err).To(BeNil())
+		agreements = []*cmv1.VersionGateAgreement{agreement}
+	})
+
+	It("Returns the gates that weren't acknowledged", func() {
+		missingGates := FilterMissingGates(versionGates, agreements, true)
+		Expect(missingGates).To(HaveLen(2))
+		Expect(missingGates[0].ID()).To(Equal("ocp2"))
+		Expect(missingGates[1].ID()).To(Equal("sts1"))
+	})
+
+	It("Ignores STS gates for clusters that don't use STS", func() {
+		missingGates := FilterMissingGates(versionGates, agreements, false)
+		Expect(missingGates).To(HaveLen(1))
+		Expect(missingGates[0].ID()).To(Equal("ocp2"))
+	})
+
+	It("Returns no gates when all of them were acknowledged", func() {
+		missingGates := FilterMissingGates(versionGates[:1], agreements, true)
+		Expect(missingGates).To(BeEmpty())
+	})
+})
@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper/cron"
+)
+
+// Labels of the subscription of a cluster used to store its hibernation schedule and history
+const (
+	HibernateScheduleLabel  = "rosa_hibernate_schedule"
+	ResumeScheduleLabel     = "rosa_resume_schedule"
+	HibernationHistoryLabel = "rosa_hibernation_history"
+)
+
+// Actions recorded in the hibernation history of a cluster
+const (
+	HibernationActionHibernate = "hibernate"
+	HibernationActionResume    = "resume"
+)
+
+// Number of hibernation events kept in the history of a cluster
+const maxHibernationHistory = 5
+
+type HibernationEvent struct {
+	Action    string
+	Timestamp time.Time
+}
+
+// HibernationSchedule holds the schedules that hibernate and resume a cluster, any of which can be nil
+type HibernationSchedule struct {
+	Hibernate *cron.Schedule
+	Resume    *cron.Schedule
+}
+
+func (c *Client) GetSubscriptionLabel(subscriptionID string, key string) (string, error) {
+	response, err := c.ocm.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).
+		Labels().Labels(key).Get().Send()
+	if err != nil {
+		if response.Status() == http.StatusNotFound {
+			return "", nil
+		}
+		return "", handleErr(response.Error(), err)
+	}
+	return response.Body().Value(), nil
+}
+
+func (c *Client) SetSubscriptionLabel(subscriptionID string, key string, value string) error {
+	current, err := c.GetSubscriptionLabel(subscriptionID, key)
+	if err != nil {
+		return err
+	}
+	label, err := amsv1.NewLabel().Key(key).Value(value).Build()
+	if err != nil {
+		return err
+	}
+	labels := c.ocm.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).Labels()
+	if current == "" {
+		response, err := labels.Add().Body(label).Send()
+		if err != nil {
+			return handleErr(response.Error(), err)
+		}
+		return nil
+	}
+	response, err := labels.Labels(key).Update().Body(label).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteSubscriptionLabel(subscriptionID string, key string) error {
+	response, err := c.ocm.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).
+		Labels().Labels(key).Delete().Send()
+	if err != nil && response.Status() != http.StatusNotFound {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+// GetHibernationSchedule returns the schedules that hibernate and resume the cluster
+func (c *Client) GetHibernationSchedule(cluster *cmv1.Cluster) (*HibernationSchedule, error) {
+	schedule := &HibernationSchedule{}
+	for _, item := range []struct {
+		label    string
+		schedule **cron.Schedule
+	}{
+		{HibernateScheduleLabel, &schedule.Hibernate},
+		{ResumeScheduleLabel, &schedule.Resume},
+	} {
+		value, err := c.GetSubscriptionLabel(cluster.Subscription().ID(), item.label)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		*item.schedule, err = cron.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid schedule in label '%s': %v", item.label, err)
+		}
+	}
+	return schedule, nil
+}
+
+// GetHibernationHistory returns the latest hibernation events of the cluster, newest first
+func (c *Client) GetHibernationHistory(cluster *cmv1.Cluster) ([]HibernationEvent, error) {
+	value, err := c.GetSubscriptionLabel(cluster.Subscription().ID(), HibernationHistoryLabel)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHibernationHistory(value), nil
+}
+
+// RecordHibernationEvent adds an event to the hibernation history of the cluster
+func (c *Client) RecordHibernationEvent(cluster *cmv1.Cluster, action string, timestamp time.Time) error {
+	events, err := c.GetHibernationHistory(cluster)
+	if err != nil {
+		return err
+	}
+	events = append([]HibernationEvent{{Action: action, Timestamp: timestamp}}, events...)
+	return c.SetSubscriptionLabel(cluster.Subscription().ID(), HibernationHistoryLabel,
+		FormatHibernationHistory(events))
+}
+
+// ParseHibernationHistory parses the value of the history label, a comma separated list of
+// events like 'hibernate@2023-05-15T20:00:00Z'. Events that can't be parsed are ignored.
+func ParseHibernationHistory(value string) []HibernationEvent {
+	events := []HibernationEvent{}
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.SplitN(item, "@", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			continue
+		}
+		events = append(events, HibernationEvent{Action: parts[0], Timestamp: timestamp})
+	}
+	return events
+}
+
+// FormatHibernationHistory formats the latest events to be stored in the history label
+func FormatHibernationHistory(events []HibernationEvent) string {
+	if len(events) > maxHibernationHistory {
+		events = events[:maxHibernationHistory]
+	}
+	items := make([]string, len(events))
+	for i, event := range events {
+		items[i] = fmt.Sprintf("%s@%s", event.Action, event.Timestamp.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(items, ",")
+}
+
+// IsEmpty checks if the cluster has no hibernation schedule
+func (s *HibernationSchedule) IsEmpty() bool {
+	return s.Hibernate == nil && s.Resume == nil
+}
+
+// Hibernated returns whether the cluster should be hibernated at the given time, which is the
+// case when the last run of the hibernation schedule is more recent than the last run of the
+// resume schedule. The second value is false when neither schedule ran in the last year.
+func (s *HibernationSchedule) Hibernated(now time.Time) (bool, bool) {
+	var lastHibernate, lastResume time.Time
+	if s.Hibernate != nil {
+		lastHibernate = s.Hibernate.Previous(now)
+	}
+	if s.Resume != nil {
+		lastResume = s.Resume.Previous(now)
+	}
+	if lastHibernate.IsZero() && lastResume.IsZero() {
+		return false, false
+	}
+	return lastHibernate.After(lastResume), true
+}
+
+// NextTransition returns the next action of the schedule after the given time and when it runs.
+// The action is empty when neither schedule runs in the next year.
+func (s *HibernationSchedule) NextTransition(now time.Time) (string, time.Time) {
+	var nextHibernate, nextResume time.Time
+	if s.Hibernate != nil {
+		nextHibernate = s.Hibernate.Next(now)
+	}
+	if s.Resume != nil {
+		nextResume = s.Resume.Next(now)
+	}
+	switch {
+	case nextHibernate.IsZero() && nextResume.IsZero():
+		return "", time.Time{}
+	case nextResume.IsZero() || (!nextHibernate.IsZero() && nextHibernate.Before(nextResume)):
+		return HibernationActionHibernate, nextHibernate
+	default:
+		return HibernationActionResume, nextResume
+	}
+}
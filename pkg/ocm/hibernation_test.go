@@ -0,0 +1,82 @@
+package ocm
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/helper/cron"
+)
+
+var _ = Describe("Hibernation", func() {
+	// Monday
+	now := time.Date(2023, time.May, 15, 10, 30, 0, 0, time.UTC)
+
+	var schedule *HibernationSchedule
+
+	BeforeEach(func() {
+		hibernate, err := cron.Parse("0 20 * * 1-5")
+		Expect(err).To(BeNil())
+		resume, err := cron.Parse("0 8 * * 1-5")
+		Expect(err).To(BeNil())
+		schedule = &HibernationSchedule{Hibernate: hibernate, Resume: resume}
+	})
+
+	It("Is not hibernated during working hours", func() {
+		hibernated, ok := schedule.Hibernated(now)
+		Expect(ok).To(BeTrue())
+		Expect(hibernated).To(BeFalse())
+	})
+
+	It("Is hibernated at night", func() {
+		hibernated, ok := schedule.Hibernated(now.Add(12 * time.Hour))
+		Expect(ok).To(BeTrue())
+		Expect(hibernated).To(BeTrue())
+	})
+
+	It("Is hibernated during the weekend", func() {
+		hibernated, ok := schedule.Hibernated(now.Add(-2 * 24 * time.Hour))
+		Expect(ok).To(BeTrue())
+		Expect(hibernated).To(BeTrue())
+	})
+
+	It("Returns the next transition", func() {
+		action, at := schedule.NextTransition(now)
+		Expect(action).To(Equal(HibernationActionHibernate))
+		Expect(at).To(Equal(time.Date(2023, time.May, 15, 20, 0, 0, 0, time.UTC)))
+
+		action, at = schedule.NextTransition(at)
+		Expect(action).To(Equal(HibernationActionResume))
+		Expect(at).To(Equal(time.Date(2023, time.May, 16, 8, 0, 0, 0, time.UTC)))
+	})
+
+	It("Supports schedules that only hibernate", func() {
+		schedule.Resume = nil
+		hibernated, ok := schedule.Hibernated(now)
+		Expect(ok).To(BeTrue())
+		Expect(hibernated).To(BeTrue())
+		action, _ := schedule.NextTransition(now)
+		Expect(action).To(Equal(HibernationActionHibernate))
+	})
+
+	It("Keeps the latest events of the history", func() {
+		events := []HibernationEvent{}
+		for i := 0; i < 7; i++ {
+			events = append(events, HibernationEvent{
+				Action:    HibernationActionHibernate,
+				Timestamp: now.Add(-time.Duration(i) * time.Hour),
+			})
+		}
+		value := FormatHibernationHistory(events)
+		Expect(value).To(HavePrefix("hibernate@2023-05-15T10:30:00Z,hibernate@2023-05-15T09:30:00Z"))
+		parsed := ParseHibernationHistory(value)
+		Expect(parsed).To(HaveLen(5))
+		Expect(parsed[0].Timestamp).To(Equal(now))
+	})
+
+	It("Ignores invalid history events", func() {
+		Expect(ParseHibernationHistory("")).To(BeEmpty())
+		Expect(ParseHibernationHistory("resume@yesterday,resume@2023-05-15T10:30:00Z")).To(HaveLen(1))
+	})
+})
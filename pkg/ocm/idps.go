@@ -61,6 +61,19 @@ func (c *Client) CreateIdentityProvider(clusterID string, idp *cmv1.IdentityProv
 	return response.Body(), nil
 }
 
+func (c *Client) UpdateIdentityProvider(clusterID string, idp *cmv1.IdentityProvider) (*cmv1.IdentityProvider,
+	error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idp.ID()).
+		Update().Body(idp).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
 func (c *Client) GetHTPasswdUserList(clusterID, htpasswdIDPId string) (*cmv1.HTPasswdUserList, error) {
 	listResponse, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 		IdentityProviders().IdentityProvider(htpasswdIDPId).HtpasswdUsers().List().Send()
@@ -83,6 +96,26 @@ func (c *Client) AddHTPasswdUser(username, password, clusterID, idpID string) er
 	return nil
 }
 
+func (c *Client) ImportHTPasswdUsers(clusterID, idpID string, users []*cmv1.HTPasswdUser) error {
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).HtpasswdUsers().Import().Items(users).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+func (c *Client) UpdateHTPasswdUserPassword(clusterID, idpID, userID, password string) error {
+	htpasswdUser, _ := cmv1.NewHTPasswdUser().Password(password).Build()
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).HtpasswdUsers().
+		HtpasswdUser(userID).Update().Body(htpasswdUser).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
 func (c *Client) DeleteHTPasswdUser(username, clusterID string, htpasswdIDP *cmv1.IdentityProvider) error {
 	var userID string
 
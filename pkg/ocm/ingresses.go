@@ -17,9 +17,52 @@ limitations under the License.
 package ocm
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// Values supported by the router options of ingresses
+const (
+	LoadBalancerTypeClassic = "classic"
+	LoadBalancerTypeNLB     = "nlb"
+
+	WildcardPolicyAllowed    = "WildcardsAllowed"
+	WildcardPolicyDisallowed = "WildcardsDisallowed"
+
+	NamespaceOwnershipPolicyStrict                = "Strict"
+	NamespaceOwnershipPolicyInterNamespaceAllowed = "InterNamespaceAllowed"
 )
 
+var LoadBalancerTypes = []string{LoadBalancerTypeClassic, LoadBalancerTypeNLB}
+var WildcardPolicies = []string{WildcardPolicyDisallowed, WildcardPolicyAllowed}
+var NamespaceOwnershipPolicies = []string{
+	NamespaceOwnershipPolicyStrict,
+	NamespaceOwnershipPolicyInterNamespaceAllowed,
+}
+
+// IngressRouterOptions holds the router options of an ingress. The clusters management types
+// vendored here don't have these attributes yet, so they are sent and read as raw JSON next to
+// the attributes of the ingress. Options that are nil are left unchanged.
+type IngressRouterOptions struct {
+	ExcludedNamespaces            *[]string `json:"excluded_namespaces,omitempty"`
+	RouteWildcardPolicy           *string   `json:"route_wildcard_policy,omitempty"`
+	RouteNamespaceOwnershipPolicy *string   `json:"route_namespace_ownership_policy,omitempty"`
+	LoadBalancerType              *string   `json:"load_balancer_type,omitempty"`
+	ClusterRoutesHostname         *string   `json:"cluster_routes_hostname,omitempty"`
+	ClusterRoutesTLSSecretRef     *string   `json:"cluster_routes_tls_secret_ref,omitempty"`
+}
+
+// IsEmpty returns true when none of the router options is set
+func (o *IngressRouterOptions) IsEmpty() bool {
+	return o == nil || *o == IngressRouterOptions{}
+}
+
 func (c *Client) GetIngresses(clusterID string) ([]*cmv1.Ingress, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -33,28 +76,102 @@ func (c *Client) GetIngresses(clusterID string) ([]*cmv1.Ingress, error) {
 	return response.Items().Slice(), nil
 }
 
-func (c *Client) CreateIngress(clusterID string, ingress *cmv1.Ingress) (*cmv1.Ingress, error) {
-	response, err := c.ocm.ClustersMgmt().V1().
-		Clusters().Cluster(clusterID).
-		Ingresses().
-		Add().Body(ingress).
-		Send()
+func (c *Client) CreateIngress(clusterID string, ingress *cmv1.Ingress,
+	options *IngressRouterOptions) (*cmv1.Ingress, error) {
+	if options.IsEmpty() {
+		response, err := c.ocm.ClustersMgmt().V1().
+			Clusters().Cluster(clusterID).
+			Ingresses().
+			Add().Body(ingress).
+			Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		return response.Body(), nil
+	}
+	return c.sendIngress(c.ocm.Post().Path(ingressesPath(clusterID)), ingress, options)
+}
+
+func (c *Client) UpdateIngress(clusterID string, ingress *cmv1.Ingress,
+	options *IngressRouterOptions) (*cmv1.Ingress, error) {
+	if options.IsEmpty() {
+		response, err := c.ocm.ClustersMgmt().V1().
+			Clusters().Cluster(clusterID).
+			Ingresses().Ingress(ingress.ID()).
+			Update().Body(ingress).
+			Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		return response.Body(), nil
+	}
+	return c.sendIngress(c.ocm.Patch().Path(ingressesPath(clusterID)+"/"+ingress.ID()), ingress, options)
+}
+
+// GetIngressRouterOptions returns the router options of an ingress
+func (c *Client) GetIngressRouterOptions(clusterID string, ingressID string) (*IngressRouterOptions, error) {
+	response, err := c.ocm.Get().Path(ingressesPath(clusterID) + "/" + ingressID).Send()
 	if err != nil {
-		return nil, handleErr(response.Error(), err)
+		return nil, err
+	}
+	if response.Status() >= http.StatusBadRequest {
+		return nil, responseErr(response)
 	}
-	return response.Body(), nil
+	options := &IngressRouterOptions{}
+	err = json.Unmarshal(response.Bytes(), options)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read router options of ingress '%s': %v", ingressID, err)
+	}
+	return options, nil
 }
 
-func (c *Client) UpdateIngress(clusterID string, ingress *cmv1.Ingress) (*cmv1.Ingress, error) {
-	response, err := c.ocm.ClustersMgmt().V1().
-		Clusters().Cluster(clusterID).
-		Ingresses().Ingress(ingress.ID()).
-		Update().Body(ingress).
-		Send()
+func (c *Client) sendIngress(request *sdk.Request, ingress *cmv1.Ingress,
+	options *IngressRouterOptions) (*cmv1.Ingress, error) {
+	buffer := &bytes.Buffer{}
+	err := cmv1.MarshalIngress(ingress, buffer)
 	if err != nil {
-		return nil, handleErr(response.Error(), err)
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	err = json.Unmarshal(buffer.Bytes(), &body)
+	if err != nil {
+		return nil, err
+	}
+	optionsData, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(optionsData, &body)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := request.Bytes(data).Send()
+	if err != nil {
+		return nil, err
+	}
+	if response.Status() >= http.StatusBadRequest {
+		return nil, responseErr(response)
+	}
+	return cmv1.UnmarshalIngress(response.Bytes())
+}
+
+func ingressesPath(clusterID string) string {
+	return fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/ingresses", clusterID)
+}
+
+// responseErr converts the body of a failed raw request into the same errors returned by the
+// typed clients
+func responseErr(response *sdk.Response) error {
+	ocmErr, err := ocmerrors.UnmarshalErrorStatus(response.Bytes(), response.Status())
+	if err != nil {
+		return fmt.Errorf("Request failed with status %d: %s", response.Status(), response.String())
 	}
-	return response.Body(), nil
+	return handleErr(ocmErr, ocmErr)
 }
 
 func (c *Client) DeleteIngress(clusterID string, ingressID string) error {
@@ -19,6 +19,8 @@ package ocm
 import (
 	"errors"
 	"fmt"
+	"math"
+	"regexp"
 	"strings"
 
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
@@ -28,6 +30,14 @@ import (
 
 const AcceleratedComputing = "accelerated_computing"
 
+const (
+	ArchitectureAMD64 = "amd64"
+	ArchitectureARM64 = "arm64"
+)
+
+// Graviton instance families carry a 'g' right after the generation number, e.g. 'm6g' or 'c7gn'
+var armFamilyRE = regexp.MustCompile(`^[a-z]+[0-9]+g`)
+
 func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderData) (MachineTypeList, error) {
 	collection := c.ocm.ClustersMgmt().V1().AWSInquiries().MachineTypes()
 	page := 1
@@ -115,6 +125,46 @@ func (mt MachineType) HasQuota(multiAZ bool) bool {
 	return mt.MachineType.Category() != AcceleratedComputing || mt.availableQuota > getDefaultNodes(multiAZ)
 }
 
+// Family returns the instance family of the machine type, e.g. 'm5' for 'm5.xlarge'
+func (mt MachineType) Family() string {
+	return strings.Split(mt.MachineType.ID(), ".")[0]
+}
+
+// Architecture returns the CPU architecture of the machine type
+func (mt MachineType) Architecture() string {
+	if armFamilyRE.MatchString(mt.Family()) {
+		return ArchitectureARM64
+	}
+	return ArchitectureAMD64
+}
+
+// IsGPU returns true when the machine type has accelerators attached
+func (mt MachineType) IsGPU() bool {
+	return mt.MachineType.Category() == AcceleratedComputing
+}
+
+// CPUCores returns the number of vCPUs of the machine type
+func (mt MachineType) CPUCores() int {
+	return int(mt.MachineType.CPU().Value())
+}
+
+// MemoryGiB returns the memory of the machine type in GiB
+func (mt MachineType) MemoryGiB() float64 {
+	memory := mt.MachineType.Memory()
+	exponent := 0
+	switch memory.Unit() {
+	case "KiB":
+		exponent = 1
+	case "MiB":
+		exponent = 2
+	case "GiB":
+		exponent = 3
+	case "TiB":
+		exponent = 4
+	}
+	return memory.Value() * math.Pow(1024, float64(exponent)) / math.Pow(1024, 3)
+}
+
 // GetAvailableMachineTypesInRegion get the supported machine type in the region.
 // The function triggers the 'api/clusters_mgmt/v1/aws_inquiries/machine_types'
 // and passes a role ARN for STS clusters or access keys for non-STS clusters.
@@ -136,6 +136,31 @@ func (c *Client) ScheduleHypershiftControlPlaneUpgrade(clusterID string,
 	return nil
 }
 
+func (c *Client) UpdateUpgradePolicy(clusterID string, upgradePolicy *cmv1.UpgradePolicy) error {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		UpgradePolicies().UpgradePolicy(upgradePolicy.ID()).
+		Update().Body(upgradePolicy).
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+func (c *Client) UpdateControlPlaneUpgradePolicy(clusterID string,
+	upgradePolicy *cmv1.ControlPlaneUpgradePolicy) error {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).ControlPlane().
+		UpgradePolicies().ControlPlaneUpgradePolicy(upgradePolicy.ID()).
+		Update().Body(upgradePolicy).
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
 func (c *Client) CancelUpgrade(clusterID string) (bool, error) {
 	scheduledUpgrade, _, err := c.GetScheduledUpgrade(clusterID)
 	if err != nil || scheduledUpgrade == nil {
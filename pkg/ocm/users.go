@@ -17,6 +17,7 @@ limitations under the License.
 package ocm
 
 import (
+	"fmt"
 	"net/http"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -78,3 +79,16 @@ func (c *Client) DeleteUser(clusterID string, group string, username string) err
 	}
 	return nil
 }
+
+// Groups of the cluster that users can be granted
+var UserRoles = []string{"cluster-admins", "dedicated-admins"}
+
+// GetUserRole returns the group of the given role, which can also be named in singular form
+func GetUserRole(role string) (string, error) {
+	for _, userRole := range UserRoles {
+		if role == userRole || role+"s" == userRole {
+			return userRole, nil
+		}
+	}
+	return "", fmt.Errorf("Expected at least one of %s", UserRoles)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ghodss/yaml"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/pricing"
 	"gitlab.com/c0b/go-ordered-json"
 )
 
@@ -98,6 +99,13 @@ func Print(resource interface{}) error {
 				}
 			}
 		}
+	case "*pricing.Estimate":
+		if estimate, ok := resource.(*pricing.Estimate); ok {
+			err := json.NewEncoder(&b).Encode(estimate)
+			if err != nil {
+				return err
+			}
+		}
 	case "object.Object", "map[string]interface {}":
 		{
 			reqBodyBytes := new(bytes.Buffer)
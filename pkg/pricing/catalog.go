@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the offline AWS price catalog used to estimate the cost of clusters and
+// machine pools. The catalog is bundled with the binary so that estimates work without access
+// to the AWS pricing API.
+
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openshift/rosa/assets"
+)
+
+const catalogPath = "templates/pricing/aws.json"
+
+type ServiceFees struct {
+	ClassicClusterHourly     float64 `json:"classicClusterHourly"`
+	HostedControlPlaneHourly float64 `json:"hostedControlPlaneHourly"`
+	WorkerVCPUHourly         float64 `json:"workerVCPUHourly"`
+}
+
+type NodeDefaults struct {
+	InstanceType    string `json:"instanceType"`
+	Replicas        int    `json:"replicas"`
+	MultiAZReplicas int    `json:"multiAZReplicas,omitempty"`
+}
+
+type InstanceType struct {
+	VCPU      int     `json:"vcpu"`
+	MemoryGiB float64 `json:"memoryGiB"`
+	OnDemand  float64 `json:"onDemand"`
+}
+
+// Catalog holds on-demand prices for the default region together with the multipliers used to
+// derive the prices of the other regions and the expected spot discount of each instance family.
+type Catalog struct {
+	Currency       string                  `json:"currency"`
+	HoursPerMonth  float64                 `json:"hoursPerMonth"`
+	DefaultRegion  string                  `json:"defaultRegion"`
+	ServiceFees    ServiceFees             `json:"serviceFees"`
+	ControlPlane   NodeDefaults            `json:"controlPlane"`
+	Infra          NodeDefaults            `json:"infra"`
+	SpotPriceRatio map[string]float64      `json:"spotPriceRatio"`
+	Regions        map[string]float64      `json:"regions"`
+	InstanceTypes  map[string]InstanceType `json:"instanceTypes"`
+}
+
+// LoadCatalog reads the price catalog bundled with the binary
+func LoadCatalog() (*Catalog, error) {
+	data, err := assets.Asset(catalogPath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read price catalog: %s", err)
+	}
+	return ParseCatalog(data)
+}
+
+func ParseCatalog(data []byte) (*Catalog, error) {
+	catalog := &Catalog{}
+	err := json.Unmarshal(data, catalog)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse price catalog: %s", err)
+	}
+	if catalog.HoursPerMonth <= 0 {
+		return nil, fmt.Errorf("Price catalog must define a positive number of hours per month")
+	}
+	return catalog, nil
+}
+
+// OnDemandPrice returns the hourly on-demand price of the instance type in the region
+func (c *Catalog) OnDemandPrice(region string, instanceType string) (float64, error) {
+	instance, ok := c.InstanceTypes[instanceType]
+	if !ok {
+		return 0, fmt.Errorf("No price available for instance type '%s'", instanceType)
+	}
+	multiplier, ok := c.Regions[region]
+	if !ok {
+		return 0, fmt.Errorf("No price available for region '%s'", region)
+	}
+	return instance.OnDemand * multiplier, nil
+}
+
+// SpotPrice returns the expected hourly spot price of the instance type in the region. When a
+// max price is given the estimate never exceeds it.
+func (c *Catalog) SpotPrice(region string, instanceType string, maxPrice *float64) (float64, error) {
+	onDemand, err := c.OnDemandPrice(region, instanceType)
+	if err != nil {
+		return 0, err
+	}
+	price := onDemand * c.spotRatio(instanceType)
+	if maxPrice != nil && *maxPrice < price {
+		price = *maxPrice
+	}
+	return price, nil
+}
+
+// VCPU returns the number of virtual CPUs of the instance type
+func (c *Catalog) VCPU(instanceType string) (int, error) {
+	instance, ok := c.InstanceTypes[instanceType]
+	if !ok {
+		return 0, fmt.Errorf("No price available for instance type '%s'", instanceType)
+	}
+	return instance.VCPU, nil
+}
+
+func (c *Catalog) spotRatio(instanceType string) float64 {
+	family := strings.Split(instanceType, ".")[0]
+	if ratio, ok := c.SpotPriceRatio[family]; ok {
+		return ratio
+	}
+	return c.SpotPriceRatio["default"]
+}
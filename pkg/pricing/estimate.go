@@ -0,0 +1,202 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricing
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+type Role string
+
+const (
+	ControlPlaneRole Role = "control-plane"
+	InfraRole        Role = "infra"
+	ComputeRole      Role = "compute"
+)
+
+// NodeGroup describes a set of identical nodes, such as a machine pool or node pool
+type NodeGroup struct {
+	Name         string
+	Role         Role
+	InstanceType string
+	MinReplicas  int
+	MaxReplicas  int
+	Spot         bool
+	SpotMaxPrice *float64
+}
+
+type LineItem struct {
+	Name          string  `json:"name"`
+	Role          Role    `json:"role"`
+	InstanceType  string  `json:"instanceType"`
+	MinReplicas   int     `json:"minReplicas"`
+	MaxReplicas   int     `json:"maxReplicas"`
+	Spot          bool    `json:"spot"`
+	InstancePrice float64 `json:"instancePrice"`
+	HourlyMin     float64 `json:"hourlyMin"`
+	HourlyMax     float64 `json:"hourlyMax"`
+}
+
+type Estimate struct {
+	Region              string     `json:"region"`
+	Currency            string     `json:"currency"`
+	HoursPerMonth       float64    `json:"hoursPerMonth"`
+	Items               []LineItem `json:"items"`
+	ServiceFeeHourlyMin float64    `json:"serviceFeeHourlyMin"`
+	ServiceFeeHourlyMax float64    `json:"serviceFeeHourlyMax"`
+	HourlyMin           float64    `json:"hourlyMin"`
+	HourlyMax           float64    `json:"hourlyMax"`
+	MonthlyMin          float64    `json:"monthlyMin"`
+	MonthlyMax          float64    `json:"monthlyMax"`
+}
+
+// ClusterNodeGroups returns the nodes that ROSA provisions for a cluster in addition to the
+// compute nodes requested by the user. Hosted control planes don't run on customer nodes.
+func (c *Catalog) ClusterNodeGroups(multiAZ bool, hostedCP bool) []NodeGroup {
+	if hostedCP {
+		return []NodeGroup{}
+	}
+	infraReplicas := c.Infra.Replicas
+	if multiAZ && c.Infra.MultiAZReplicas > 0 {
+		infraReplicas = c.Infra.MultiAZReplicas
+	}
+	return []NodeGroup{
+		{
+			Name:         "control-plane",
+			Role:         ControlPlaneRole,
+			InstanceType: c.ControlPlane.InstanceType,
+			MinReplicas:  c.ControlPlane.Replicas,
+			MaxReplicas:  c.ControlPlane.Replicas,
+		},
+		{
+			Name:         "infra",
+			Role:         InfraRole,
+			InstanceType: c.Infra.InstanceType,
+			MinReplicas:  infraReplicas,
+			MaxReplicas:  infraReplicas,
+		},
+	}
+}
+
+// Estimate calculates the hourly and monthly cost of the node groups in the region. The ROSA
+// service fee is charged per vCPU of compute nodes, and the per-cluster fee is only added
+// when estimating a whole cluster.
+func (c *Catalog) Estimate(region string, hostedCP bool, includeClusterFee bool,
+	groups []NodeGroup) (*Estimate, error) {
+	estimate := &Estimate{
+		Region:        region,
+		Currency:      c.Currency,
+		HoursPerMonth: c.HoursPerMonth,
+		Items:         []LineItem{},
+	}
+	for _, group := range groups {
+		var price float64
+		var err error
+		if group.Spot {
+			price, err = c.SpotPrice(region, group.InstanceType, group.SpotMaxPrice)
+		} else {
+			price, err = c.OnDemandPrice(region, group.InstanceType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		item := LineItem{
+			Name:          group.Name,
+			Role:          group.Role,
+			InstanceType:  group.InstanceType,
+			MinReplicas:   group.MinReplicas,
+			MaxReplicas:   group.MaxReplicas,
+			Spot:          group.Spot,
+			InstancePrice: price,
+			HourlyMin:     price * float64(group.MinReplicas),
+			HourlyMax:     price * float64(group.MaxReplicas),
+		}
+		estimate.Items = append(estimate.Items, item)
+		estimate.HourlyMin += item.HourlyMin
+		estimate.HourlyMax += item.HourlyMax
+
+		if group.Role == ComputeRole {
+			vcpu, err := c.VCPU(group.InstanceType)
+			if err != nil {
+				return nil, err
+			}
+			fee := c.ServiceFees.WorkerVCPUHourly * float64(vcpu)
+			estimate.ServiceFeeHourlyMin += fee * float64(group.MinReplicas)
+			estimate.ServiceFeeHourlyMax += fee * float64(group.MaxReplicas)
+		}
+	}
+	if includeClusterFee {
+		clusterFee := c.ServiceFees.ClassicClusterHourly
+		if hostedCP {
+			clusterFee = c.ServiceFees.HostedControlPlaneHourly
+		}
+		estimate.ServiceFeeHourlyMin += clusterFee
+		estimate.ServiceFeeHourlyMax += clusterFee
+	}
+	estimate.HourlyMin += estimate.ServiceFeeHourlyMin
+	estimate.HourlyMax += estimate.ServiceFeeHourlyMax
+	estimate.MonthlyMin = estimate.HourlyMin * c.HoursPerMonth
+	estimate.MonthlyMax = estimate.HourlyMax * c.HoursPerMonth
+	return estimate, nil
+}
+
+// Print writes the estimate as a table
+func (e *Estimate) Print(w io.Writer) {
+	hoursPerMonth := e.HoursPerMonth
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ROLE\tNAME\tINSTANCE TYPE\tREPLICAS\tMARKET\tHOURLY\tMONTHLY\n")
+	for _, item := range e.Items {
+		market := "on-demand"
+		if item.Spot {
+			market = "spot"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			item.Role,
+			item.Name,
+			item.InstanceType,
+			formatRange(item.MinReplicas, item.MaxReplicas),
+			market,
+			e.formatPrice(item.HourlyMin, item.HourlyMax),
+			e.formatPrice(item.HourlyMin*hoursPerMonth, item.HourlyMax*hoursPerMonth),
+		)
+	}
+	fmt.Fprintf(writer, "service fee\t\t\t\t\t%s\t%s\n",
+		e.formatPrice(e.ServiceFeeHourlyMin, e.ServiceFeeHourlyMax),
+		e.formatPrice(e.ServiceFeeHourlyMin*hoursPerMonth, e.ServiceFeeHourlyMax*hoursPerMonth),
+	)
+	fmt.Fprintf(writer, "total\t\t\t\t\t%s\t%s\n",
+		e.formatPrice(e.HourlyMin, e.HourlyMax),
+		e.formatPrice(e.MonthlyMin, e.MonthlyMax),
+	)
+	writer.Flush()
+}
+
+func (e *Estimate) formatPrice(min float64, max float64) string {
+	if fmt.Sprintf("%.2f", min) == fmt.Sprintf("%.2f", max) {
+		return fmt.Sprintf("%.2f %s", min, e.Currency)
+	}
+	return fmt.Sprintf("%.2f-%.2f %s", min, max, e.Currency)
+}
+
+func formatRange(min int, max int) string {
+	if min == max {
+		return fmt.Sprintf("%d", min)
+	}
+	return fmt.Sprintf("%d-%d", min, max)
+}
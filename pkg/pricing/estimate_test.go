@@ -0,0 +1,109 @@
+package pricing
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Estimate", func() {
+	var catalog *Catalog
+
+	BeforeEach(func() {
+		var err error
+		catalog, err = ParseCatalog([]byte(`{
+			"currency": "USD",
+			"hoursPerMonth": 730,
+			"serviceFees": {
+				"classicClusterHourly": 0.03,
+				"hostedControlPlaneHourly": 0.25,
+				"workerVCPUHourly": 0.05
+			},
+			"controlPlane": {"instanceType": "m5.2xlarge", "replicas": 3},
+			"infra": {"instanceType": "r5.xlarge", "replicas": 2, "multiAZReplicas": 3},
+			"spotPriceRatio": {"default": 0.5, "m5": 0.25},
+			"regions": {"us-east-1": 1.0, "eu-west-1": 2.0},
+			"instanceTypes": {
+				"m5.xlarge": {"vcpu": 4, "memoryGiB": 16, "onDemand": 0.2},
+				"m5.2xlarge": {"vcpu": 8, "memoryGiB": 32, "onDemand": 0.4},
+				"r5.xlarge": {"vcpu": 4, "memoryGiB": 32, "onDemand": 0.25}
+			}
+		}`))
+		Expect(err).To(BeNil())
+	})
+
+	It("Loads the bundled catalog", func() {
+		bundled, err := LoadCatalog()
+		Expect(err).To(BeNil())
+		_, err = bundled.OnDemandPrice(bundled.DefaultRegion, "m5.xlarge")
+		Expect(err).To(BeNil())
+	})
+
+	It("Applies the regional multiplier", func() {
+		price, err := catalog.OnDemandPrice("eu-west-1", "m5.xlarge")
+		Expect(err).To(BeNil())
+		Expect(price).To(BeNumerically("~", 0.4))
+	})
+
+	It("Fails for unknown instance types and regions", func() {
+		_, err := catalog.OnDemandPrice("us-east-1", "x1.32xlarge")
+		Expect(err).To(MatchError("No price available for instance type 'x1.32xlarge'"))
+		_, err = catalog.OnDemandPrice("mars-north-1", "m5.xlarge")
+		Expect(err).To(MatchError("No price available for region 'mars-north-1'"))
+	})
+
+	It("Caps spot prices at the max price", func() {
+		price, err := catalog.SpotPrice("us-east-1", "m5.xlarge", nil)
+		Expect(err).To(BeNil())
+		Expect(price).To(BeNumerically("~", 0.05))
+		maxPrice := 0.01
+		price, err = catalog.SpotPrice("us-east-1", "m5.xlarge", &maxPrice)
+		Expect(err).To(BeNil())
+		Expect(price).To(BeNumerically("~", 0.01))
+		price, err = catalog.SpotPrice("us-east-1", "r5.xlarge", nil)
+		Expect(err).To(BeNil())
+		Expect(price).To(BeNumerically("~", 0.125))
+	})
+
+	It("Adds control plane and infra nodes for classic clusters only", func() {
+		Expect(catalog.ClusterNodeGroups(false, true)).To(BeEmpty())
+		groups := catalog.ClusterNodeGroups(true, false)
+		Expect(groups).To(HaveLen(2))
+		Expect(groups[0].MinReplicas).To(Equal(3))
+		Expect(groups[1].MinReplicas).To(Equal(3))
+		Expect(catalog.ClusterNodeGroups(false, false)[1].MinReplicas).To(Equal(2))
+	})
+
+	It("Estimates a classic cluster with autoscaling compute nodes", func() {
+		groups := append(catalog.ClusterNodeGroups(false, false), NodeGroup{
+			Name:         "worker",
+			Role:         ComputeRole,
+			InstanceType: "m5.xlarge",
+			MinReplicas:  2,
+			MaxReplicas:  4,
+		})
+		estimate, err := catalog.Estimate("us-east-1", false, true, groups)
+		Expect(err).To(BeNil())
+		Expect(estimate.Items).To(HaveLen(3))
+		// Service fee: 0.03 cluster fee + 0.05 * 4 vCPU per compute node
+		Expect(estimate.ServiceFeeHourlyMin).To(BeNumerically("~", 0.03+0.2*2))
+		Expect(estimate.ServiceFeeHourlyMax).To(BeNumerically("~", 0.03+0.2*4))
+		// Nodes: 3 * 0.4 control plane + 2 * 0.25 infra + 0.2 per compute node
+		Expect(estimate.HourlyMin).To(BeNumerically("~", 1.2+0.5+0.4+0.43))
+		Expect(estimate.HourlyMax).To(BeNumerically("~", 1.2+0.5+0.8+0.83))
+		Expect(estimate.MonthlyMin).To(BeNumerically("~", estimate.HourlyMin*730))
+	})
+
+	It("Estimates a spot machine pool without the cluster fee", func() {
+		estimate, err := catalog.Estimate("us-east-1", true, false, []NodeGroup{{
+			Name:         "mp-1",
+			Role:         ComputeRole,
+			InstanceType: "m5.xlarge",
+			MinReplicas:  2,
+			MaxReplicas:  2,
+			Spot:         true,
+		}})
+		Expect(err).To(BeNil())
+		Expect(estimate.HourlyMin).To(BeNumerically("~", 0.05*2+0.2*2))
+		Expect(estimate.HourlyMax).To(Equal(estimate.HourlyMin))
+	})
+})